@@ -0,0 +1,107 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// AdminPermissionsClientId is the client id of the client that Keycloak creates to hold the authorization
+// server of fine-grained admin permissions v2 once they are enabled for a realm.
+const AdminPermissionsClientId = "admin-permissions"
+
+// AdminPermissionsResourceTypes lists the resource types supported by fine-grained admin permissions v2,
+// along with the scopes that can be granted for each of them.
+var AdminPermissionsResourceTypes = map[string][]string{
+	"Users":   {"view", "manage", "impersonate", "map-roles", "manage-group-membership"},
+	"Groups":  {"view", "manage", "view-members", "manage-members", "manage-membership", "impersonate-members"},
+	"Clients": {"view", "manage", "configure", "map-roles", "map-roles-composite", "map-roles-client-scope"},
+	"Roles":   {"map-role", "map-role-composite", "map-role-client-scope"},
+}
+
+type AdminPermission struct {
+	Id               string   `json:"id,omitempty"`
+	RealmId          string   `json:"-"`
+	ResourceServerId string   `json:"-"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	DecisionStrategy string   `json:"decisionStrategy"`
+	ResourceType     string   `json:"resourceType"`
+	Resources        []string `json:"resources"`
+	Scopes           []string `json:"scopes"`
+	Policies         []string `json:"policies"`
+}
+
+func (keycloakClient *KeycloakClient) GetAdminPermissionsClient(ctx context.Context, realmId string) (*OpenidClient, error) {
+	return keycloakClient.GetOpenidClientByClientId(ctx, realmId, AdminPermissionsClientId)
+}
+
+func (keycloakClient *KeycloakClient) NewAdminPermission(ctx context.Context, permission *AdminPermission) error {
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/permission/scope", permission.RealmId, permission.ResourceServerId), permission)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, permission)
+}
+
+func (keycloakClient *KeycloakClient) GetAdminPermission(ctx context.Context, realmId, resourceServerId, id string) (*AdminPermission, error) {
+	permission := AdminPermission{
+		RealmId:          realmId,
+		ResourceServerId: resourceServerId,
+	}
+
+	var policies []OpenidClientAuthorizationPolicy
+	var resources []OpenidClientAuthorizationResource
+	var scopes []OpenidClientAuthorizationScope
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/permission/scope/%s", realmId, resourceServerId, id), &permission, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/policy/%s/associatedPolicies", realmId, resourceServerId, id), &policies, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/permission/%s/resources", realmId, resourceServerId, id), &resources, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/permission/%s/scopes", realmId, resourceServerId, id), &scopes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	permission.Policies = nil
+	for _, policy := range policies {
+		permission.Policies = append(permission.Policies, policy.Id)
+	}
+
+	// resources are named after the id of the user, group, client or role they protect. a permission that applies to
+	// every resource of its type is linked to a single resource named after the resource type instead.
+	permission.Resources = nil
+	for _, resource := range resources {
+		if resource.Name == permission.ResourceType {
+			continue
+		}
+		permission.Resources = append(permission.Resources, resource.Name)
+	}
+
+	permission.Scopes = nil
+	for _, scope := range scopes {
+		permission.Scopes = append(permission.Scopes, scope.Name)
+	}
+
+	return &permission, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateAdminPermission(ctx context.Context, permission *AdminPermission) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/permission/scope/%s", permission.RealmId, permission.ResourceServerId, permission.Id), permission)
+}
+
+func (keycloakClient *KeycloakClient) DeleteAdminPermission(ctx context.Context, realmId, resourceServerId, id string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/permission/%s", realmId, resourceServerId, id), nil)
+}
@@ -114,3 +114,18 @@ func (keycloakClient *KeycloakClient) DeleteAuthenticationFlow(ctx context.Conte
 	}
 	return nil
 }
+
+type authenticationFlowCopy struct {
+	NewName string `json:"newName"`
+}
+
+// CopyAuthenticationFlow copies a flow with all of its executions and configs. The subflows of the copy are named
+// after the new alias followed by their original alias.
+func (keycloakClient *KeycloakClient) CopyAuthenticationFlow(ctx context.Context, realmId, alias, newAlias string) (*AuthenticationFlow, error) {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/authentication/flows/%s/copy", realmId, alias), &authenticationFlowCopy{NewName: newAlias})
+	if err != nil {
+		return nil, err
+	}
+
+	return keycloakClient.GetAuthenticationFlowFromAlias(ctx, realmId, newAlias)
+}
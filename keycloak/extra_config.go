@@ -29,16 +29,17 @@ func unmarshalExtraConfig(data []byte, reflectValue reflect.Value, extraConfig *
 						if err == nil {
 							field.Set(reflect.ValueOf(types.KeycloakBoolQuoted(boolVal)))
 						}
-					} else if field.Kind() == reflect.TypeOf([]string{}).Kind() {
+					} else if field.Type() == reflect.TypeOf(types.KeycloakSliceQuoted{}) {
+						// a value may be parsable as both slice types, the type of the field decides how it's read
 						var sliceQuoted types.KeycloakSliceQuoted
-						var sliceHashDelimited types.KeycloakSliceHashDelimited
-
 						if err = json.Unmarshal([]byte(configValue.(string)), &sliceQuoted); err == nil {
 							field.Set(reflect.ValueOf(sliceQuoted))
-						} else if err = sliceHashDelimited.UnmarshalJSON([]byte(configValue.(string))); err == nil {
+						}
+					} else if field.Type() == reflect.TypeOf(types.KeycloakSliceHashDelimited{}) {
+						var sliceHashDelimited types.KeycloakSliceHashDelimited
+						if err = sliceHashDelimited.UnmarshalJSON([]byte(configValue.(string))); err == nil {
 							field.Set(reflect.ValueOf(sliceHashDelimited))
 						}
-
 					}
 
 					delete(*extraConfig, jsonKey)
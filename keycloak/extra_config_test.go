@@ -0,0 +1,90 @@
+package keycloak
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/types"
+)
+
+type extraConfigSlices struct {
+	Quoted        types.KeycloakSliceQuoted        `json:"quoted"`
+	HashDelimited types.KeycloakSliceHashDelimited `json:"hashDelimited"`
+}
+
+func TestUnmarshalExtraConfigSlices(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  extraConfigSlices
+		extra map[string]interface{}
+	}{
+		{
+			name: "both slice types",
+			data: `{"quoted": "[\"a\",\"b\"]", "hashDelimited": "c##d", "other": "e"}`,
+			want: extraConfigSlices{
+				Quoted:        types.KeycloakSliceQuoted{"a", "b"},
+				HashDelimited: types.KeycloakSliceHashDelimited{"c", "d"},
+			},
+			extra: map[string]interface{}{"other": "e"},
+		},
+		{
+			name: "hash delimited value that is valid JSON",
+			data: `{"hashDelimited": "[\"c\"]"}`,
+			want: extraConfigSlices{
+				HashDelimited: types.KeycloakSliceHashDelimited{`["c"]`},
+			},
+			extra: map[string]interface{}{},
+		},
+		{
+			name:  "quoted value that isn't JSON",
+			data:  `{"quoted": "a##b"}`,
+			want:  extraConfigSlices{},
+			extra: map[string]interface{}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var slices extraConfigSlices
+			extra := map[string]interface{}{}
+
+			err := unmarshalExtraConfig([]byte(test.data), reflect.ValueOf(&slices).Elem(), &extra)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(slices, test.want) {
+				t.Errorf("expected %#v, got %#v", test.want, slices)
+			}
+			if !reflect.DeepEqual(extra, test.extra) {
+				t.Errorf("expected the remaining extra config to be %v, got %v", test.extra, extra)
+			}
+		})
+	}
+}
+
+func TestMarshalExtraConfigSlices(t *testing.T) {
+	slices := extraConfigSlices{
+		Quoted:        types.KeycloakSliceQuoted{"a", "b"},
+		HashDelimited: types.KeycloakSliceHashDelimited{"c", "d"},
+	}
+
+	data, err := marshalExtraConfig(reflect.ValueOf(&slices).Elem(), map[string]interface{}{"other": "e"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var roundTrip extraConfigSlices
+	extra := map[string]interface{}{}
+	if err := unmarshalExtraConfig(data, reflect.ValueOf(&roundTrip).Elem(), &extra); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(roundTrip, slices) {
+		t.Errorf("expected %#v after a round trip, got %#v", slices, roundTrip)
+	}
+	if !reflect.DeepEqual(extra, map[string]interface{}{"other": "e"}) {
+		t.Errorf("expected the extra config to be kept, got %v", extra)
+	}
+}
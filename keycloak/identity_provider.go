@@ -2,8 +2,10 @@ package keycloak
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/keycloak/terraform-provider-keycloak/keycloak/types"
 )
@@ -110,6 +112,43 @@ func (keycloakClient *KeycloakClient) DeleteIdentityProvider(ctx context.Context
 	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances/%s", realm, alias), nil)
 }
 
+// ImportIdentityProviderConfig has keycloak fetch the metadata published by an identity provider, like an OpenID Connect
+// discovery document, and returns the identity provider config keycloak derives from it. Nothing is stored by keycloak.
+func (keycloakClient *KeycloakClient) ImportIdentityProviderConfig(ctx context.Context, realm, providerId, fromUrl string) (map[string]string, error) {
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/identity-provider/import-config", realm), map[string]string{
+		"providerId": providerId,
+		"fromUrl":    fromUrl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]string
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ImportIdentityProviderConfigFromDocument is like ImportIdentityProviderConfig, for metadata which isn't published at
+// a URL, like a SAML entity descriptor sent by a partner
+func (keycloakClient *KeycloakClient) ImportIdentityProviderConfigFromDocument(ctx context.Context, realm, providerId, document string) (map[string]string, error) {
+	body, err := keycloakClient.postMultipart(ctx, fmt.Sprintf("/realms/%s/identity-provider/import-config", realm), map[string]string{
+		"providerId": providerId,
+	}, "file", "metadata.xml", []byte(document))
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]string
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
 func (keycloakClient *KeycloakClient) LinkIdentityProviderWithOrganization(ctx context.Context, realm, alias string, orgId string) error {
 	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers", realm, orgId), alias)
 	if err != nil {
@@ -134,3 +173,40 @@ func (f *IdentityProviderConfig) UnmarshalJSON(data []byte) error {
 func (f *IdentityProviderConfig) MarshalJSON() ([]byte, error) {
 	return marshalExtraConfig(reflect.ValueOf(f).Elem(), f.ExtraConfig)
 }
+
+// Values returns the config of an identity provider by config key, the way keycloak represents it
+func (f *IdentityProviderConfig) Values() (map[string]interface{}, error) {
+	configJson, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(configJson, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// SetValues sets config values by config key, whether they are kept in a field of IdentityProviderConfig or in its
+// ExtraConfig, so identity providers can have their own settings without a field for each of them
+func (f *IdentityProviderConfig) SetValues(values map[string]string) {
+	reflectValue := reflect.ValueOf(f).Elem()
+
+Values:
+	for key, value := range values {
+		for i := 0; i < reflectValue.NumField(); i++ {
+			jsonKey := strings.Split(reflectValue.Type().Field(i).Tag.Get("json"), ",")[0]
+			if jsonKey == key && reflectValue.Field(i).Kind() == reflect.String {
+				reflectValue.Field(i).SetString(value)
+				continue Values
+			}
+		}
+
+		if f.ExtraConfig == nil {
+			f.ExtraConfig = map[string]interface{}{}
+		}
+		f.ExtraConfig[key] = value
+	}
+}
@@ -38,6 +38,13 @@ func (keycloakClient *KeycloakClient) GetIdentityProviderPermissions(ctx context
 	return &identityProviderPermissions, nil
 }
 
+// GetScopePermissionId returns the id of the permission linked to scope, or an empty string if there is none
+func (identityProviderPermissions *IdentityProviderPermissions) GetScopePermissionId(scope string) string {
+	permissionId, _ := identityProviderPermissions.ScopePermissions[scope].(string)
+
+	return permissionId
+}
+
 func (identityProviderPermissions *IdentityProviderPermissions) GetTokenExchangeScopedPermissionId() (string, error) {
 	if identityProviderPermissions.Enabled {
 		return identityProviderPermissions.ScopePermissions["token-exchange"].(string), nil
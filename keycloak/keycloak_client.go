@@ -6,14 +6,18 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-uuid"
@@ -32,9 +36,11 @@ type KeycloakClient struct {
 	authUrl             string
 	realm               string
 	clientCredentials   *ClientCredentials
+	tokenSource         *tokenSource
 	httpClient          *http.Client
-	initialLogin        bool
 	userAgent           string
+	versionMutex        sync.Mutex
+	realmDocumentLocks  sync.Map
 	version             *version.Version
 	additionalHeaders   map[string]string
 	debug               bool
@@ -43,6 +49,24 @@ type KeycloakClient struct {
 	keycloakVersion     string
 }
 
+// RetryConfig controls how requests to Keycloak are retried when the server
+// responds with 429 / 5xx or the connection fails.
+type RetryConfig struct {
+	MaxRetries      int
+	WaitMin         time.Duration
+	WaitMax         time.Duration
+	HonorRetryAfter bool
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:      5,
+		WaitMin:         time.Second * 1,
+		WaitMax:         time.Second * 60,
+		HonorRetryAfter: true,
+	}
+}
+
 type ClientCredentials struct {
 	ClientId      string
 	ClientSecret  string
@@ -51,9 +75,6 @@ type ClientCredentials struct {
 	Username      string
 	Password      string
 	GrantType     string
-	AccessToken   string `json:"access_token"`
-	RefreshToken  string `json:"refresh_token"`
-	TokenType     string `json:"token_type"`
 }
 
 const (
@@ -69,7 +90,7 @@ var redHatSSO7VersionMap = map[int]string{
 	4: "9.0.17",
 }
 
-func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, clientSecret, realm, username, password, accessToken, jwtSigningAlg, jwtSigningKey string, initialLogin bool, clientTimeout int, caCert string, tlsInsecureSkipVerify bool, tlsClientCert string, tlsClientPrivateKey string, userAgent string, redHatSSO bool, additionalHeaders map[string]string, keycloakVersion string) (*KeycloakClient, error) {
+func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, clientSecret, realm, username, password, accessToken, jwtSigningAlg, jwtSigningKey string, initialLogin bool, clientTimeout int, caCert string, tlsInsecureSkipVerify bool, tlsClientCert string, tlsClientPrivateKey string, userAgent string, redHatSSO bool, additionalHeaders map[string]string, keycloakVersion string, retryConfig RetryConfig) (*KeycloakClient, error) {
 	clientCredentials := &ClientCredentials{
 		ClientId:      clientId,
 		ClientSecret:  clientSecret,
@@ -84,8 +105,7 @@ func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, c
 	} else if clientSecret != "" || jwtSigningKey != "" {
 		clientCredentials.GrantType = "client_credentials"
 	} else if accessToken != "" {
-		clientCredentials.AccessToken = accessToken
-		clientCredentials.TokenType = "bearer"
+		// the access token is handed to the static token source below
 	} else {
 		if initialLogin {
 			return nil, fmt.Errorf("must specify client id, username and password for password grant, either client id and client secret or JWT Signing Key for client credentials grant")
@@ -94,7 +114,7 @@ func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, c
 		}
 	}
 
-	httpClient, err := newHttpClient(tlsInsecureSkipVerify, clientTimeout, caCert, tlsClientCert, tlsClientPrivateKey)
+	httpClient, err := newHttpClient(tlsInsecureSkipVerify, clientTimeout, caCert, tlsClientCert, tlsClientPrivateKey, retryConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http client: %v", err)
 	}
@@ -105,12 +125,11 @@ func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, c
 		baseUrl = adminUrl + basePath
 	}
 
-	keycloakClient := KeycloakClient{
+	keycloakClient := &KeycloakClient{
 		baseUrl:             baseUrl,
 		authUrl:             authUrl,
 		clientCredentials:   clientCredentials,
 		httpClient:          httpClient,
-		initialLogin:        initialLogin,
 		realm:               realm,
 		userAgent:           userAgent,
 		redHatSSO:           redHatSSO,
@@ -119,7 +138,13 @@ func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, c
 		keycloakVersion:     keycloakVersion,
 	}
 
-	if accessToken == "" && keycloakClient.initialLogin {
+	if accessToken != "" {
+		keycloakClient.tokenSource = newStaticTokenSource(accessToken)
+	} else {
+		keycloakClient.tokenSource = newTokenSource(keycloakClient.requestToken)
+	}
+
+	if accessToken == "" && initialLogin {
 		err = keycloakClient.login(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to perform initial login to Keycloak: %v", err)
@@ -130,71 +155,110 @@ func NewKeycloakClient(ctx context.Context, url, basePath, adminUrl, clientId, c
 		keycloakClient.debug = true
 	}
 
-	return &keycloakClient, nil
+	return keycloakClient, nil
 }
 
+// login fetches a new access token (unless one was provided) and determines the version of the Keycloak server
 func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
-
 	if !keycloakClient.accessTokenProvided {
-		accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.authUrl, keycloakClient.realm)
-		accessTokenData, err := keycloakClient.getAuthenticationFormData(ctx, accessTokenUrl)
+		_, err := keycloakClient.tokenSource.Refresh(ctx)
 		if err != nil {
 			return err
 		}
+	} else {
+		tflog.Debug(ctx, "Using provided access_token")
+	}
 
-		tflog.Debug(ctx, "Login request", map[string]interface{}{
-			"request": accessTokenData.Encode(),
-		})
+	keycloakClient.versionMutex.Lock()
+	defer keycloakClient.versionMutex.Unlock()
 
-		accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(accessTokenData.Encode()))
-		if err != nil {
-			return err
-		}
+	v, err := keycloakClient.fetchVersion(ctx)
+	if err != nil {
+		return err
+	}
 
-		for header, value := range keycloakClient.additionalHeaders {
-			accessTokenRequest.Header.Set(header, value)
-		}
+	keycloakClient.version = v
 
-		accessTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return nil
+}
 
-		if keycloakClient.userAgent != "" {
-			accessTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
-		}
+// requestToken performs the configured grant against the token endpoint. It's only called through the tokenSource.
+func (keycloakClient *KeycloakClient) requestToken(ctx context.Context) (*token, error) {
+	accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.authUrl, keycloakClient.realm)
+	accessTokenData, err := keycloakClient.getAuthenticationFormData(ctx, accessTokenUrl)
+	if err != nil {
+		return nil, err
+	}
 
-		accessTokenResponse, err := keycloakClient.httpClient.Do(accessTokenRequest)
-		if err != nil {
-			return err
-		}
-		if accessTokenResponse.StatusCode != http.StatusOK {
-			return fmt.Errorf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status)
-		}
+	tflog.Debug(ctx, "Login request", map[string]interface{}{
+		"request": accessTokenData.Encode(),
+	})
 
-		defer accessTokenResponse.Body.Close()
+	issuedAt := time.Now()
 
-		body, _ := io.ReadAll(accessTokenResponse.Body)
+	body, err := keycloakClient.sendTokenRequest(ctx, accessTokenUrl, accessTokenData)
+	if err != nil {
+		return nil, err
+	}
 
-		tflog.Debug(ctx, "Login response", map[string]interface{}{
-			"response": string(body),
-		})
+	tflog.Debug(ctx, "Login response", map[string]interface{}{
+		"response": string(body),
+	})
 
-		var clientCredentials ClientCredentials
-		err = json.Unmarshal(body, &clientCredentials)
-		if err != nil {
-			return err
+	var t token
+	err = json.Unmarshal(body, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	t.setExpiry(issuedAt)
+
+	return &t, nil
+}
+
+// sendTokenRequest posts the form to the token endpoint and returns the body of the response
+func (keycloakClient *KeycloakClient) sendTokenRequest(ctx context.Context, accessTokenUrl string, form url.Values) ([]byte, error) {
+	accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	for header, value := range keycloakClient.additionalHeaders {
+		accessTokenRequest.Header.Set(header, value)
+	}
+
+	accessTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if keycloakClient.userAgent != "" {
+		accessTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
+	}
+
+	accessTokenResponse, err := keycloakClient.httpClient.Do(accessTokenRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer accessTokenResponse.Body.Close()
+
+	body, _ := io.ReadAll(accessTokenResponse.Body)
+
+	if accessTokenResponse.StatusCode != http.StatusOK {
+		var tokenError struct {
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &tokenError) == nil && tokenError.ErrorDescription != "" {
+			return nil, fmt.Errorf("error sending POST request to %s: %s: %s", accessTokenUrl, accessTokenResponse.Status, tokenError.ErrorDescription)
 		}
 
-		keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-		keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-		keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
-	} else {
-		tflog.Debug(ctx, "Using provided access_token", map[string]interface{}{
-			"access_token": keycloakClient.clientCredentials.AccessToken,
-		})
+		return nil, fmt.Errorf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status)
 	}
 
+	return body, nil
+}
+
+func (keycloakClient *KeycloakClient) fetchVersion(ctx context.Context) (*version.Version, error) {
 	info, err := keycloakClient.GetServerInfo(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	serverVersion := info.SystemInfo.ServerVersion
@@ -206,7 +270,7 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 				"keycloak_version": serverVersion,
 			})
 		} else {
-			return fmt.Errorf("unable to determine Keycloak version: serverinfo endpoint returned empty version. " +
+			return nil, fmt.Errorf("unable to determine Keycloak version: serverinfo endpoint returned empty version. " +
 				"This typically occurs with Keycloak 26.4+ when the service account lacks the 'view-system' role. " +
 				"Either grant the 'view-system' role in the 'realm-management' client, or set the 'keycloak_version' provider attribute (e.g., keycloak_version = \"26.4.7\")")
 		}
@@ -218,7 +282,7 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 
 		if err != nil {
 			fmt.Println("Error compiling regex:", err)
-			return err
+			return nil, err
 		}
 
 		// Check if the pattern is found in serverVersion
@@ -230,86 +294,26 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 
 	v, err := version.NewVersion(serverVersion)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if keycloakClient.redHatSSO {
-		keycloakVersion, err := version.NewVersion(redHatSSO7VersionMap[v.Segments()[1]])
-		if err != nil {
-			return err
-		}
-
-		keycloakClient.version = keycloakVersion
-	} else {
-		keycloakClient.version = v
+		return version.NewVersion(redHatSSO7VersionMap[v.Segments()[1]])
 	}
 
-	return nil
+	return v, nil
 }
 
+// Refresh fetches a new access token, e.g. after creating a realm which the current token doesn't grant access to yet
 func (keycloakClient *KeycloakClient) Refresh(ctx context.Context) error {
-
 	if keycloakClient.accessTokenProvided {
 		// If an access_token was provided, we skip refresh
 		return nil
 	}
 
-	refreshTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.authUrl, keycloakClient.realm)
-	refreshTokenData, err := keycloakClient.getAuthenticationFormData(ctx, refreshTokenUrl)
-	if err != nil {
-		return err
-	}
-
-	tflog.Debug(ctx, "Refresh request", map[string]interface{}{
-		"request": refreshTokenData.Encode(),
-	})
-
-	refreshTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, refreshTokenUrl, strings.NewReader(refreshTokenData.Encode()))
-	if err != nil {
-		return err
-	}
-
-	for header, value := range keycloakClient.additionalHeaders {
-		refreshTokenRequest.Header.Set(header, value)
-	}
-
-	refreshTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	if keycloakClient.userAgent != "" {
-		refreshTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
-	}
+	_, err := keycloakClient.tokenSource.Refresh(ctx)
 
-	refreshTokenResponse, err := keycloakClient.httpClient.Do(refreshTokenRequest)
-	if err != nil {
-		return err
-	}
-
-	defer refreshTokenResponse.Body.Close()
-
-	body, _ := io.ReadAll(refreshTokenResponse.Body)
-
-	tflog.Debug(ctx, "Refresh response", map[string]interface{}{
-		"response": string(body),
-	})
-
-	// Handle 401 "User or client no longer has role permissions for client key" until I better understand why that happens in the first place
-	if refreshTokenResponse.StatusCode == http.StatusBadRequest {
-		tflog.Debug(ctx, "Unexpected 400, attempting to log in again")
-
-		return keycloakClient.login(ctx)
-	}
-
-	var clientCredentials ClientCredentials
-	err = json.Unmarshal(body, &clientCredentials)
-	if err != nil {
-		return err
-	}
-
-	keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
-
-	return nil
+	return err
 }
 
 func (keycloakClient *KeycloakClient) getAuthenticationFormData(ctx context.Context, kc_url string) (url.Values, error) {
@@ -348,9 +352,9 @@ func (keycloakClient *KeycloakClient) getAuthenticationFormData(ctx context.Cont
 	return authenticationFormData, nil
 }
 
-func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
-	tokenType := keycloakClient.clientCredentials.TokenType
-	accessToken := keycloakClient.clientCredentials.AccessToken
+func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request, t *token) {
+	tokenType := t.TokenType
+	accessToken := t.AccessToken
 
 	for header, value := range keycloakClient.additionalHeaders {
 		request.Header.Set(header, value)
@@ -373,12 +377,9 @@ func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
 Sends an HTTP request and refreshes credentials on 403 or 401 errors
 */
 func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *http.Request, body []byte) ([]byte, string, error) {
-	if !keycloakClient.initialLogin {
-		keycloakClient.initialLogin = true
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return nil, "", fmt.Errorf("error logging in: %s", err)
-		}
+	t, err := keycloakClient.tokenSource.Token(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("error logging in: %s", err)
 	}
 
 	requestMethod := request.Method
@@ -396,7 +397,7 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 
 	tflog.Debug(ctx, "Sending request", requestLogArgs)
 
-	keycloakClient.addRequestHeaders(request)
+	keycloakClient.addRequestHeaders(request, t)
 
 	response, err := keycloakClient.httpClient.Do(request)
 	if err != nil {
@@ -411,12 +412,13 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 			"status": response.Status,
 		})
 
-		err := keycloakClient.Refresh(ctx)
+		// if several requests were rejected at the same time, only the first one fetches a new token
+		t, err = keycloakClient.tokenSource.Invalidate(ctx, t)
 		if err != nil {
 			return nil, "", fmt.Errorf("error refreshing credentials: %s", err)
 		}
 
-		keycloakClient.addRequestHeaders(request)
+		keycloakClient.addRequestHeaders(request, t)
 
 		if body != nil {
 			request.Body = io.NopCloser(bytes.NewReader(body))
@@ -468,6 +470,27 @@ func (keycloakClient *KeycloakClient) get(ctx context.Context, path string, reso
 }
 
 func (keycloakClient *KeycloakClient) getRaw(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		for k, v := range params {
+			query.Add(k, v)
+		}
+	}
+
+	return keycloakClient.getRawWithQuery(ctx, path, query)
+}
+
+// getWithQuery is like get, for query parameters which can be repeated, like the types of events
+func (keycloakClient *KeycloakClient) getWithQuery(ctx context.Context, path string, resource interface{}, query url.Values) error {
+	body, err := keycloakClient.getRawWithQuery(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, resource)
+}
+
+func (keycloakClient *KeycloakClient) getRawWithQuery(ctx context.Context, path string, query url.Values) ([]byte, error) {
 	resourceUrl := keycloakClient.baseUrl + apiUrl + path
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, resourceUrl, nil)
@@ -475,11 +498,7 @@ func (keycloakClient *KeycloakClient) getRaw(ctx context.Context, path string, p
 		return nil, err
 	}
 
-	if params != nil {
-		query := url.Values{}
-		for k, v := range params {
-			query.Add(k, v)
-		}
+	if query != nil {
 		request.URL.RawQuery = query.Encode()
 	}
 
@@ -518,6 +537,51 @@ func (keycloakClient *KeycloakClient) post(ctx context.Context, path string, req
 	return body, location, err
 }
 
+// postMultipart sends the fields and a single file as multipart/form-data, which is how keycloak accepts uploads
+func (keycloakClient *KeycloakClient) postMultipart(ctx context.Context, path string, fields map[string]string, fileField, fileName string, file []byte) ([]byte, error) {
+	resourceUrl := keycloakClient.baseUrl + apiUrl + path
+
+	payload := &bytes.Buffer{}
+	writer := multipart.NewWriter(payload)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	fileWriter, err := writer.CreateFormFile(fileField, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fileWriter.Write(file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, resourceUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-type", writer.FormDataContentType())
+
+	body, _, err := keycloakClient.sendRequest(ctx, request, payload.Bytes())
+
+	return body, err
+}
+
+// lockRealmDocument serializes read-modify-write cycles on documents that Keycloak only exposes as a whole,
+// such as the client policies of a realm. It returns the function releasing the lock.
+func (keycloakClient *KeycloakClient) lockRealmDocument(realmId, document string) func() {
+	value, _ := keycloakClient.realmDocumentLocks.LoadOrStore(realmId+"/"+document, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
 func (keycloakClient *KeycloakClient) put(ctx context.Context, path string, requestBody interface{}) error {
 	resourceUrl := keycloakClient.baseUrl + apiUrl + path
 
@@ -569,31 +633,81 @@ func (keycloakClient *KeycloakClient) marshal(body interface{}) ([]byte, error)
 	return json.Marshal(body)
 }
 
+type requestMethodContextKey struct{}
+
+// methodAwareTransport records the HTTP method of each request in its context, since the
+// retryablehttp CheckRetry hook only receives the request context and (maybe) a response.
+type methodAwareTransport struct {
+	next http.RoundTripper
+}
+
+func (t *methodAwareTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := context.WithValue(request.Context(), requestMethodContextKey{}, request.Method)
+
+	return t.next.RoundTrip(request.WithContext(ctx))
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isConnectionError returns true if the request failed before it could reach the server,
+// meaning it's always safe to send it again.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+
+	return false
+}
+
 func RetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
-	// do retry on context.Canceled or context.DeadlineExceeded
+	// do not retry on context.Canceled or context.DeadlineExceeded
 	if ctx.Err() != nil {
-		return true, ctx.Err()
+		return false, ctx.Err()
 	}
 
-	// 429 Too Many Requests is recoverable. Sometimes the server puts
-	// a Retry-After response header to indicate when the server is
-	// available to start processing request from client.
-	if resp.StatusCode == http.StatusTooManyRequests {
-		return true, nil
+	// the default policy retries on connection errors (except for TLS / redirect / scheme errors),
+	// 429 Too Many Requests, and 500-range responses other than 501 Not Implemented
+	shouldRetry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !shouldRetry {
+		return false, checkErr
 	}
 
-	// Check the response code. We retry on 500-range responses to allow
-	// the server time to recover, as 500's are typically not permanent
-	// errors and may relate to outages on the server side. This will catch
-	// invalid response codes as well, like 0 and 999.
-	if resp.StatusCode == 0 || (resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented) {
+	method, _ := ctx.Value(requestMethodContextKey{}).(string)
+	if method == "" || isIdempotentMethod(method) {
 		return true, nil
 	}
 
-	return false, nil
+	// A POST that failed with a 5xx or a broken connection may have already been processed by Keycloak,
+	// so sending it again could create duplicates or fail with a 409. Only retry when we know the request
+	// was rejected without being processed.
+	if err != nil {
+		return isConnectionError(err), nil
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable, nil
 }
 
-func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string, tlsClientCert string, tlsClientPrivateKey string) (*http.Client, error) {
+// retryBackoff returns an exponential backoff bounded by min and max which, when honorRetryAfter is set,
+// waits for the duration the server asked for in a Retry-After header on 429 / 503 responses instead.
+func retryBackoff(honorRetryAfter bool) retryablehttp.Backoff {
+	return func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		if !honorRetryAfter {
+			resp = nil
+		}
+
+		return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+	}
+}
+
+func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string, tlsClientCert string, tlsClientPrivateKey string, retryConfig RetryConfig) (*http.Client, error) {
 	cookieJar, err := cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -622,15 +736,36 @@ func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string,
 	}
 
 	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = &http.Client{
+		Transport: transport,
+		// the timeout applies to each attempt, not to the whole retry sequence
+		Timeout: time.Second * time.Duration(clientTimeout),
+	}
 	retryClient.CheckRetry = RetryPolicy
-	retryClient.RetryMax = 5
-	retryClient.RetryWaitMin = time.Second * 1
-	retryClient.RetryWaitMax = time.Second * 60
-
-	httpClient := retryClient.StandardClient()
-	httpClient.Timeout = time.Second * time.Duration(clientTimeout)
-	httpClient.Transport = transport
-	httpClient.Jar = cookieJar
+	retryClient.Backoff = retryBackoff(retryConfig.HonorRetryAfter)
+	retryClient.RetryMax = retryConfig.MaxRetries
+	retryClient.RetryWaitMin = retryConfig.WaitMin
+	retryClient.RetryWaitMax = retryConfig.WaitMax
+	// once retries are exhausted, hand the last response back to sendRequest so it can be turned into an ApiError
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	// retryablehttp logs to stderr by default, we use tflog instead
+	retryClient.Logger = nil
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, request *http.Request, attempt int) {
+		if attempt > 0 {
+			tflog.Debug(request.Context(), "Retrying request", map[string]interface{}{
+				"method":  request.Method,
+				"path":    request.URL.Path,
+				"attempt": attempt,
+			})
+		}
+	}
+
+	httpClient := &http.Client{
+		Transport: &methodAwareTransport{
+			next: &retryablehttp.RoundTripper{Client: retryClient},
+		},
+		Jar: cookieJar,
+	}
 
 	return httpClient, nil
 }
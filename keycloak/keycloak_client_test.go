@@ -2,10 +2,18 @@ package keycloak
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/keycloak/terraform-provider-keycloak/helper"
@@ -25,7 +33,7 @@ func TestAccKeycloakClientConnect(t *testing.T) {
 
 	keycloakClient, err := NewKeycloakClient(ctx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, os.Getenv("KEYCLOAK_TLS_CA_CERT"), true, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), "", false, map[string]string{
 		"foo": "bar",
-	}, "")
+	}, "", DefaultRetryConfig())
 
 	keycloakClientChecks(t, err, keycloakClient, ctx)
 }
@@ -44,7 +52,7 @@ func TestAccKeycloakClientConnectAccessTokenAuth(t *testing.T) {
 
 	keycloakClient, err := NewKeycloakClient(ctx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, os.Getenv("KEYCLOAK_TLS_CA_CERT"), true, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), "", false, map[string]string{
 		"foo": "bar",
-	}, "")
+	}, "", DefaultRetryConfig())
 	keycloakClientChecks(t, err, keycloakClient, ctx)
 }
 
@@ -67,7 +75,7 @@ func TestAccKeycloakClientConnectHttpsMtlsAuth(t *testing.T) {
 			t.Fatalf("KEYCLOAK_URL_HTTP must also be set to https when using https")
 		}
 	}
-	keycloakClient, err := NewKeycloakClient(ctx, keycloakHttpUrl, "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, "", true, "", "", "", false, map[string]string{}, "")
+	keycloakClient, err := NewKeycloakClient(ctx, keycloakHttpUrl, "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, "", true, "", "", "", false, map[string]string{}, "", DefaultRetryConfig())
 
 	_, err = keycloakClient.Version(ctx)
 	if err != nil {
@@ -86,7 +94,7 @@ func TestAccKeycloakClientConnectHttpsMtlsAuth(t *testing.T) {
 	}
 
 	// then try again to connect with Keycloak but this time via https with mtls client auth
-	mtlsKeycloakClient, err := NewKeycloakClient(ctx, keycloakUrl, "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, os.Getenv("KEYCLOAK_TLS_CA_CERT"), true, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), "", false, map[string]string{}, "")
+	mtlsKeycloakClient, err := NewKeycloakClient(ctx, keycloakUrl, "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, os.Getenv("KEYCLOAK_TLS_CA_CERT"), true, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), "", false, map[string]string{}, "", DefaultRetryConfig())
 	keycloakClientChecks(t, err, mtlsKeycloakClient, ctx)
 }
 
@@ -109,7 +117,7 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 
 	keycloakClient, err := NewKeycloakClient(ctx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", true, clientTimeout, os.Getenv("KEYCLOAK_TLS_CA_CERT"), false, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), "", false, map[string]string{
 		"foo": "bar",
-	}, "")
+	}, "", DefaultRetryConfig())
 	if err != nil {
 		t.Fatalf("%s", err)
 	}
@@ -134,9 +142,13 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 
 	// A following GET for this realm will result in a 403, so we should save the current access and refresh token
 	if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		oldAccessToken = keycloakClient.clientCredentials.AccessToken
-		oldRefreshToken = keycloakClient.clientCredentials.RefreshToken
-		oldTokenType = keycloakClient.clientCredentials.TokenType
+		oldToken, err := keycloakClient.tokenSource.Token(ctx)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		oldAccessToken = oldToken.AccessToken
+		oldRefreshToken = oldToken.RefreshToken
+		oldTokenType = oldToken.TokenType
 	}
 
 	_, err = keycloakClient.GetRealm(ctx, realmName) // This should not fail since it will automatically refresh and try again
@@ -151,9 +163,13 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 	}
 
 	if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		newAccessToken := keycloakClient.clientCredentials.AccessToken
-		newRefreshToken := keycloakClient.clientCredentials.RefreshToken
-		newTokenType := keycloakClient.clientCredentials.TokenType
+		newToken, err := keycloakClient.tokenSource.Token(ctx)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		newAccessToken := newToken.AccessToken
+		newRefreshToken := newToken.RefreshToken
+		newTokenType := newToken.TokenType
 
 		if oldAccessToken == newAccessToken {
 			t.Fatalf("expected access token to update after refresh")
@@ -192,3 +208,241 @@ func keycloakClientChecks(t *testing.T, err error, keycloakClient *KeycloakClien
 		t.Fatalf("%s", "Server Version not found")
 	}
 }
+
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:      3,
+		WaitMin:         time.Millisecond,
+		WaitMax:         time.Millisecond * 10,
+		HonorRetryAfter: true,
+	}
+}
+
+// Returns a test server that responds with failureStatus for the first `failures` requests, then 200
+func newFlakyServer(t *testing.T, failures int32, failureStatus int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(failureStatus)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func sendTestRequest(t *testing.T, httpClient *http.Client, method, url, body string) *http.Response {
+	request, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	t.Cleanup(func() {
+		response.Body.Close()
+	})
+
+	return response
+}
+
+func TestHttpClientRetriesServerErrors(t *testing.T) {
+	server, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable, nil)
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", testRetryConfig())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	response := sendTestRequest(t, httpClient, http.MethodGet, server.URL, "")
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 after retries, got %d", response.StatusCode)
+	}
+
+	if *calls != 3 {
+		t.Fatalf("expected 3 requests, got %d", *calls)
+	}
+}
+
+func TestHttpClientDoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusNotFound, nil)
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", testRetryConfig())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	response := sendTestRequest(t, httpClient, http.MethodGet, server.URL, "")
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", response.StatusCode)
+	}
+
+	if *calls != 1 {
+		t.Fatalf("expected 1 request, got %d", *calls)
+	}
+}
+
+func TestHttpClientHonorsRetryAfter(t *testing.T) {
+	for _, honorRetryAfter := range []bool{true, false} {
+		server, calls := newFlakyServer(t, 1, http.StatusTooManyRequests, http.Header{
+			"Retry-After": []string{"1"},
+		})
+
+		retryConfig := testRetryConfig()
+		retryConfig.HonorRetryAfter = honorRetryAfter
+
+		httpClient, err := newHttpClient(false, 5, "", "", "", retryConfig)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		start := time.Now()
+		response := sendTestRequest(t, httpClient, http.MethodGet, server.URL, "")
+		elapsed := time.Since(start)
+
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200 after retry, got %d", response.StatusCode)
+		}
+
+		if *calls != 2 {
+			t.Fatalf("expected 2 requests, got %d", *calls)
+		}
+
+		if honorRetryAfter && elapsed < time.Second {
+			t.Fatalf("expected client to wait at least 1s as requested by Retry-After, waited %s", elapsed)
+		}
+
+		if !honorRetryAfter && elapsed >= time.Second {
+			t.Fatalf("expected client to ignore Retry-After, waited %s", elapsed)
+		}
+	}
+}
+
+func TestHttpClientPostRetries(t *testing.T) {
+	tests := map[int]int32{
+		// the server may have processed the request, retrying could duplicate it
+		http.StatusInternalServerError: 1,
+		http.StatusBadGateway:          1,
+		// the server explicitly didn't process the request
+		http.StatusServiceUnavailable: 2,
+		http.StatusTooManyRequests:    2,
+	}
+
+	for status, expectedCalls := range tests {
+		server, calls := newFlakyServer(t, 1, status, nil)
+
+		httpClient, err := newHttpClient(false, 5, "", "", "", testRetryConfig())
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		sendTestRequest(t, httpClient, http.MethodPost, server.URL, `{"name":"foo"}`)
+
+		if *calls != expectedCalls {
+			t.Fatalf("expected %d requests for POST failing with %d, got %d", expectedCalls, status, *calls)
+		}
+	}
+}
+
+func TestHttpClientResendsBodyOnRetry(t *testing.T) {
+	var calls int32
+	expectedBody := `{"name":"foo"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != expectedBody {
+			t.Errorf("expected request body %s, got %s", expectedBody, body)
+		}
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", testRetryConfig())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	response := sendTestRequest(t, httpClient, http.MethodPut, server.URL, expectedBody)
+	if response.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204 after retry, got %d", response.StatusCode)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestKeycloakClientReturnsApiErrorWhenRetriesAreExhausted(t *testing.T) {
+	server, calls := newFlakyServer(t, 100, http.StatusBadGateway, nil)
+
+	retryConfig := testRetryConfig()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", retryConfig)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	keycloakClient := &KeycloakClient{
+		baseUrl:           server.URL,
+		httpClient:        httpClient,
+		clientCredentials: &ClientCredentials{},
+		tokenSource:       newStaticTokenSource("token"),
+	}
+
+	var realm Realm
+	err = keycloakClient.get(context.Background(), "/realms/foo", &realm, nil)
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected ApiError, got %v", err)
+	}
+
+	if apiErr.Code != http.StatusBadGateway {
+		t.Fatalf("expected ApiError with code 502, got %d", apiErr.Code)
+	}
+
+	if *calls != int32(retryConfig.MaxRetries+1) {
+		t.Fatalf("expected %d requests, got %d", retryConfig.MaxRetries+1, *calls)
+	}
+}
+
+func TestRetryPolicyConnectionErrors(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "http://localhost", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	readErr := &url.Error{Op: "Post", URL: "http://localhost", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}
+
+	tests := []struct {
+		method      string
+		err         error
+		shouldRetry bool
+	}{
+		{http.MethodGet, dialErr, true},
+		{http.MethodGet, readErr, true},
+		{http.MethodPost, dialErr, true},
+		{http.MethodPost, readErr, false},
+	}
+
+	for _, test := range tests {
+		ctx := context.WithValue(context.Background(), requestMethodContextKey{}, test.method)
+
+		shouldRetry, _ := RetryPolicy(ctx, nil, test.err)
+		if shouldRetry != test.shouldRetry {
+			t.Fatalf("expected retry for %s with %v to be %t", test.method, test.err, test.shouldRetry)
+		}
+	}
+}
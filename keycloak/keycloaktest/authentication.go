@@ -0,0 +1,481 @@
+package keycloaktest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Executions are stored with the id of the flow they belong to in `parentFlow`. Executions of subflows have
+// `authenticatorFlow` set and reference the subflow in `flowId`.
+
+type builtInExecution struct {
+	authenticator string
+	requirement   string
+	subFlow       *builtInFlow
+}
+
+type builtInFlow struct {
+	alias       string
+	description string
+	providerId  string
+	executions  []builtInExecution
+}
+
+var builtInFlows = []builtInFlow{
+	{
+		alias:       "browser",
+		description: "Browser based authentication",
+		providerId:  "basic-flow",
+		executions: []builtInExecution{
+			{authenticator: "auth-cookie", requirement: "ALTERNATIVE"},
+			{authenticator: "auth-spnego", requirement: "DISABLED"},
+			{authenticator: "identity-provider-redirector", requirement: "ALTERNATIVE"},
+			{requirement: "ALTERNATIVE", subFlow: &builtInFlow{
+				alias:       "forms",
+				description: "Username, password, otp and other auth forms.",
+				providerId:  "basic-flow",
+				executions: []builtInExecution{
+					{authenticator: "auth-username-password-form", requirement: "REQUIRED"},
+					{requirement: "CONDITIONAL", subFlow: &builtInFlow{
+						alias:       "Browser - Conditional OTP",
+						description: "Flow to determine if the OTP is required for the authentication",
+						providerId:  "basic-flow",
+						executions: []builtInExecution{
+							{authenticator: "conditional-user-configured", requirement: "REQUIRED"},
+							{authenticator: "auth-otp-form", requirement: "REQUIRED"},
+						},
+					}},
+				},
+			}},
+		},
+	},
+	{
+		alias:       "direct grant",
+		description: "OpenID Connect Resource Owner Grant",
+		providerId:  "basic-flow",
+		executions: []builtInExecution{
+			{authenticator: "direct-grant-validate-username", requirement: "REQUIRED"},
+			{authenticator: "direct-grant-validate-password", requirement: "REQUIRED"},
+		},
+	},
+	{
+		alias:       "clients",
+		description: "Base authentication for clients",
+		providerId:  "client-flow",
+		executions: []builtInExecution{
+			{authenticator: "client-secret", requirement: "ALTERNATIVE"},
+			{authenticator: "client-jwt", requirement: "ALTERNATIVE"},
+			{authenticator: "client-secret-jwt", requirement: "ALTERNATIVE"},
+			{authenticator: "client-x509", requirement: "ALTERNATIVE"},
+		},
+	},
+}
+
+func (r *realm) createBuiltInFlows() {
+	for _, flow := range builtInFlows {
+		r.createBuiltInFlow(flow, true)
+	}
+
+	r.representation["browserFlow"] = "browser"
+	r.representation["directGrantFlow"] = "direct grant"
+	r.representation["clientAuthenticationFlow"] = "clients"
+}
+
+func (r *realm) createBuiltInFlow(flow builtInFlow, topLevel bool) object {
+	f := r.createFlow(object{
+		"alias":       flow.alias,
+		"description": flow.description,
+		"providerId":  flow.providerId,
+		"topLevel":    topLevel,
+		"builtIn":     true,
+	})
+
+	for _, execution := range flow.executions {
+		e := r.createExecution(str(f, "id"), execution.authenticator)
+		e["requirement"] = execution.requirement
+
+		if execution.subFlow != nil {
+			subFlow := r.createBuiltInFlow(*execution.subFlow, false)
+			e["authenticatorFlow"] = true
+			e["flowId"] = str(subFlow, "id")
+		}
+	}
+
+	return f
+}
+
+func (r *realm) createFlow(flow object) object {
+	id := newId()
+	flow["id"] = id
+	r.flows.put(id, flow)
+
+	return flow
+}
+
+func (r *realm) createExecution(parentFlowId, authenticator string) object {
+	priority := 0
+	for _, sibling := range r.flowExecutions(parentFlowId) {
+		if number(sibling, "priority") > priority {
+			priority = number(sibling, "priority")
+		}
+	}
+
+	id := newId()
+	execution := object{
+		"id":                id,
+		"parentFlow":        parentFlowId,
+		"authenticator":     authenticator,
+		"authenticatorFlow": false,
+		"requirement":       "DISABLED",
+		"priority":          priority + 10,
+	}
+	r.executions.put(id, execution)
+
+	return execution
+}
+
+func (r *realm) flowByAlias(alias string) (object, bool) {
+	return r.flows.find(func(o object) bool {
+		return str(o, "alias") == alias
+	})
+}
+
+// flowExecutions returns the direct executions of a flow, by priority
+func (r *realm) flowExecutions(flowId string) []object {
+	executions := r.executions.filter(func(o object) bool {
+		return str(o, "parentFlow") == flowId
+	})
+	sortByNumber(executions, "priority")
+
+	return executions
+}
+
+func (r *realm) deleteExecution(id string) {
+	execution, ok := r.executions.get(id)
+	if !ok {
+		return
+	}
+
+	r.executions.remove(id)
+	r.configs.remove(str(execution, "authenticationConfig"))
+
+	if boolean(execution, "authenticatorFlow") {
+		r.deleteFlow(str(execution, "flowId"))
+	}
+}
+
+func (r *realm) deleteFlow(id string) {
+	for _, execution := range r.flowExecutions(id) {
+		r.deleteExecution(str(execution, "id"))
+	}
+
+	r.flows.remove(id)
+}
+
+// executionInfos builds the flattened tree returned by GET /authentication/flows/{alias}/executions
+func (r *realm) executionInfos(flowId string, level int) []object {
+	var result []object
+
+	for index, execution := range r.flowExecutions(flowId) {
+		info := object{
+			"id":                   str(execution, "id"),
+			"requirement":          str(execution, "requirement"),
+			"level":                level,
+			"index":                index,
+			"priority":             number(execution, "priority"),
+			"authenticationFlow":   boolean(execution, "authenticatorFlow"),
+			"requirementChoices":   []string{"REQUIRED", "ALTERNATIVE", "DISABLED", "CONDITIONAL"},
+			"authenticationConfig": str(execution, "authenticationConfig"),
+		}
+
+		if boolean(execution, "authenticatorFlow") {
+			subFlow, _ := r.flows.get(str(execution, "flowId"))
+			info["displayName"] = str(subFlow, "alias")
+			info["description"] = str(subFlow, "description")
+			info["flowId"] = str(subFlow, "id")
+			info["configurable"] = false
+			if authenticator := str(execution, "authenticator"); authenticator != "" {
+				info["providerId"] = authenticator
+			}
+		} else {
+			info["displayName"] = str(execution, "authenticator")
+			info["providerId"] = str(execution, "authenticator")
+			info["configurable"] = true
+		}
+
+		if config, ok := r.configs.get(str(execution, "authenticationConfig")); ok {
+			info["alias"] = str(config, "alias")
+		}
+
+		result = append(result, info)
+
+		if boolean(execution, "authenticatorFlow") {
+			result = append(result, r.executionInfos(str(execution, "flowId"), level+1)...)
+		}
+	}
+
+	if result == nil {
+		result = []object{}
+	}
+
+	return result
+}
+
+func (r *realm) executionRepresentation(execution object) object {
+	representation := clone(execution)
+	// the provider reads this attribute as `authenticationFlow`
+	representation["authenticationFlow"] = boolean(execution, "authenticatorFlow")
+
+	return representation
+}
+
+func (r *realm) copyFlow(flow object, newAlias string, topLevel bool) object {
+	copied := r.createFlow(object{
+		"alias":       newAlias,
+		"description": str(flow, "description"),
+		"providerId":  str(flow, "providerId"),
+		"topLevel":    topLevel,
+		"builtIn":     false,
+	})
+
+	for _, execution := range r.flowExecutions(str(flow, "id")) {
+		e := r.createExecution(str(copied, "id"), str(execution, "authenticator"))
+		e["requirement"] = str(execution, "requirement")
+		e["priority"] = number(execution, "priority")
+
+		if config, ok := r.configs.get(str(execution, "authenticationConfig")); ok {
+			configCopy := clone(config)
+			configCopy["id"] = newId()
+			r.configs.put(str(configCopy, "id"), configCopy)
+			e["authenticationConfig"] = str(configCopy, "id")
+		}
+
+		if boolean(execution, "authenticatorFlow") {
+			subFlow, _ := r.flows.get(str(execution, "flowId"))
+			// this is how Keycloak names the subflows of a copied flow
+			subFlowCopy := r.copyFlow(subFlow, newAlias+" "+str(subFlow, "alias"), false)
+			e["authenticatorFlow"] = true
+			e["flowId"] = str(subFlowCopy, "id")
+		}
+	}
+
+	return copied
+}
+
+func (r *realm) handleAuthentication(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	switch segments[0] {
+	case "flows":
+		return r.handleFlows(req, segments[1:])
+	case "executions":
+		return r.handleExecutions(req, segments[1:])
+	case "config":
+		return r.handleConfigs(req, segments[1:])
+	}
+
+	return nil
+}
+
+func (r *realm) handleFlows(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(r.flows.filter(func(o object) bool {
+				return boolean(o, "topLevel")
+			}))
+		case http.MethodPost:
+			flow := req.object()
+			if _, exists := r.flowByAlias(str(flow, "alias")); exists {
+				return conflict("Flow " + str(flow, "alias") + " already exists")
+			}
+			flow["topLevel"] = true
+			flow["builtIn"] = false
+			r.createFlow(flow)
+			return created(fmt.Sprintf("/realms/%s/authentication/flows/%s", r.name(), str(flow, "id")))
+		}
+		return nil
+	}
+
+	// flows are addressed by id, but their executions and copies by alias
+	if len(segments) == 1 {
+		flow, exists := r.flows.get(segments[0])
+		if !exists {
+			return notFound("Could not find flow by id")
+		}
+
+		switch req.method {
+		case http.MethodGet:
+			return ok(flow)
+		case http.MethodPut:
+			update := req.object()
+			if alias := str(update, "alias"); alias != str(flow, "alias") {
+				if _, exists := r.flowByAlias(alias); exists {
+					return conflict("Flow " + alias + " already exists")
+				}
+			}
+			merge(flow, update)
+			return noContent()
+		case http.MethodDelete:
+			if boolean(flow, "builtIn") {
+				return badRequest("Can't delete built in flow")
+			}
+			r.deleteFlow(segments[0])
+			return noContent()
+		}
+		return nil
+	}
+
+	flow, exists := r.flowByAlias(segments[0])
+	if !exists {
+		return notFound("Flow not found")
+	}
+	flowId := str(flow, "id")
+
+	switch segments[1] {
+	case "copy":
+		if req.method == http.MethodPost {
+			newName := str(req.object(), "newName")
+			if _, exists := r.flowByAlias(newName); exists {
+				return conflict("New flow alias name already exists")
+			}
+			copied := r.copyFlow(flow, newName, true)
+			return created(fmt.Sprintf("/realms/%s/authentication/flows/%s", r.name(), str(copied, "id")))
+		}
+	case "executions":
+		if len(segments) == 2 {
+			switch req.method {
+			case http.MethodGet:
+				return ok(r.executionInfos(flowId, 0))
+			case http.MethodPut:
+				update := req.object()
+				execution, exists := r.executions.get(str(update, "id"))
+				if !exists {
+					return notFound("Illegal execution")
+				}
+				if requirement := str(update, "requirement"); requirement != "" {
+					execution["requirement"] = requirement
+				}
+				if priority := number(update, "priority"); priority != 0 {
+					execution["priority"] = priority
+				}
+				return noContent()
+			}
+			return nil
+		}
+
+		if len(segments) == 3 && req.method == http.MethodPost {
+			switch segments[2] {
+			case "execution":
+				execution := r.createExecution(flowId, str(req.object(), "provider"))
+				return created(fmt.Sprintf("/realms/%s/authentication/executions/%s", r.name(), str(execution, "id")))
+			case "flow":
+				body := req.object()
+				if _, exists := r.flowByAlias(str(body, "alias")); exists {
+					return conflict("New flow alias name already exists")
+				}
+				subFlow := r.createFlow(object{
+					"alias":       str(body, "alias"),
+					"description": str(body, "description"),
+					"providerId":  str(body, "type"),
+					"topLevel":    false,
+					"builtIn":     false,
+				})
+				execution := r.createExecution(flowId, str(body, "provider"))
+				execution["authenticatorFlow"] = true
+				execution["flowId"] = str(subFlow, "id")
+				return created(fmt.Sprintf("/realms/%s/authentication/flows/%s", r.name(), str(subFlow, "id")))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *realm) handleExecutions(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	execution, exists := r.executions.get(segments[0])
+	if !exists {
+		return notFound("Illegal execution")
+	}
+	id := str(execution, "id")
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(r.executionRepresentation(execution))
+		case http.MethodDelete:
+			r.deleteExecution(id)
+			return noContent()
+		}
+		return nil
+	}
+
+	if req.method != http.MethodPost || len(segments) != 2 {
+		return nil
+	}
+
+	switch segments[1] {
+	case "raise-priority", "lower-priority":
+		siblings := r.flowExecutions(str(execution, "parentFlow"))
+		for i, sibling := range siblings {
+			if str(sibling, "id") != id {
+				continue
+			}
+			j := i - 1
+			if segments[1] == "lower-priority" {
+				j = i + 1
+			}
+			if j >= 0 && j < len(siblings) {
+				priority := number(execution, "priority")
+				execution["priority"] = number(siblings[j], "priority")
+				siblings[j]["priority"] = priority
+			}
+			break
+		}
+		return noContent()
+	case "config":
+		config := req.object()
+		configId := newId()
+		config["id"] = configId
+		r.configs.put(configId, config)
+		execution["authenticationConfig"] = configId
+		return created(fmt.Sprintf("/realms/%s/authentication/executions/%s/config/%s", r.name(), id, configId))
+	}
+
+	return nil
+}
+
+func (r *realm) handleConfigs(req *request, segments []string) *response {
+	if len(segments) != 1 {
+		return nil
+	}
+
+	config, exists := r.configs.get(segments[0])
+	if !exists {
+		return notFound("Could not find authenticator config")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(config)
+	case http.MethodPut:
+		merge(config, req.object())
+		return noContent()
+	case http.MethodDelete:
+		r.configs.remove(segments[0])
+		for _, execution := range r.executions.list() {
+			if str(execution, "authenticationConfig") == segments[0] {
+				delete(execution, "authenticationConfig")
+			}
+		}
+		return noContent()
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package keycloaktest
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+)
+
+// The certificates of a client are kept in its attributes by attribute prefix, like keycloak does: the certificate of
+// the jwt.credential prefix is the jwt.credential.certificate attribute, base64 encoded without the PEM armor.
+
+func (r *realm) handleClientCertificates(req *request, segments []string, client object) *response {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	attrs, _ := client["attributes"].(map[string]interface{})
+	prefix := segments[0]
+
+	switch {
+	case len(segments) == 1 && req.method == http.MethodGet:
+		certificate := object{}
+		for key, attribute := range map[string]string{"certificate": ".certificate", "publicKey": ".public.key", "kid": ".kid"} {
+			if value, _ := attrs[prefix+attribute].(string); value != "" {
+				certificate[key] = value
+			}
+		}
+		return ok(certificate)
+	case len(segments) == 2 && segments[1] == "upload-certificate" && req.method == http.MethodPost:
+		upload := req.object()
+		if str(upload, "keystoreFormat") != "Certificate PEM" {
+			return badRequest("Only the Certificate PEM keystore format is supported")
+		}
+		block, _ := pem.Decode([]byte(str(upload, "file")))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return badRequest("Unable to parse the certificate")
+		}
+		certificate := base64.StdEncoding.EncodeToString(block.Bytes)
+		attrs[prefix+".certificate"] = certificate
+		delete(attrs, prefix+".public.key")
+		delete(attrs, prefix+".kid")
+		return ok(object{"certificate": certificate})
+	}
+
+	return nil
+}
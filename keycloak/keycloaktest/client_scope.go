@@ -0,0 +1,189 @@
+package keycloaktest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Client scopes are linked to clients, and to the realm for the scopes given to new clients, either as default or as
+// optional scopes. The links are kept by the id of the client, or of the realm, then by the id of the client scope.
+
+func (r *realm) createBuiltInClientScopes() {
+	builtIn := []struct {
+		name          string
+		protocol      string
+		defaultScope  bool
+		optionalScope bool
+	}{
+		{"profile", "openid-connect", true, false},
+		{"email", "openid-connect", true, false},
+		{"roles", "openid-connect", true, false},
+		{"web-origins", "openid-connect", true, false},
+		{"acr", "openid-connect", true, false},
+		{"basic", "openid-connect", true, false},
+		{"offline_access", "openid-connect", false, true},
+		{"address", "openid-connect", false, true},
+		{"phone", "openid-connect", false, true},
+		{"microprofile-jwt", "openid-connect", false, true},
+		{"role_list", "saml", true, false},
+		{"saml_organization", "saml", true, false},
+	}
+
+	for _, scope := range builtIn {
+		clientScope := r.createClientScope(object{
+			"name":       scope.name,
+			"protocol":   scope.protocol,
+			"attributes": object{},
+		})
+
+		if scope.defaultScope || scope.optionalScope {
+			r.linkClientScope(r.id(), str(clientScope, "id"), scope.defaultScope)
+		}
+	}
+}
+
+func (r *realm) createClientScope(clientScope object) object {
+	id := str(clientScope, "id")
+	if id == "" {
+		id = newId()
+	}
+
+	clientScope["id"] = id
+	if _, ok := clientScope["protocol"]; !ok {
+		clientScope["protocol"] = "openid-connect"
+	}
+
+	r.clientScopes.put(id, clientScope)
+
+	return clientScope
+}
+
+func (r *realm) linkClientScope(containerId, scopeId string, defaultScope bool) {
+	if r.clientScopeLinks[containerId] == nil {
+		r.clientScopeLinks[containerId] = map[string]bool{}
+	}
+
+	r.clientScopeLinks[containerId][scopeId] = defaultScope
+}
+
+// linkedClientScopes returns the default or optional client scopes of a client or of the realm, the way Keycloak lists
+// them: only their id, name and protocol
+func (r *realm) linkedClientScopes(containerId string, defaultScope bool) []object {
+	result := []object{}
+	for scopeId, isDefault := range r.clientScopeLinks[containerId] {
+		clientScope, exists := r.clientScopes.get(scopeId)
+		if !exists || isDefault != defaultScope {
+			continue
+		}
+
+		result = append(result, object{
+			"id":       scopeId,
+			"name":     str(clientScope, "name"),
+			"protocol": str(clientScope, "protocol"),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return str(result[i], "name") < str(result[j], "name")
+	})
+
+	return result
+}
+
+// linkRealmClientScopes gives a new client the realm default and optional client scopes of its protocol
+func (r *realm) linkRealmClientScopes(client object) {
+	protocol := str(client, "protocol")
+	if protocol == "" {
+		protocol = "openid-connect"
+	}
+
+	for scopeId, defaultScope := range r.clientScopeLinks[r.id()] {
+		clientScope, exists := r.clientScopes.get(scopeId)
+		if exists && str(clientScope, "protocol") == protocol {
+			r.linkClientScope(str(client, "id"), scopeId, defaultScope)
+		}
+	}
+}
+
+func (r *realm) handleClientScopes(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(r.clientScopes.list())
+		case http.MethodPost:
+			clientScope := req.object()
+			name := str(clientScope, "name")
+			if name == "" {
+				return badRequest("Client scope name cannot be empty")
+			}
+			if _, exists := r.clientScopes.find(func(o object) bool { return str(o, "name") == name }); exists {
+				return conflict(fmt.Sprintf("Client Scope %s already exists", name))
+			}
+			clientScope = r.createClientScope(clientScope)
+			return created(fmt.Sprintf("/realms/%s/client-scopes/%s", r.name(), str(clientScope, "id")))
+		}
+		return nil
+	}
+
+	clientScope, exists := r.clientScopes.get(segments[0])
+	if !exists {
+		return notFound("Could not find client scope")
+	}
+	id := str(clientScope, "id")
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(clientScope)
+		case http.MethodPut:
+			update := req.object()
+			update["id"] = id
+			r.clientScopes.put(id, update)
+			return noContent()
+		case http.MethodDelete:
+			r.clientScopes.remove(id)
+			for _, links := range r.clientScopeLinks {
+				delete(links, id)
+			}
+			delete(r.protocolMappers, id)
+			delete(r.scopeMappings, id)
+			return noContent()
+		}
+		return nil
+	}
+
+	switch segments[1] {
+	case "protocol-mappers":
+		return r.handleProtocolMappers(req, segments[2:], "client-scopes", id)
+	case "scope-mappings":
+		return r.handleScopeMappings(req, segments[2:], id)
+	}
+
+	return nil
+}
+
+// handleClientScopeLinks serves the default and optional client scopes of a client, or of the realm
+func (r *realm) handleClientScopeLinks(req *request, segments []string, containerId string, defaultScope bool) *response {
+	switch {
+	case len(segments) == 0 && req.method == http.MethodGet:
+		return ok(r.linkedClientScopes(containerId, defaultScope))
+	case len(segments) == 1:
+		if _, exists := r.clientScopes.get(segments[0]); !exists {
+			return notFound("Client scope not found")
+		}
+
+		switch req.method {
+		case http.MethodPut:
+			r.linkClientScope(containerId, segments[0], defaultScope)
+			return noContent()
+		case http.MethodDelete:
+			if isDefault, linked := r.clientScopeLinks[containerId][segments[0]]; linked && isDefault == defaultScope {
+				delete(r.clientScopeLinks[containerId], segments[0])
+			}
+			return noContent()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package keycloaktest
+
+import (
+	"net/http"
+	"time"
+)
+
+// credentials of a user are kept in priority order, secretData is never returned just like in Keycloak
+
+func (r *realm) addCredentials(userId string, credentials []interface{}) {
+	for _, c := range credentials {
+		credential, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if str(credential, "type") == "password" {
+			r.setPassword(userId, credential)
+			continue
+		}
+
+		stored := object{
+			"id":             newId(),
+			"type":           str(credential, "type"),
+			"createdDate":    time.Now().UnixMilli(),
+			"credentialData": str(credential, "credentialData"),
+			"secretData":     str(credential, "secretData"),
+		}
+		if label := str(credential, "userLabel"); label != "" {
+			stored["userLabel"] = label
+		}
+
+		r.credentials[userId] = append(r.credentials[userId], stored)
+	}
+}
+
+// setPassword replaces the password of the user, the existing password credential keeps its id and priority
+func (r *realm) setPassword(userId string, credential object) {
+	secretData := `{"value":"` + str(credential, "value") + `"}`
+
+	for _, existing := range r.credentials[userId] {
+		if str(existing, "type") == "password" {
+			existing["secretData"] = secretData
+			existing["createdDate"] = time.Now().UnixMilli()
+			return
+		}
+	}
+
+	r.credentials[userId] = append([]object{{
+		"id":             newId(),
+		"type":           "password",
+		"createdDate":    time.Now().UnixMilli(),
+		"credentialData": `{"hashIterations":5,"algorithm":"argon2"}`,
+		"secretData":     secretData,
+	}}, r.credentials[userId]...)
+}
+
+func (r *realm) handleCredentials(req *request, userId string, segments []string) *response {
+	credentials := r.credentials[userId]
+
+	if len(segments) == 0 {
+		if req.method != http.MethodGet {
+			return nil
+		}
+
+		result := make([]object, 0, len(credentials))
+		for _, credential := range credentials {
+			c := clone(credential)
+			delete(c, "secretData")
+			result = append(result, c)
+		}
+		return ok(result)
+	}
+
+	index := -1
+	for i, credential := range credentials {
+		if str(credential, "id") == segments[0] {
+			index = i
+		}
+	}
+	if index == -1 {
+		return notFound("Credential not found")
+	}
+	credential := credentials[index]
+
+	switch {
+	case len(segments) == 1 && req.method == http.MethodDelete:
+		r.credentials[userId] = append(credentials[:index:index], credentials[index+1:]...)
+		return noContent()
+	case len(segments) == 2 && segments[1] == "userLabel" && req.method == http.MethodPut:
+		label, _ := req.body.(string)
+		credential["userLabel"] = label
+		return noContent()
+	case len(segments) == 2 && segments[1] == "moveToFirst" && req.method == http.MethodPost:
+		remaining := append(credentials[:index:index], credentials[index+1:]...)
+		r.credentials[userId] = append([]object{credential}, remaining...)
+		return noContent()
+	case len(segments) == 3 && segments[1] == "moveAfter" && req.method == http.MethodPost:
+		remaining := append(credentials[:index:index], credentials[index+1:]...)
+		for i, previous := range remaining {
+			if str(previous, "id") == segments[2] {
+				moved := append(append(append([]object{}, remaining[:i+1]...), credential), remaining[i+1:]...)
+				r.credentials[userId] = moved
+				return noContent()
+			}
+		}
+		return notFound("Credential not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package keycloaktest
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// Keycloak has no API to store events, they are added to the fake with AddEvents and AddAdminEvents instead.
+// Like keycloak, the events are returned newest first.
+
+// AddEvents stores login events of the realm
+func (s *Server) AddEvents(realmName string, events ...keycloak.Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r := s.realmState[realmName]
+	for _, event := range events {
+		event.RealmId = r.id()
+		r.events = append(r.events, event)
+	}
+	sort.SliceStable(r.events, func(i, j int) bool {
+		return r.events[i].Time > r.events[j].Time
+	})
+}
+
+// AddAdminEvents stores admin events of the realm
+func (s *Server) AddAdminEvents(realmName string, adminEvents ...keycloak.AdminEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r := s.realmState[realmName]
+	for _, adminEvent := range adminEvents {
+		adminEvent.RealmId = r.id()
+		r.adminEvents = append(r.adminEvents, adminEvent)
+	}
+	sort.SliceStable(r.adminEvents, func(i, j int) bool {
+		return r.adminEvents[i].Time > r.adminEvents[j].Time
+	})
+}
+
+func (r *realm) handleEvents(req *request, segments []string) *response {
+	if len(segments) != 0 || req.method != http.MethodGet {
+		return nil
+	}
+
+	from, to, err := eventDateRange(req)
+	if err != nil {
+		return badRequest(err.Error())
+	}
+
+	var events []object
+	for _, event := range r.events {
+		if !matchesAny(req.query["type"], event.Type) ||
+			!matchesQuery(req, "client", event.ClientId) ||
+			!matchesQuery(req, "user", event.UserId) ||
+			!matchesQuery(req, "ipAddress", event.IpAddress) ||
+			event.Time < from || event.Time > to {
+			continue
+		}
+		events = append(events, object{
+			"time":      event.Time,
+			"type":      event.Type,
+			"realmId":   event.RealmId,
+			"clientId":  event.ClientId,
+			"userId":    event.UserId,
+			"sessionId": event.SessionId,
+			"ipAddress": event.IpAddress,
+			"error":     event.Error,
+			"details":   event.Details,
+		})
+	}
+
+	return ok(paginate(req, events, 100))
+}
+
+func (r *realm) handleAdminEvents(req *request, segments []string) *response {
+	if len(segments) != 0 || req.method != http.MethodGet {
+		return nil
+	}
+
+	from, to, err := eventDateRange(req)
+	if err != nil {
+		return badRequest(err.Error())
+	}
+
+	var adminEvents []object
+	for _, adminEvent := range r.adminEvents {
+		if !matchesAny(req.query["operationTypes"], adminEvent.OperationType) ||
+			!matchesAny(req.query["resourceTypes"], adminEvent.ResourceType) ||
+			!matchesResourcePath(req.queryValue("resourcePath"), adminEvent.ResourcePath) ||
+			!matchesQuery(req, "authRealm", adminEvent.AuthDetails.RealmId) ||
+			!matchesQuery(req, "authClient", adminEvent.AuthDetails.ClientId) ||
+			!matchesQuery(req, "authUser", adminEvent.AuthDetails.UserId) ||
+			!matchesQuery(req, "authIpAddress", adminEvent.AuthDetails.IpAddress) ||
+			adminEvent.Time < from || adminEvent.Time > to {
+			continue
+		}
+		adminEvents = append(adminEvents, object{
+			"time":    adminEvent.Time,
+			"realmId": adminEvent.RealmId,
+			"authDetails": object{
+				"realmId":   adminEvent.AuthDetails.RealmId,
+				"clientId":  adminEvent.AuthDetails.ClientId,
+				"userId":    adminEvent.AuthDetails.UserId,
+				"ipAddress": adminEvent.AuthDetails.IpAddress,
+			},
+			"operationType":  adminEvent.OperationType,
+			"resourceType":   adminEvent.ResourceType,
+			"resourcePath":   adminEvent.ResourcePath,
+			"representation": adminEvent.Representation,
+			"error":          adminEvent.Error,
+			"details":        adminEvent.Details,
+		})
+	}
+
+	return ok(paginate(req, adminEvents, 100))
+}
+
+func matchesAny(values []string, value string) bool {
+	return len(values) == 0 || slices.Contains(values, value)
+}
+
+func matchesQuery(req *request, key, value string) bool {
+	expected := req.queryValue(key)
+
+	return expected == "" || expected == value
+}
+
+// matchesResourcePath supports the wildcards keycloak allows in the resource path, like clients/*
+func matchesResourcePath(pattern, resourcePath string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	matched, _ := path.Match(pattern, resourcePath)
+
+	return matched
+}
+
+// eventDateRange returns the range of event times in milliseconds. The dates are either yyyy-MM-dd, the last day
+// being included, or milliseconds since the epoch.
+func eventDateRange(req *request) (int64, int64, error) {
+	from, err := eventTime(req.queryValue("dateFrom"), 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := eventTime(req.queryValue("dateTo"), 24*time.Hour-time.Millisecond)
+	if err != nil {
+		return 0, 0, err
+	}
+	if req.queryValue("dateTo") == "" {
+		to = 1<<63 - 1
+	}
+
+	return from, to, nil
+}
+
+func eventTime(value string, endOfDay time.Duration) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return millis, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return date.Add(endOfDay).UnixMilli(), nil
+}
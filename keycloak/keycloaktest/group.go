@@ -0,0 +1,195 @@
+package keycloaktest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// groups are stored flat, with the id of their parent (if any) in `parentId`
+
+func (r *realm) handleGroups(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			search := req.queryValue("search")
+			var groups []object
+			for _, group := range r.childGroups("") {
+				if search == "" || r.groupSubtreeMatches(group, search) {
+					groups = append(groups, r.groupRepresentation(group, true))
+				}
+			}
+			return ok(paginate(req, groups, 100))
+		case http.MethodPost:
+			return r.createGroup(req.object(), "")
+		}
+		return nil
+	}
+
+	if segments[0] == "count" && req.method == http.MethodGet {
+		return ok(object{"count": len(r.childGroups(""))})
+	}
+
+	group, exists := r.groups.get(segments[0])
+	if !exists {
+		return notFound("Could not find group by id")
+	}
+	id := str(group, "id")
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(r.groupRepresentation(group, true))
+		case http.MethodPut:
+			update := req.object()
+			delete(update, "subGroups")
+			delete(update, "path")
+			delete(update, "parentId")
+			merge(group, update)
+			return noContent()
+		case http.MethodDelete:
+			r.deleteGroup(id)
+			return noContent()
+		}
+		return nil
+	}
+
+	switch segments[1] {
+	case "children":
+		switch req.method {
+		case http.MethodGet:
+			var children []object
+			for _, child := range r.childGroups(id) {
+				children = append(children, r.groupRepresentation(child, true))
+			}
+			return ok(paginate(req, children, 100))
+		case http.MethodPost:
+			return r.createGroup(req.object(), id)
+		}
+	case "members":
+		if req.method == http.MethodGet {
+			var members []object
+			for _, user := range r.users.list() {
+				if contains(r.userGroups[str(user, "id")], id) {
+					members = append(members, user)
+				}
+			}
+			return ok(paginate(req, members, 100))
+		}
+	}
+
+	return nil
+}
+
+func (r *realm) createGroup(group object, parentId string) *response {
+	name := str(group, "name")
+	if name == "" {
+		return badRequest("Group name is missing")
+	}
+
+	for _, sibling := range r.childGroups(parentId) {
+		if str(sibling, "name") == name {
+			if parentId == "" {
+				return conflict(fmt.Sprintf("Top level group named '%s' already exists.", name))
+			}
+			return conflict(fmt.Sprintf("Sibling group named '%s' already exists.", name))
+		}
+	}
+
+	id := str(group, "id")
+	if id == "" {
+		id = newId()
+	}
+
+	delete(group, "subGroups")
+	delete(group, "path")
+	group["id"] = id
+	if parentId != "" {
+		group["parentId"] = parentId
+	} else {
+		delete(group, "parentId")
+	}
+	if _, ok := group["attributes"]; !ok {
+		group["attributes"] = object{}
+	}
+
+	r.groups.put(id, group)
+
+	return created(fmt.Sprintf("/realms/%s/groups/%s", r.name(), id))
+}
+
+func (r *realm) deleteGroup(id string) {
+	for _, child := range r.childGroups(id) {
+		r.deleteGroup(str(child, "id"))
+	}
+
+	r.groups.remove(id)
+	for userId, groups := range r.userGroups {
+		r.userGroups[userId] = without(groups, id)
+	}
+}
+
+func (r *realm) childGroups(parentId string) []object {
+	return r.groups.filter(func(o object) bool {
+		return str(o, "parentId") == parentId
+	})
+}
+
+func (r *realm) groupPath(group object) string {
+	path := "/" + str(group, "name")
+
+	if parent, ok := r.groups.get(str(group, "parentId")); ok {
+		return r.groupPath(parent) + path
+	}
+
+	return path
+}
+
+// groupRepresentation adds the computed attributes of a group, and optionally its whole subtree
+func (r *realm) groupRepresentation(group object, withSubGroups bool) object {
+	representation := clone(group)
+	representation["path"] = r.groupPath(group)
+
+	children := r.childGroups(str(group, "id"))
+	representation["subGroupCount"] = len(children)
+
+	if withSubGroups {
+		subGroups := []object{}
+		for _, child := range children {
+			subGroups = append(subGroups, r.groupRepresentation(child, true))
+		}
+		representation["subGroups"] = subGroups
+	}
+
+	return representation
+}
+
+func (r *realm) groupSubtreeMatches(group object, search string) bool {
+	if containsFold(str(group, "name"), search) {
+		return true
+	}
+
+	for _, child := range r.childGroups(str(group, "id")) {
+		if r.groupSubtreeMatches(child, search) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *realm) handleGroupByPath(req *request, segments []string) *response {
+	if req.method != http.MethodGet {
+		return nil
+	}
+
+	path := "/" + strings.Join(segments, "/")
+	group, exists := r.groups.find(func(o object) bool {
+		return r.groupPath(o) == path
+	})
+	if !exists {
+		return notFound("Group path does not exist")
+	}
+
+	return ok(r.groupRepresentation(group, true))
+}
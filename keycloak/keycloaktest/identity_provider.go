@@ -0,0 +1,241 @@
+package keycloaktest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Identity providers are kept by their alias, like keycloak they mask the client secret when they're read.
+
+func (r *realm) handleIdentityProviders(req *request, segments []string) *response {
+	if len(segments) == 0 || segments[0] != "instances" {
+		return nil
+	}
+	segments = segments[1:]
+
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			var identityProviders []object
+			for _, identityProvider := range r.identityProviders.list() {
+				identityProviders = append(identityProviders, maskIdentityProvider(identityProvider))
+			}
+			return ok(identityProviders)
+		case http.MethodPost:
+			identityProvider := req.object()
+			alias := str(identityProvider, "alias")
+			if alias == "" {
+				return badRequest("Identity provider alias cannot be empty")
+			}
+			if _, exists := r.identityProviders.get(alias); exists {
+				return conflict(fmt.Sprintf("Identity Provider %s already exists", alias))
+			}
+			identityProvider["internalId"] = newId()
+			if _, ok := identityProvider["config"]; !ok {
+				identityProvider["config"] = object{}
+			}
+			r.identityProviders.put(alias, identityProvider)
+			return created(fmt.Sprintf("/realms/%s/identity-provider/instances/%s", r.name(), alias))
+		}
+		return nil
+	}
+
+	identityProvider, exists := r.identityProviders.get(segments[0])
+	if !exists || len(segments) != 1 {
+		return notFound("Could not find identity provider")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(maskIdentityProvider(identityProvider))
+	case http.MethodPut:
+		update := req.object()
+		update["alias"] = segments[0]
+		update["internalId"] = identityProvider["internalId"]
+		if config, ok := update["config"].(object); ok && str(config, "clientSecret") == "**********" {
+			config["clientSecret"] = identityProvider["config"].(object)["clientSecret"]
+		}
+		r.identityProviders.put(segments[0], update)
+		return noContent()
+	case http.MethodDelete:
+		r.identityProviders.remove(segments[0])
+		return noContent()
+	}
+
+	return nil
+}
+
+func maskIdentityProvider(identityProvider object) object {
+	masked := clone(identityProvider)
+	if config, ok := masked["config"].(object); ok && str(config, "clientSecret") != "" {
+		config["clientSecret"] = "**********"
+	}
+
+	return masked
+}
+
+// handleImportIdentityProviderConfig derives the config of an identity provider from the metadata it publishes, or
+// from an uploaded file. The metadata of the fake's own realms is resolved without a request, as the server is locked
+// while handling this one.
+func (s *Server) handleImportIdentityProviderConfig(req *request) *response {
+	if req.method != http.MethodPost {
+		return nil
+	}
+
+	body := req.object()
+	fromUrl := str(body, "fromUrl")
+
+	var document []byte
+	if file, uploaded := body["file"].(string); uploaded {
+		document = []byte(file)
+	} else if path, found := strings.CutPrefix(fromUrl, s.URL+"/"); found {
+		segments := strings.Split(path, "/")
+		if len(segments) != 4 || segments[0] != "realms" || segments[2] != ".well-known" || segments[3] != "openid-configuration" {
+			return badRequest("Could not import config from " + fromUrl)
+		}
+		if _, exists := s.realmState[segments[1]]; !exists {
+			return badRequest("Could not import config from " + fromUrl)
+		}
+		document, _ = json.Marshal(s.discoveryDocument(segments[1]))
+	} else {
+		response, err := http.Get(fromUrl)
+		if err != nil {
+			return badRequest(fmt.Sprintf("Could not import config from %s: %s", fromUrl, err))
+		}
+		defer response.Body.Close()
+
+		document, err = io.ReadAll(response.Body)
+		if err != nil || response.StatusCode != http.StatusOK {
+			return badRequest("Could not import config from " + fromUrl)
+		}
+	}
+
+	var config map[string]string
+	var err error
+	switch str(body, "providerId") {
+	case "oidc", "keycloak-oidc":
+		config, err = importOidcConfig(document)
+	case "saml":
+		config, err = importSamlConfig(document)
+	default:
+		return badRequest("Could not import config for provider " + str(body, "providerId"))
+	}
+	if err != nil {
+		return badRequest(fmt.Sprintf("Could not import config: %s", err))
+	}
+
+	if fromUrl != "" {
+		config["metadataDescriptorUrl"] = fromUrl
+	}
+
+	return ok(config)
+}
+
+func importOidcConfig(document []byte) (map[string]string, error) {
+	var discovery object
+	if err := json.Unmarshal(document, &discovery); err != nil {
+		return nil, err
+	}
+
+	config := map[string]string{
+		"issuer":           str(discovery, "issuer"),
+		"authorizationUrl": str(discovery, "authorization_endpoint"),
+		"tokenUrl":         str(discovery, "token_endpoint"),
+		"userInfoUrl":      str(discovery, "userinfo_endpoint"),
+		"logoutUrl":        str(discovery, "end_session_endpoint"),
+	}
+	if jwksUrl := str(discovery, "jwks_uri"); jwksUrl != "" {
+		config["jwksUrl"] = jwksUrl
+		config["useJwksUrl"] = "true"
+		config["validateSignature"] = "true"
+	}
+
+	return config, nil
+}
+
+const samlHttpPostBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+type samlEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type samlEntityDescriptor struct {
+	EntityId      string `xml:"entityID,attr"`
+	IdpDescriptor *struct {
+		WantAuthnRequestsSigned string `xml:"WantAuthnRequestsSigned,attr"`
+		KeyDescriptors          []struct {
+			Use          string   `xml:"use,attr"`
+			Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleLogoutServices []samlEndpoint `xml:"SingleLogoutService"`
+		NameIdFormats        []string       `xml:"NameIDFormat"`
+		SingleSignOnServices []samlEndpoint `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+// importSamlConfig reads an entity descriptor the way keycloak does: the HTTP-POST binding is preferred, the first
+// NameID format is used, and all the signing certificates are kept, separated by commas
+func importSamlConfig(document []byte) (map[string]string, error) {
+	var entityDescriptor samlEntityDescriptor
+	if err := xml.Unmarshal(document, &entityDescriptor); err != nil {
+		return nil, err
+	}
+	idpDescriptor := entityDescriptor.IdpDescriptor
+	if idpDescriptor == nil {
+		return nil, fmt.Errorf("no IDPSSODescriptor in the metadata of %s", entityDescriptor.EntityId)
+	}
+
+	config := map[string]string{
+		"idpEntityId":             entityDescriptor.EntityId,
+		"wantAuthnRequestsSigned": strconv.FormatBool(idpDescriptor.WantAuthnRequestsSigned == "true"),
+	}
+
+	singleSignOnServiceUrl, postBinding := samlEndpointLocation(idpDescriptor.SingleSignOnServices)
+	config["singleSignOnServiceUrl"] = singleSignOnServiceUrl
+	config["postBindingResponse"] = strconv.FormatBool(postBinding)
+	config["postBindingAuthnRequest"] = strconv.FormatBool(postBinding)
+
+	singleLogoutServiceUrl, postBinding := samlEndpointLocation(idpDescriptor.SingleLogoutServices)
+	config["singleLogoutServiceUrl"] = singleLogoutServiceUrl
+	config["postBindingLogout"] = strconv.FormatBool(postBinding)
+
+	if len(idpDescriptor.NameIdFormats) != 0 {
+		config["nameIDPolicyFormat"] = strings.TrimSpace(idpDescriptor.NameIdFormats[0])
+	}
+
+	var signingCertificates []string
+	for _, keyDescriptor := range idpDescriptor.KeyDescriptors {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != "signing" {
+			continue
+		}
+		for _, certificate := range keyDescriptor.Certificates {
+			signingCertificates = append(signingCertificates, strings.Join(strings.Fields(certificate), ""))
+		}
+	}
+	if len(signingCertificates) != 0 {
+		config["signingCertificate"] = strings.Join(signingCertificates, ",")
+		config["validateSignature"] = "true"
+	}
+
+	return config, nil
+}
+
+func samlEndpointLocation(endpoints []samlEndpoint) (string, bool) {
+	location := ""
+	for _, endpoint := range endpoints {
+		if endpoint.Binding == samlHttpPostBinding {
+			return endpoint.Location, true
+		}
+		if location == "" {
+			location = endpoint.Location
+		}
+	}
+
+	return location, false
+}
@@ -0,0 +1,65 @@
+package keycloaktest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Protocol mappers belong to a client or to a client scope, they are kept by the id of their container.
+
+func (r *realm) handleProtocolMappers(req *request, segments []string, containerPath, containerId string) *response {
+	if len(segments) == 0 || segments[0] != "models" {
+		return nil
+	}
+	segments = segments[1:]
+
+	mappers := r.protocolMappers[containerId]
+	if mappers == nil {
+		mappers = newCollection()
+		r.protocolMappers[containerId] = mappers
+	}
+
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(mappers.list())
+		case http.MethodPost:
+			mapper := req.object()
+			name := str(mapper, "name")
+			if name == "" {
+				return badRequest("Protocol mapper name cannot be empty")
+			}
+			if _, exists := mappers.find(func(o object) bool { return str(o, "name") == name }); exists {
+				return conflict("Protocol mapper exists with same name")
+			}
+			id := newId()
+			mapper["id"] = id
+			if _, ok := mapper["config"]; !ok {
+				mapper["config"] = object{}
+			}
+			mappers.put(id, mapper)
+			return created(fmt.Sprintf("/realms/%s/%s/%s/protocol-mappers/models/%s", r.name(), containerPath, containerId, id))
+		}
+		return nil
+	}
+
+	mapper, exists := mappers.get(segments[0])
+	if !exists || len(segments) != 1 {
+		return notFound("Model not found")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(mapper)
+	case http.MethodPut:
+		update := req.object()
+		update["id"] = segments[0]
+		mappers.put(segments[0], update)
+		return noContent()
+	case http.MethodDelete:
+		mappers.remove(segments[0])
+		return noContent()
+	}
+
+	return nil
+}
@@ -0,0 +1,883 @@
+package keycloaktest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+type realm struct {
+	representation object
+
+	clients        *collection
+	clientSecrets  map[string]string
+	roles          *collection
+	composites     map[string][]string
+	users          *collection
+	userGroups     map[string][]string
+	credentials    map[string][]object
+	groups         *collection
+	components     *collection
+	flows          *collection
+	executions     *collection
+	configs        *collection
+	requiredAction *collection
+	clientPolicies object
+
+	clientScopes     *collection
+	clientScopeLinks map[string]map[string]bool
+	protocolMappers  map[string]*collection
+	scopeMappings    map[string][]string
+
+	identityProviders *collection
+
+	events      []keycloak.Event
+	adminEvents []keycloak.AdminEvent
+}
+
+func (s *Server) createRealm(representation object) *realm {
+	name := str(representation, "realm")
+	if str(representation, "id") == "" {
+		representation["id"] = name
+	}
+
+	r := &realm{
+		representation: representation,
+		clients:        newCollection(),
+		clientSecrets:  map[string]string{},
+		roles:          newCollection(),
+		composites:     map[string][]string{},
+		users:          newCollection(),
+		userGroups:     map[string][]string{},
+		credentials:    map[string][]object{},
+		groups:         newCollection(),
+		components:     newCollection(),
+		flows:          newCollection(),
+		executions:     newCollection(),
+		configs:        newCollection(),
+		requiredAction: newCollection(),
+		clientPolicies: object{"policies": []interface{}{}},
+
+		clientScopes:     newCollection(),
+		clientScopeLinks: map[string]map[string]bool{},
+		protocolMappers:  map[string]*collection{},
+		scopeMappings:    map[string][]string{},
+
+		identityProviders: newCollection(),
+	}
+
+	defaultRoles := r.createRole(object{
+		"name":        "default-roles-" + strings.ToLower(name),
+		"description": "${role_default-roles}",
+	}, str(representation, "id"), false)
+	offlineAccess := r.createRole(object{"name": "offline_access", "description": "${role_offline-access}"}, str(representation, "id"), false)
+	umaAuthorization := r.createRole(object{"name": "uma_authorization", "description": "${role_uma_authorization}"}, str(representation, "id"), false)
+	r.composites[str(defaultRoles, "id")] = []string{str(offlineAccess, "id"), str(umaAuthorization, "id")}
+	defaultRoles["composite"] = true
+	representation["defaultRole"] = clone(defaultRoles)
+
+	r.createBuiltInFlows()
+	r.createBuiltInClientScopes()
+	r.syncAdminPermissionsClient()
+
+	s.realms.put(name, r.representation)
+	s.realmState[name] = r
+
+	return r
+}
+
+func (s *Server) handleRealms(req *request) *response {
+	segments := req.segments[1:]
+
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(s.realms.list())
+		case http.MethodPost:
+			representation := req.object()
+			name := str(representation, "realm")
+			if name == "" {
+				return badRequest("Realm name cannot be empty")
+			}
+			if _, exists := s.realms.get(name); exists {
+				return conflict("Conflict detected. See logs for details")
+			}
+			s.createRealm(representation)
+			return created("/realms/" + name)
+		}
+		return nil
+	}
+
+	name := segments[0]
+	r, exists := s.realmState[name]
+	if !exists {
+		return notFound("Realm not found.")
+	}
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(r.representation)
+		case http.MethodPut:
+			merge(r.representation, req.object())
+			r.syncAdminPermissionsClient()
+			return noContent()
+		case http.MethodDelete:
+			s.realms.remove(name)
+			delete(s.realmState, name)
+			return noContent()
+		}
+		return nil
+	}
+
+	switch segments[1] {
+	case "clients":
+		return r.handleClients(req, segments[2:])
+	case "roles":
+		return r.handleRealmRoles(req, segments[2:])
+	case "roles-by-id":
+		return r.handleRolesById(req, segments[2:])
+	case "users":
+		return r.handleUsers(req, segments[2:])
+	case "groups":
+		return r.handleGroups(req, segments[2:])
+	case "group-by-path":
+		return r.handleGroupByPath(req, segments[2:])
+	case "components":
+		return r.handleComponents(req, segments[2:])
+	case "user-storage":
+		return s.handleUserStorage(req, r, segments[2:])
+	case "testLDAPConnection":
+		return s.handleTestLdapConnection(req, r)
+	case "client-scopes":
+		return r.handleClientScopes(req, segments[2:])
+	case "default-default-client-scopes":
+		return r.handleClientScopeLinks(req, segments[2:], r.id(), true)
+	case "default-optional-client-scopes":
+		return r.handleClientScopeLinks(req, segments[2:], r.id(), false)
+	case "events":
+		return r.handleEvents(req, segments[2:])
+	case "admin-events":
+		return r.handleAdminEvents(req, segments[2:])
+	case "identity-provider":
+		if len(segments) == 3 && segments[2] == "import-config" {
+			return s.handleImportIdentityProviderConfig(req)
+		}
+		return r.handleIdentityProviders(req, segments[2:])
+	case "authentication":
+		return r.handleAuthentication(req, segments[2:])
+	case "client-policies":
+		return r.handleClientPolicies(req, segments[2:])
+	}
+
+	return nil
+}
+
+// handleClientPolicies serves the client policies document, which Keycloak only allows to replace as a whole
+func (r *realm) handleClientPolicies(req *request, segments []string) *response {
+	if len(segments) != 1 || segments[0] != "policies" {
+		return nil
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(clone(r.clientPolicies))
+	case http.MethodPut:
+		policies := req.object()
+		if _, exists := policies["policies"]; !exists {
+			policies["policies"] = []interface{}{}
+		}
+		r.clientPolicies = policies
+		return noContent()
+	}
+
+	return nil
+}
+
+func (r *realm) id() string {
+	return str(r.representation, "id")
+}
+
+func (r *realm) name() string {
+	return str(r.representation, "realm")
+}
+
+// clients
+
+func (r *realm) handleClients(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			clientId := req.queryValue("clientId")
+			clients := r.clients.filter(func(o object) bool {
+				return clientId == "" || str(o, "clientId") == clientId
+			})
+			return ok(paginate(req, clients, -1))
+		case http.MethodPost:
+			client := req.object()
+			if str(client, "clientId") == "" {
+				return badRequest("Client id cannot be empty")
+			}
+			if _, exists := r.clientByClientId(str(client, "clientId")); exists {
+				return conflict(fmt.Sprintf("Client %s already exists", str(client, "clientId")))
+			}
+			id := str(client, "id")
+			if id == "" {
+				id = newId()
+				client["id"] = id
+			}
+			mergeAttributes(client, client)
+			r.clients.put(id, client)
+			secret := str(client, "secret")
+			if secret == "" {
+				secret = newId()
+			}
+			r.clientSecrets[id] = secret
+			delete(client, "secret")
+			r.syncServiceAccount(client)
+			r.linkRealmClientScopes(client)
+			return created(fmt.Sprintf("/realms/%s/clients/%s", r.name(), id))
+		}
+		return nil
+	}
+
+	client, exists := r.clients.get(segments[0])
+	if !exists {
+		return notFound("Could not find client")
+	}
+	id := str(client, "id")
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(client)
+		case http.MethodPut:
+			update := req.object()
+			if secret := str(update, "secret"); secret != "" {
+				r.clientSecrets[id] = secret
+			}
+			delete(update, "secret")
+			mergeAttributes(client, update)
+			merge(client, update)
+			r.syncServiceAccount(client)
+			return noContent()
+		case http.MethodDelete:
+			r.clients.remove(id)
+			delete(r.clientSecrets, id)
+			for _, role := range r.roles.filter(func(o object) bool { return str(o, "containerId") == id }) {
+				r.deleteRole(str(role, "id"))
+			}
+			if user, ok := r.serviceAccountUser(id); ok {
+				r.users.remove(str(user, "id"))
+			}
+			delete(r.clientScopeLinks, id)
+			delete(r.protocolMappers, id)
+			delete(r.scopeMappings, id)
+			return noContent()
+		}
+		return nil
+	}
+
+	switch segments[1] {
+	case "client-secret":
+		switch req.method {
+		case http.MethodGet:
+			return ok(object{"type": "secret", "value": r.clientSecrets[id]})
+		case http.MethodPost:
+			r.clientSecrets[id] = newId()
+			return ok(object{"type": "secret", "value": r.clientSecrets[id]})
+		}
+	case "service-account-user":
+		if req.method == http.MethodGet {
+			user, exists := r.serviceAccountUser(id)
+			if !exists {
+				return badRequest("Service account not enabled for the client")
+			}
+			return ok(user)
+		}
+	case "roles":
+		return r.handleRoles(req, segments[2:], id, true)
+	case "default-client-scopes":
+		return r.handleClientScopeLinks(req, segments[2:], id, true)
+	case "optional-client-scopes":
+		return r.handleClientScopeLinks(req, segments[2:], id, false)
+	case "protocol-mappers":
+		return r.handleProtocolMappers(req, segments[2:], "clients", id)
+	case "scope-mappings":
+		return r.handleScopeMappings(req, segments[2:], id)
+	case "certificates":
+		return r.handleClientCertificates(req, segments[2:], client)
+	}
+
+	return nil
+}
+
+// syncAdminPermissionsClient creates the client holding the fine-grained admin permissions v2 when they are enabled,
+// and removes it when they are disabled, like keycloak does
+func (r *realm) syncAdminPermissionsClient() {
+	client, exists := r.clientByClientId(keycloak.AdminPermissionsClientId)
+
+	switch enabled := boolean(r.representation, "adminPermissionsEnabled"); {
+	case enabled && !exists:
+		id := newId()
+		r.clients.put(id, object{
+			"id":                           id,
+			"clientId":                     keycloak.AdminPermissionsClientId,
+			"protocol":                     "openid-connect",
+			"enabled":                      true,
+			"bearerOnly":                   false,
+			"publicClient":                 false,
+			"authorizationServicesEnabled": true,
+			"attributes":                   object{},
+		})
+	case !enabled && exists:
+		r.clients.remove(str(client, "id"))
+	}
+}
+
+func (r *realm) clientByClientId(clientId string) (object, bool) {
+	return r.clients.find(func(o object) bool {
+		return str(o, "clientId") == clientId
+	})
+}
+
+func (r *realm) serviceAccountUser(clientId string) (object, bool) {
+	return r.users.find(func(o object) bool {
+		return str(o, "serviceAccountClientId") == clientId
+	})
+}
+
+// syncServiceAccount creates the service account user of clients with service accounts enabled
+func (r *realm) syncServiceAccount(client object) {
+	if !boolean(client, "serviceAccountsEnabled") {
+		return
+	}
+
+	if _, exists := r.serviceAccountUser(str(client, "id")); exists {
+		return
+	}
+
+	id := newId()
+	r.users.put(id, object{
+		"id":                     id,
+		"username":               "service-account-" + strings.ToLower(str(client, "clientId")),
+		"enabled":                true,
+		"serviceAccountClientId": str(client, "id"),
+	})
+}
+
+// roles
+
+func (r *realm) createRole(role object, containerId string, clientRole bool) object {
+	id := str(role, "id")
+	if id == "" {
+		id = newId()
+	}
+
+	role["id"] = id
+	role["containerId"] = containerId
+	role["clientRole"] = clientRole
+	if _, ok := role["composite"]; !ok {
+		role["composite"] = false
+	}
+	if _, ok := role["attributes"]; !ok {
+		role["attributes"] = object{}
+	}
+
+	r.roles.put(id, role)
+
+	return role
+}
+
+// updateRole changes the role like keycloak does, which ignores where the role is in the update
+func (r *realm) updateRole(role, update object) {
+	delete(update, "id")
+	delete(update, "containerId")
+	delete(update, "clientRole")
+	merge(role, update)
+}
+
+func (r *realm) deleteRole(id string) {
+	r.roles.remove(id)
+	delete(r.composites, id)
+
+	for parent, children := range r.composites {
+		r.composites[parent] = without(children, id)
+	}
+	for containerId, roleIds := range r.scopeMappings {
+		r.scopeMappings[containerId] = without(roleIds, id)
+	}
+}
+
+func (r *realm) handleRealmRoles(req *request, segments []string) *response {
+	return r.handleRoles(req, segments, r.id(), false)
+}
+
+// handleRoles implements the by-name role endpoints of both realms (/roles) and clients (/clients/{id}/roles)
+func (r *realm) handleRoles(req *request, segments []string, containerId string, clientRole bool) *response {
+	inContainer := func(o object) bool {
+		return str(o, "containerId") == containerId
+	}
+
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			search := req.queryValue("search")
+			roles := r.roles.filter(func(o object) bool {
+				return inContainer(o) && (search == "" || containsFold(str(o, "name"), search))
+			})
+			// like keycloak, the roles are listed without their attributes unless asked otherwise
+			if req.queryValue("briefRepresentation") != "false" {
+				for i, role := range roles {
+					roles[i] = clone(role)
+					delete(roles[i], "attributes")
+				}
+			}
+			return ok(paginate(req, roles, -1))
+		case http.MethodPost:
+			role := req.object()
+			if str(role, "name") == "" {
+				return badRequest("Role name cannot be empty")
+			}
+			if _, exists := r.roles.find(func(o object) bool { return inContainer(o) && str(o, "name") == str(role, "name") }); exists {
+				return conflict(fmt.Sprintf("Role with name %s already exists", str(role, "name")))
+			}
+			delete(role, "id")
+			r.createRole(role, containerId, clientRole)
+			return created(fmt.Sprintf("/realms/%s/roles/%s", r.name(), str(role, "name")))
+		}
+		return nil
+	}
+
+	role, exists := r.roles.find(func(o object) bool {
+		return inContainer(o) && str(o, "name") == segments[0]
+	})
+	if !exists {
+		return notFound("Could not find role")
+	}
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(role)
+		case http.MethodPut:
+			r.updateRole(role, req.object())
+			return noContent()
+		case http.MethodDelete:
+			r.deleteRole(str(role, "id"))
+			return noContent()
+		}
+		return nil
+	}
+
+	if segments[1] == "composites" {
+		return r.handleComposites(req, str(role, "id"))
+	}
+
+	return nil
+}
+
+func (r *realm) handleRolesById(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	role, exists := r.roles.get(segments[0])
+	if !exists {
+		return notFound("Could not find role with id")
+	}
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(role)
+		case http.MethodPut:
+			r.updateRole(role, req.object())
+			return noContent()
+		case http.MethodDelete:
+			r.deleteRole(segments[0])
+			return noContent()
+		}
+		return nil
+	}
+
+	if segments[1] == "composites" && len(segments) == 2 {
+		return r.handleComposites(req, segments[0])
+	}
+
+	return nil
+}
+
+func (r *realm) handleComposites(req *request, roleId string) *response {
+	role, _ := r.roles.get(roleId)
+
+	switch req.method {
+	case http.MethodGet:
+		var composites []object
+		for _, id := range r.composites[roleId] {
+			if composite, ok := r.roles.get(id); ok {
+				composites = append(composites, composite)
+			}
+		}
+		return ok(composites)
+	case http.MethodPost:
+		for _, composite := range req.objects() {
+			id := str(composite, "id")
+			if _, exists := r.roles.get(id); !exists {
+				return notFound("Could not find composite role")
+			}
+			if !contains(r.composites[roleId], id) {
+				r.composites[roleId] = append(r.composites[roleId], id)
+			}
+		}
+		role["composite"] = len(r.composites[roleId]) > 0
+		return noContent()
+	case http.MethodDelete:
+		for _, composite := range req.objects() {
+			r.composites[roleId] = without(r.composites[roleId], str(composite, "id"))
+		}
+		role["composite"] = len(r.composites[roleId]) > 0
+		return noContent()
+	}
+
+	return nil
+}
+
+// users
+
+func (r *realm) handleUsers(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(paginate(req, r.searchUsers(req), 100))
+		case http.MethodPost:
+			user := req.object()
+			username := strings.ToLower(str(user, "username"))
+			if username == "" {
+				return badRequest("User name is missing")
+			}
+			if _, exists := r.users.find(func(o object) bool { return str(o, "username") == username }); exists {
+				return conflict("User exists with same username")
+			}
+			user["username"] = username
+			id := str(user, "id")
+			if id == "" {
+				id = newId()
+				user["id"] = id
+			}
+			if _, ok := user["createdTimestamp"]; !ok {
+				user["createdTimestamp"] = 0
+			}
+			credentials, _ := user["credentials"].([]interface{})
+			delete(user, "credentials")
+			r.users.put(id, user)
+			r.addCredentials(id, credentials)
+			return created(fmt.Sprintf("/realms/%s/users/%s", r.name(), id))
+		}
+		return nil
+	}
+
+	if segments[0] == "count" && req.method == http.MethodGet {
+		return ok(len(r.searchUsers(req)))
+	}
+
+	user, exists := r.users.get(segments[0])
+	if !exists {
+		return notFound("User not found")
+	}
+	id := str(user, "id")
+
+	if len(segments) == 1 {
+		switch req.method {
+		case http.MethodGet:
+			return ok(user)
+		case http.MethodPut:
+			update := req.object()
+			if username, ok := update["username"].(string); ok {
+				update["username"] = strings.ToLower(username)
+			}
+			credentials, _ := update["credentials"].([]interface{})
+			delete(update, "credentials")
+			merge(user, update)
+			r.addCredentials(id, credentials)
+			return noContent()
+		case http.MethodDelete:
+			r.users.remove(id)
+			delete(r.userGroups, id)
+			delete(r.credentials, id)
+			return noContent()
+		}
+		return nil
+	}
+
+	switch segments[1] {
+	case "reset-password":
+		if req.method == http.MethodPut {
+			credential := req.object()
+			if str(credential, "value") == "" {
+				return badRequest("Password cannot be empty")
+			}
+			r.setPassword(id, credential)
+			return noContent()
+		}
+	case "credentials":
+		return r.handleCredentials(req, id, segments[2:])
+	case "groups":
+		if len(segments) == 2 && req.method == http.MethodGet {
+			var groups []object
+			for _, groupId := range r.userGroups[id] {
+				if group, ok := r.groups.get(groupId); ok {
+					groups = append(groups, r.groupRepresentation(group, false))
+				}
+			}
+			return ok(groups)
+		}
+		if len(segments) == 3 {
+			if _, ok := r.groups.get(segments[2]); !ok {
+				return notFound("Could not find group by id")
+			}
+			switch req.method {
+			case http.MethodPut:
+				if !contains(r.userGroups[id], segments[2]) {
+					r.userGroups[id] = append(r.userGroups[id], segments[2])
+				}
+				return noContent()
+			case http.MethodDelete:
+				r.userGroups[id] = without(r.userGroups[id], segments[2])
+				return noContent()
+			}
+		}
+	case "federated-identity":
+		if len(segments) == 3 && req.method == http.MethodPost {
+			identity := req.object()
+			identity["identityProvider"] = segments[2]
+			identities, _ := user["federatedIdentities"].([]interface{})
+			user["federatedIdentities"] = append(identities, identity)
+			return &response{status: http.StatusNoContent}
+		}
+		if len(segments) == 2 && req.method == http.MethodGet {
+			identities, _ := user["federatedIdentities"].([]interface{})
+			if identities == nil {
+				identities = []interface{}{}
+			}
+			return ok(identities)
+		}
+	}
+
+	return nil
+}
+
+// searchQueryPattern matches the key:value terms of the q parameter, either side may be quoted like Keycloak allows
+var searchQueryPattern = regexp.MustCompile(`\s*(?:([^":\s]+)|"((?:\\.|[^"\\])+)"):(?:([^"\s]+)|"((?:\\.|[^"\\])+)")\s*`)
+
+var searchQueryEscapePattern = regexp.MustCompile(`\\(.)`)
+
+func unescapeSearchQueryTerm(term string) string {
+	return searchQueryEscapePattern.ReplaceAllString(term, "$1")
+}
+
+// searchUsers applies the query parameters supported by GET /users, apart from pagination
+func (r *realm) searchUsers(req *request) []object {
+	exact := req.queryValue("exact") == "true"
+	matches := func(value, query string) bool {
+		if exact {
+			return strings.EqualFold(value, query)
+		}
+		return containsFold(value, query)
+	}
+
+	var attributeQuery map[string]string
+	if q := req.queryValue("q"); q != "" {
+		attributeQuery = map[string]string{}
+		for _, match := range searchQueryPattern.FindAllStringSubmatch(q, -1) {
+			attributeQuery[unescapeSearchQueryTerm(match[1]+match[2])] = unescapeSearchQueryTerm(match[3] + match[4])
+		}
+	}
+
+	return r.users.filter(func(user object) bool {
+		// service account users are never returned by the search endpoint
+		if str(user, "serviceAccountClientId") != "" {
+			return false
+		}
+
+		for _, field := range []string{"username", "email", "firstName", "lastName"} {
+			if query := req.queryValue(field); query != "" && !matches(str(user, field), query) {
+				return false
+			}
+		}
+
+		if search := req.queryValue("search"); search != "" && search != "*" {
+			search = strings.Trim(search, "*")
+			found := false
+			for _, field := range []string{"username", "email", "firstName", "lastName"} {
+				if matches(str(user, field), search) {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+
+		if enabled := req.queryValue("enabled"); enabled != "" && strconv.FormatBool(boolean(user, "enabled")) != enabled {
+			return false
+		}
+
+		if idpAlias := req.queryValue("idpAlias"); idpAlias != "" {
+			found := false
+			identities, _ := user["federatedIdentities"].([]interface{})
+			for _, identity := range identities {
+				if o, ok := identity.(map[string]interface{}); ok && str(o, "identityProvider") == idpAlias {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+
+		if attributeQuery != nil {
+			userAttributes := attributes(user)
+			for k, v := range attributeQuery {
+				if !contains(userAttributes[k], v) {
+					return false
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// components
+
+func (r *realm) handleComponents(req *request, segments []string) *response {
+	if len(segments) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			parent, providerType, name := req.queryValue("parent"), req.queryValue("type"), req.queryValue("name")
+			return ok(r.components.filter(func(o object) bool {
+				return (parent == "" || str(o, "parentId") == parent) &&
+					(providerType == "" || str(o, "providerType") == providerType) &&
+					(name == "" || str(o, "name") == name)
+			}))
+		case http.MethodPost:
+			component := req.object()
+			id := str(component, "id")
+			if id == "" {
+				id = newId()
+				component["id"] = id
+			}
+			if str(component, "parentId") == "" {
+				component["parentId"] = r.id()
+			}
+			mergeComponentConfig(object{}, component)
+			r.components.put(id, component)
+			return created(fmt.Sprintf("/realms/%s/components/%s", r.name(), id))
+		}
+		return nil
+	}
+
+	component, exists := r.components.get(segments[0])
+	if !exists || len(segments) > 1 {
+		return notFound("Could not find component")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(component)
+	case http.MethodPut:
+		update := req.object()
+		mergeComponentConfig(component, update)
+		merge(component, update)
+		return noContent()
+	case http.MethodDelete:
+		r.components.remove(segments[0])
+		// child components, e.g. LDAP mappers, are deleted with their parent
+		for _, child := range r.components.filter(func(o object) bool { return str(o, "parentId") == segments[0] }) {
+			r.components.remove(str(child, "id"))
+		}
+		return noContent()
+	}
+
+	return nil
+}
+
+// mergeComponentConfig sets the config of update to the config of existing updated like Keycloak does: entries that
+// aren't sent are kept and entries without a value are removed
+func mergeComponentConfig(existing, update object) {
+	config := object{}
+	if existingConfig, ok := existing["config"].(map[string]interface{}); ok {
+		for k, v := range existingConfig {
+			config[k] = v
+		}
+	}
+
+	updateConfig, ok := update["config"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for k, v := range updateConfig {
+		values, _ := v.([]interface{})
+		if len(values) == 0 || values[0] == nil || values[0] == "" {
+			delete(config, k)
+		} else {
+			config[k] = values
+		}
+	}
+
+	update["config"] = config
+}
+
+// paginate applies the first and max query parameters, defaultMax is used when max isn't set and -1 means no limit
+func paginate(req *request, objects []object, defaultMax int) []object {
+	if objects == nil {
+		objects = []object{}
+	}
+
+	first, _ := strconv.Atoi(req.queryValue("first"))
+	max, err := strconv.Atoi(req.queryValue("max"))
+	if err != nil {
+		max = defaultMax
+	}
+	if max < 0 {
+		max = len(objects)
+	}
+
+	if first >= len(objects) {
+		return []object{}
+	}
+
+	end := first + max
+	if end > len(objects) {
+		end = len(objects)
+	}
+
+	return objects[first:end]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func without(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,95 @@
+package keycloaktest
+
+import (
+	"net/http"
+)
+
+// Scope mappings are the roles in scope of a client or of a client scope. They are kept by the id of the client, or of
+// the client scope, as the ids of the roles.
+
+func (r *realm) handleScopeMappings(req *request, segments []string, containerId string) *response {
+	if len(segments) == 0 {
+		if req.method == http.MethodGet {
+			return ok(r.scopeMappingsRepresentation(containerId))
+		}
+		return nil
+	}
+
+	var roleContainerId string
+	switch {
+	case len(segments) == 1 && segments[0] == "realm":
+		roleContainerId = r.id()
+	case len(segments) == 2 && segments[0] == "clients":
+		if _, exists := r.clients.get(segments[1]); !exists {
+			return notFound("Could not find client")
+		}
+		roleContainerId = segments[1]
+	default:
+		return nil
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(r.scopeMappedRoles(containerId, roleContainerId))
+	case http.MethodPost:
+		for _, role := range req.objects() {
+			id := str(role, "id")
+			if existing, exists := r.roles.get(id); !exists || str(existing, "containerId") != roleContainerId {
+				return notFound("Could not find role")
+			}
+			if !contains(r.scopeMappings[containerId], id) {
+				r.scopeMappings[containerId] = append(r.scopeMappings[containerId], id)
+			}
+		}
+		return noContent()
+	case http.MethodDelete:
+		// like keycloak, deleting the client level scope mappings without a body removes all the roles of the client
+		roles := req.objects()
+		if req.body == nil {
+			roles = r.scopeMappedRoles(containerId, roleContainerId)
+		}
+		for _, role := range roles {
+			r.scopeMappings[containerId] = without(r.scopeMappings[containerId], str(role, "id"))
+		}
+		return noContent()
+	}
+
+	return nil
+}
+
+func (r *realm) scopeMappedRoles(containerId, roleContainerId string) []object {
+	roles := []object{}
+	for _, id := range r.scopeMappings[containerId] {
+		if role, exists := r.roles.get(id); exists && str(role, "containerId") == roleContainerId {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+// scopeMappingsRepresentation lists all the roles in scope the way keycloak does: the realm roles, then the client roles
+// by the client id of their client
+func (r *realm) scopeMappingsRepresentation(containerId string) object {
+	representation := object{}
+
+	if realmRoles := r.scopeMappedRoles(containerId, r.id()); len(realmRoles) != 0 {
+		representation["realmMappings"] = realmRoles
+	}
+
+	clientMappings := object{}
+	for _, client := range r.clients.list() {
+		if clientRoles := r.scopeMappedRoles(containerId, str(client, "id")); len(clientRoles) != 0 {
+			clientMappings[str(client, "clientId")] = object{
+				"id":       str(client, "id"),
+				"client":   str(client, "clientId"),
+				"mappings": clientRoles,
+			}
+		}
+	}
+	if len(clientMappings) != 0 {
+		representation["clientMappings"] = clientMappings
+	}
+
+	return representation
+}
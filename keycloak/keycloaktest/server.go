@@ -0,0 +1,356 @@
+// Package keycloaktest provides an in-memory fake of the Keycloak admin REST API, so code built on top of
+// keycloak.KeycloakClient (including the Terraform provider itself) can be tested without a running Keycloak.
+//
+// The fake implements realms, clients, client scopes, protocol mappers, users, groups, roles, role scope mappings,
+// components, identity providers, authentication flows, client policies and events closely enough for the provider's
+// CRUD operations. It is not a full Keycloak: requests to endpoints it doesn't know about fail with a 404, and are
+// recorded in UnsupportedRequests to make missing coverage easy to spot.
+package keycloaktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+const (
+	ClientId     = "terraform"
+	ClientSecret = "keycloaktest"
+	// DefaultVersion is the Keycloak version reported by /serverinfo unless Server.Version is changed
+	DefaultVersion = "26.4.7"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mutex sync.Mutex
+
+	// Version is reported by the /serverinfo endpoint
+	Version string
+	// ServerInfo is returned by the /serverinfo endpoint, its SystemInfo.ServerVersion is overwritten by Version
+	ServerInfo keycloak.ServerInfo
+	// TokenLifetime is the lifetime of the access tokens issued by the token endpoint
+	TokenLifetime time.Duration
+
+	realms      *collection
+	realmState  map[string]*realm
+	tokens      map[string]time.Time
+	entries     []string
+	ldapServers map[string]map[string]string
+
+	ldapSyncFailures map[string]int
+
+	TokenRequests       int
+	UnsupportedRequests []string
+}
+
+// NewServer starts a fake Keycloak with an empty master realm. Close must be called once it's no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		Version:       DefaultVersion,
+		ServerInfo:    defaultServerInfo(),
+		TokenLifetime: time.Minute * 5,
+		realms:        newCollection(),
+		realmState:    map[string]*realm{},
+		tokens:        map[string]time.Time{},
+		ldapServers:   map[string]map[string]string{},
+
+		ldapSyncFailures: map[string]int{},
+	}
+
+	s.createRealm(object{
+		"realm":   "master",
+		"enabled": true,
+	})
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// NewClient returns a KeycloakClient authenticating against the server with the client credentials grant
+func (s *Server) NewClient(ctx context.Context) (*keycloak.KeycloakClient, error) {
+	return keycloak.NewKeycloakClient(ctx, s.URL, "", "", ClientId, ClientSecret, "master", "", "", "", "", "", true, 5, "", false, "", "", "", false, map[string]string{}, "", keycloak.RetryConfig{})
+}
+
+// Requests returns the method and path of every request received so far, in order
+func (s *Server) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string(nil), s.entries...)
+}
+
+func defaultServerInfo() keycloak.ServerInfo {
+	return keycloak.ServerInfo{
+		Themes: map[string][]keycloak.Theme{
+			"login":   {{Name: "base"}, {Name: "keycloak"}},
+			"account": {{Name: "base"}, {Name: "keycloak.v3"}},
+			"admin":   {{Name: "base"}, {Name: "keycloak.v2"}},
+			"email":   {{Name: "base"}, {Name: "keycloak"}},
+		},
+		ComponentTypes: map[string][]keycloak.ComponentType{
+			"org.keycloak.storage.UserStorageProvider": {{Id: "ldap"}, {Id: "kerberos"}},
+			"org.keycloak.keys.KeyProvider":            {{Id: "rsa-generated"}, {Id: "hmac-generated"}, {Id: "aes-generated"}, {Id: "ecdsa-generated"}, {Id: "rsa"}, {Id: "java-keystore"}},
+			"org.keycloak.services.clientpolicy.condition.ClientPolicyConditionProvider": {
+				{Id: "any-client"},
+				{Id: "client-roles", Properties: []keycloak.ComponentTypeProperty{{Name: "roles", Type: "MultivaluedString"}}},
+				{Id: "client-scopes", Properties: []keycloak.ComponentTypeProperty{{Name: "scopes", Type: "MultivaluedString"}, {Name: "type", Type: "List", Options: []string{"Default", "Optional"}}}},
+				{Id: "client-access-type", Properties: []keycloak.ComponentTypeProperty{{Name: "type", Type: "MultivaluedList", Options: []string{"confidential", "public", "bearer-only"}}}},
+				{Id: "client-updater-source-host", Properties: []keycloak.ComponentTypeProperty{{Name: "trusted-hosts", Type: "MultivaluedString"}}},
+			},
+		},
+		ProviderTypes: map[string]keycloak.ProviderType{},
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries = append(s.entries, r.Method+" "+r.URL.Path)
+
+	path := strings.Trim(r.URL.Path, "/")
+	segments := strings.Split(path, "/")
+
+	if len(segments) == 5 && segments[0] == "realms" && segments[2] == "protocol" && segments[4] == "token" {
+		s.handleToken(w, r, segments[1])
+		return
+	}
+
+	if len(segments) == 4 && segments[0] == "realms" && segments[2] == ".well-known" && segments[3] == "openid-configuration" {
+		s.handleDiscovery(w, segments[1])
+		return
+	}
+
+	if len(segments) == 0 || segments[0] != "admin" {
+		s.unsupported(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "HTTP 401 Unauthorized")
+		return
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &request{
+		method:   r.Method,
+		segments: segments[1:],
+		query:    r.URL.Query(),
+		body:     body,
+	}
+
+	var resp *response
+	switch {
+	case len(req.segments) == 1 && req.segments[0] == "serverinfo" && req.method == http.MethodGet:
+		info := s.ServerInfo
+		info.SystemInfo.ServerVersion = s.Version
+		resp = ok(info)
+	case len(req.segments) >= 1 && req.segments[0] == "realms":
+		resp = s.handleRealms(req)
+	}
+
+	if resp == nil {
+		s.unsupported(w, r)
+		return
+	}
+
+	resp.write(w, r)
+}
+
+func (s *Server) unsupported(w http.ResponseWriter, r *http.Request) {
+	s.UnsupportedRequests = append(s.UnsupportedRequests, r.Method+" "+r.URL.Path)
+	writeError(w, http.StatusNotFound, fmt.Sprintf("keycloaktest: %s %s is not supported", r.Method, r.URL.Path))
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	accessToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		return false
+	}
+
+	expiry, ok := s.tokens[accessToken]
+
+	return ok && time.Now().Before(expiry)
+}
+
+// AddLdapServer registers a stand-in for an LDAP server reachable at connectionUrl, which only answers the LDAP
+// connection tests. bindCredentials maps the DNs allowed to bind to their password.
+func (s *Server) AddLdapServer(connectionUrl string, bindCredentials map[string]string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.ldapServers[connectionUrl] = bindCredentials
+}
+
+// FailLdapSync makes the user syncs of the providers connected to connectionUrl report failed entries
+func (s *Server) FailLdapSync(connectionUrl string, failed int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.ldapSyncFailures[connectionUrl] = failed
+}
+
+// RevokeTokens invalidates every access token issued so far
+func (s *Server) RevokeTokens() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+type request struct {
+	method   string
+	segments []string
+	query    map[string][]string
+	body     interface{}
+}
+
+func (r *request) queryValue(key string) string {
+	values := r.query[key]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (r *request) object() object {
+	o, _ := r.body.(map[string]interface{})
+	if o == nil {
+		return object{}
+	}
+
+	return o
+}
+
+func (r *request) objects() []object {
+	items, _ := r.body.([]interface{})
+
+	var result []object
+	for _, item := range items {
+		if o, ok := item.(map[string]interface{}); ok {
+			result = append(result, o)
+		}
+	}
+
+	return result
+}
+
+type response struct {
+	status   int
+	body     interface{}
+	location string
+}
+
+func ok(body interface{}) *response {
+	return &response{status: http.StatusOK, body: body}
+}
+
+func noContent() *response {
+	return &response{status: http.StatusNoContent}
+}
+
+// created returns a 201 with a Location header pointing at path, relative to /admin
+func created(path string) *response {
+	return &response{status: http.StatusCreated, location: path}
+}
+
+func notFound(message string) *response {
+	return &response{status: http.StatusNotFound, body: object{"error": message}}
+}
+
+func conflict(message string) *response {
+	return &response{status: http.StatusConflict, body: object{"errorMessage": message}}
+}
+
+func badRequest(message string) *response {
+	return &response{status: http.StatusBadRequest, body: object{"errorMessage": message}}
+}
+
+func (resp *response) write(w http.ResponseWriter, r *http.Request) {
+	if resp.location != "" {
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		w.Header().Set("Location", fmt.Sprintf("%s://%s/admin%s", scheme, r.Host, resp.location))
+	}
+
+	if resp.body == nil {
+		w.WriteHeader(resp.status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.status)
+	_ = json.NewEncoder(w).Encode(resp.body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(object{"error": message})
+}
+
+func readBody(r *http.Request) (interface{}, error) {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/plain") {
+		b, err := io.ReadAll(r.Body)
+		return string(b), err
+	}
+
+	// multipart uploads are read as an object of their fields, with the content of the files as strings
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			return nil, fmt.Errorf("unable to parse request body: %v", err)
+		}
+
+		body := map[string]interface{}{}
+		for name, values := range r.MultipartForm.Value {
+			body[name] = values[0]
+		}
+		for name, files := range r.MultipartForm.File {
+			file, err := files[0].Open()
+			if err != nil {
+				return nil, err
+			}
+			content, err := io.ReadAll(file)
+			file.Close()
+			if err != nil {
+				return nil, err
+			}
+			body[name] = string(content)
+		}
+
+		return body, nil
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return nil, nil
+	}
+
+	var body interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("unable to parse request body: %v", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,616 @@
+package keycloaktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func newTestClient(t *testing.T) (*Server, *keycloak.KeycloakClient) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	keycloakClient, err := server.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if len(server.UnsupportedRequests) != 0 {
+			t.Errorf("unsupported requests: %v", server.UnsupportedRequests)
+		}
+	})
+
+	return server, keycloakClient
+}
+
+func newTestRealm(t *testing.T, keycloakClient *keycloak.KeycloakClient, name string) {
+	t.Helper()
+
+	err := keycloakClient.NewRealm(context.Background(), &keycloak.Realm{Realm: name, Enabled: true})
+	if err != nil {
+		t.Fatalf("unable to create realm: %v", err)
+	}
+}
+
+func TestServerRealms(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.Realm != "test" || !realm.Enabled {
+		t.Errorf("unexpected realm %+v", realm)
+	}
+	if realm.DefaultRole == nil || realm.DefaultRole.Name != "default-roles-test" {
+		t.Errorf("expected realm to have a default role, got %+v", realm.DefaultRole)
+	}
+
+	realm.DisplayName = "Test"
+	if err := keycloakClient.UpdateRealm(ctx, realm); err != nil {
+		t.Fatal(err)
+	}
+
+	realm, err = keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.DisplayName != "Test" {
+		t.Errorf("expected display name to be updated, got %s", realm.DisplayName)
+	}
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test"}); err == nil {
+		t.Error("expected an error when creating a duplicate realm")
+	}
+
+	if err := keycloakClient.DeleteRealm(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = keycloakClient.GetRealm(ctx, "test")
+	if !keycloak.ErrorIs404(err) {
+		t.Errorf("expected a 404 after deleting the realm, got %v", err)
+	}
+}
+
+func TestServerClients(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	client := &keycloak.OpenidClient{
+		RealmId:                "test",
+		ClientId:               "my-client",
+		Enabled:                true,
+		PublicClient:           false,
+		ServiceAccountsEnabled: true,
+	}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	if client.Id == "" {
+		t.Fatal("expected client id to be set")
+	}
+
+	byClientId, err := keycloakClient.GetOpenidClientByClientId(ctx, "test", "my-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byClientId.Id != client.Id {
+		t.Errorf("expected client %s, got %s", client.Id, byClientId.Id)
+	}
+
+	serviceAccount, err := keycloakClient.GetOpenidClientServiceAccountUserId(ctx, "test", client.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serviceAccount.Username != "service-account-my-client" {
+		t.Errorf("unexpected service account username %s", serviceAccount.Username)
+	}
+
+	role := &keycloak.Role{RealmId: "test", ClientId: client.Id, Name: "client-role"}
+	if err := keycloakClient.CreateRole(ctx, role); err != nil {
+		t.Fatal(err)
+	}
+
+	clientRole, err := keycloakClient.GetRoleByName(ctx, "test", client.Id, "client-role")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !clientRole.ClientRole || clientRole.ClientId != client.Id {
+		t.Errorf("expected a role of client %s, got %+v", client.Id, clientRole)
+	}
+}
+
+func TestServerClientScopes(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	clientScope := &keycloak.SamlClientScope{RealmId: "test", Name: "my-scope"}
+	if err := keycloakClient.NewSamlClientScope(ctx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.NewSamlClientScope(ctx, &keycloak.SamlClientScope{RealmId: "test", Name: "my-scope"}); err == nil {
+		t.Error("expected a duplicate client scope to be rejected")
+	}
+	if err := keycloakClient.MarkClientScopesAsRealmOptional(ctx, "test", []string{"my-scope"}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &keycloak.SamlClient{RealmId: "test", ClientId: "my-saml-client"}
+	if err := keycloakClient.NewSamlClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+
+	defaultScopes, err := keycloakClient.GetSamlClientDefaultScopes(ctx, "test", client.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defaultScopes) != 2 || defaultScopes[0].Name != "role_list" || defaultScopes[1].Name != "saml_organization" {
+		t.Errorf("expected the realm default saml client scopes, got %v", defaultScopes)
+	}
+
+	optionalScopes, err := keycloakClient.GetSamlClientOptionalScopes(ctx, "test", client.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(optionalScopes) != 1 || optionalScopes[0].Id != clientScope.Id {
+		t.Errorf("expected the realm optional saml client scope, got %v", optionalScopes)
+	}
+
+	if err := keycloakClient.DeleteSamlClientScope(ctx, "test", clientScope.Id); err != nil {
+		t.Fatal(err)
+	}
+	optionalScopes, err = keycloakClient.GetSamlClientOptionalScopes(ctx, "test", client.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(optionalScopes) != 0 {
+		t.Errorf("expected deleted client scopes to be detached, got %v", optionalScopes)
+	}
+}
+
+func TestServerProtocolMappers(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	client := &keycloak.SamlClient{RealmId: "test", ClientId: "my-saml-client"}
+	if err := keycloakClient.NewSamlClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+
+	mapper := &keycloak.SamlHardcodedAttributeProtocolMapper{RealmId: "test", ClientId: client.Id, Name: "tenant", SamlAttributeName: "tenant", AttributeValue: "acme"}
+	if err := keycloakClient.NewSamlHardcodedAttributeProtocolMapper(ctx, mapper); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.NewSamlHardcodedAttributeProtocolMapper(ctx, &keycloak.SamlHardcodedAttributeProtocolMapper{RealmId: "test", ClientId: client.Id, Name: "tenant"}); err == nil {
+		t.Error("expected a duplicate protocol mapper name to be rejected")
+	}
+
+	mapper.AttributeValue = "globex"
+	if err := keycloakClient.UpdateSamlHardcodedAttributeProtocolMapper(ctx, mapper); err != nil {
+		t.Fatal(err)
+	}
+	fetched, err := keycloakClient.GetSamlHardcodedAttributeProtocolMapper(ctx, "test", client.Id, "", mapper.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched.AttributeValue != "globex" {
+		t.Errorf("expected the updated attribute value, got %s", fetched.AttributeValue)
+	}
+
+	if err := keycloakClient.DeleteSamlClient(ctx, "test", client.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.NewSamlClient(ctx, &keycloak.SamlClient{RealmId: "test", Id: client.Id, ClientId: "my-saml-client"}); err != nil {
+		t.Fatal(err)
+	}
+	protocolMappers, err := keycloakClient.ListGenericProtocolMappers(ctx, "test", client.Id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protocolMappers) != 0 {
+		t.Errorf("expected the protocol mappers of deleted clients to be deleted, got %v", protocolMappers)
+	}
+}
+
+func TestServerIdentityProviders(t *testing.T) {
+	ctx := context.Background()
+	server, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	identityProvider := &keycloak.IdentityProvider{
+		Realm:      "test",
+		Alias:      "gitlab",
+		ProviderId: "gitlab",
+		Config:     &keycloak.IdentityProviderConfig{ClientId: "id", ClientSecret: "secret"},
+	}
+	if err := keycloakClient.NewIdentityProvider(ctx, identityProvider); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.NewIdentityProvider(ctx, identityProvider); err == nil {
+		t.Error("expected a duplicate alias to be rejected")
+	}
+
+	fetched, err := keycloakClient.GetIdentityProvider(ctx, "test", "gitlab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched.InternalId == "" || fetched.Config.ClientSecret != "**********" {
+		t.Errorf("expected an internal id and a masked client secret, got %+v", fetched)
+	}
+
+	// sending the masked secret back keeps the secret
+	fetched.Config.DefaultScope = "openid"
+	if err := keycloakClient.UpdateIdentityProvider(ctx, fetched); err != nil {
+		t.Fatal(err)
+	}
+	stored := server.realmState["test"].identityProviders.items["gitlab"]
+	if secret := str(stored["config"].(object), "clientSecret"); secret != "secret" {
+		t.Errorf("expected the client secret to be kept, got %s", secret)
+	}
+
+	if err := keycloakClient.DeleteIdentityProvider(ctx, "test", "gitlab"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keycloakClient.GetIdentityProvider(ctx, "test", "gitlab"); !keycloak.ErrorIs404(err) {
+		t.Errorf("expected the identity provider to be deleted, got %v", err)
+	}
+}
+
+func TestServerImportIdentityProviderConfig(t *testing.T) {
+	ctx := context.Background()
+	server, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	discoveryUrl := server.URL + "/realms/master/.well-known/openid-configuration"
+	config, err := keycloakClient.ImportIdentityProviderConfig(ctx, "test", "keycloak-oidc", discoveryUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config["issuer"] != server.URL+"/realms/master" || config["tokenUrl"] != server.URL+"/realms/master/protocol/openid-connect/token" || config["useJwksUrl"] != "true" {
+		t.Errorf("unexpected config imported from the discovery document: %v", config)
+	}
+
+	if _, err := keycloakClient.ImportIdentityProviderConfig(ctx, "test", "keycloak-oidc", server.URL+"/realms/missing/.well-known/openid-configuration"); err == nil {
+		t.Error("expected the discovery document of a missing realm to be rejected")
+	}
+
+	config, err = keycloakClient.ImportIdentityProviderConfigFromDocument(ctx, "test", "saml", `
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com">
+	<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+		<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>MIIB
+		current</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+		<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>MIIBnext</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+		<md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>MIIBencryption</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+		<md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/slo"/>
+		<md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress</md:NameIDFormat>
+		<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+		<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+	</md:IDPSSODescriptor>
+</md:EntityDescriptor>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"idpEntityId":            "https://idp.example.com",
+		"singleSignOnServiceUrl": "https://idp.example.com/sso/post",
+		"postBindingResponse":    "true",
+		"singleLogoutServiceUrl": "https://idp.example.com/slo",
+		"postBindingLogout":      "false",
+		"nameIDPolicyFormat":     "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+		"signingCertificate":     "MIIBcurrent,MIIBnext",
+	}
+	for key, value := range expected {
+		if config[key] != value {
+			t.Errorf("expected %s to be imported as %s, got %s", key, value, config[key])
+		}
+	}
+}
+
+func TestServerUsersAndGroups(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	for _, username := range []string{"foo", "foo-user", "bar"} {
+		if err := keycloakClient.NewUser(ctx, &keycloak.User{RealmId: "test", Username: username, Enabled: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	user, err := keycloakClient.GetUserByUsername(ctx, "test", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || user.Username != "foo" {
+		t.Fatalf("expected to find user foo, got %+v", user)
+	}
+
+	parent := &keycloak.Group{RealmId: "test", Name: "parent"}
+	if err := keycloakClient.NewGroup(ctx, parent); err != nil {
+		t.Fatal(err)
+	}
+	child := &keycloak.Group{RealmId: "test", Name: "child", ParentId: parent.Id}
+	if err := keycloakClient.NewGroup(ctx, child); err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := keycloakClient.GetGroup(ctx, "test", child.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Path != "/parent/child" || group.ParentId != parent.Id {
+		t.Errorf("unexpected child group %+v", group)
+	}
+
+	if err := keycloakClient.AddUsersToGroup(ctx, "test", child.Id, []interface{}{"foo", "bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := keycloakClient.GetGroupMembers(ctx, "test", child.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 {
+		t.Errorf("expected 2 members, got %d", len(members))
+	}
+
+	if err := keycloakClient.DeleteGroup(ctx, "test", parent.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = keycloakClient.GetGroup(ctx, "test", child.Id)
+	if !keycloak.ErrorIs404(err) {
+		t.Errorf("expected child group to be deleted with its parent, got %v", err)
+	}
+}
+
+func TestServerRoleComposites(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	var roles []*keycloak.Role
+	for _, name := range []string{"parent", "first", "second"} {
+		role := &keycloak.Role{RealmId: "test", Name: name}
+		if err := keycloakClient.CreateRole(ctx, role); err != nil {
+			t.Fatal(err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := keycloakClient.AddCompositesToRole(ctx, roles[0], roles[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	composites, err := keycloakClient.GetRoleComposites(ctx, roles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(composites) != 2 {
+		t.Errorf("expected 2 composites, got %d", len(composites))
+	}
+
+	if err := keycloakClient.RemoveCompositesFromRole(ctx, roles[0], roles[1:2]); err != nil {
+		t.Fatal(err)
+	}
+
+	parent, err := keycloakClient.GetRole(ctx, "test", roles[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parent.Composite {
+		t.Error("expected role to still be a composite")
+	}
+
+	composites, err = keycloakClient.GetRoleComposites(ctx, roles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(composites) != 1 || composites[0].Name != "second" {
+		t.Errorf("expected only the second role to remain a composite, got %v", composites)
+	}
+}
+
+func TestServerRoleScopeMappings(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	client := &keycloak.OpenidClient{RealmId: "test", ClientId: "app", Enabled: true}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	clientScope := &keycloak.OpenidClientScope{RealmId: "test", Name: "scope"}
+	if err := keycloakClient.NewOpenidClientScope(ctx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+
+	realmRole := &keycloak.Role{RealmId: "test", Name: "realm-role"}
+	clientRoles := []*keycloak.Role{
+		{RealmId: "test", ClientId: client.Id, Name: "first"},
+		{RealmId: "test", ClientId: client.Id, Name: "second"},
+	}
+	for _, role := range append([]*keycloak.Role{realmRole}, clientRoles...) {
+		if err := keycloakClient.CreateRole(ctx, role); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, scope := range []struct{ clientId, clientScopeId string }{{client.Id, ""}, {"", clientScope.Id}} {
+		if err := keycloakClient.AddRealmRoleScopeMappings(ctx, "test", scope.clientId, scope.clientScopeId, []*keycloak.Role{realmRole}); err != nil {
+			t.Fatal(err)
+		}
+		if err := keycloakClient.AddClientRoleScopeMappings(ctx, "test", scope.clientId, scope.clientScopeId, client.Id, clientRoles); err != nil {
+			t.Fatal(err)
+		}
+
+		roleMapping, err := keycloakClient.GetRoleScopeMappings(ctx, "test", scope.clientId, scope.clientScopeId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(roleMapping.RealmMappings) != 1 || roleMapping.RealmMappings[0].Name != "realm-role" {
+			t.Errorf("expected the realm role in scope, got %v", roleMapping.RealmMappings)
+		}
+		if mapping := roleMapping.ClientMappings["app"]; mapping == nil || mapping.Id != client.Id || len(mapping.Mappings) != 2 {
+			t.Errorf("expected the client roles in scope, got %v", roleMapping.ClientMappings)
+		}
+
+		if err := keycloakClient.RemoveClientRoleScopeMappings(ctx, "test", scope.clientId, scope.clientScopeId, client.Id, clientRoles[:1]); err != nil {
+			t.Fatal(err)
+		}
+		mappedRole, err := keycloakClient.GetRoleScopeMapping(ctx, "test", scope.clientId, scope.clientScopeId, clientRoles[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mappedRole != nil {
+			t.Error("expected the first client role to be removed from the scope")
+		}
+	}
+
+	// deleted roles are removed from the scope
+	if err := keycloakClient.DeleteRole(ctx, "test", realmRole.Id); err != nil {
+		t.Fatal(err)
+	}
+	roleMapping, err := keycloakClient.GetRoleScopeMappings(ctx, "test", "", clientScope.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roleMapping.RealmMappings) != 0 || len(roleMapping.ClientMappings["app"].Mappings) != 1 {
+		t.Errorf("expected only the second client role in scope, got %v", roleMapping)
+	}
+}
+
+func TestServerAuthenticationFlows(t *testing.T) {
+	ctx := context.Background()
+	_, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	flow := &keycloak.AuthenticationFlow{RealmId: "test", Alias: "my-flow", ProviderId: "basic-flow"}
+	if err := keycloakClient.NewAuthenticationFlow(ctx, flow); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, authenticator := range []string{"auth-cookie", "auth-username-password-form"} {
+		execution := &keycloak.AuthenticationExecution{RealmId: "test", ParentFlowAlias: "my-flow", Authenticator: authenticator, Requirement: "ALTERNATIVE"}
+		if err := keycloakClient.NewAuthenticationExecution(ctx, execution); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	subFlow := &keycloak.AuthenticationSubFlow{RealmId: "test", ParentFlowAlias: "my-flow", Alias: "my-subflow", ProviderId: "basic-flow", Requirement: "CONDITIONAL"}
+	if err := keycloakClient.NewAuthenticationSubFlow(ctx, subFlow); err != nil {
+		t.Fatal(err)
+	}
+
+	executions, err := keycloakClient.ListAuthenticationExecutions(ctx, "test", "my-flow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(executions) != 3 {
+		t.Fatalf("expected 3 executions, got %d", len(executions))
+	}
+	if executions[2].FlowId != subFlow.Id || executions[2].Requirement != "CONDITIONAL" {
+		t.Errorf("unexpected subflow execution %+v", executions[2])
+	}
+
+	if err := keycloakClient.RaiseAuthenticationExecutionPriority(ctx, "test", executions[1].Id); err != nil {
+		t.Fatal(err)
+	}
+
+	reordered, err := keycloakClient.ListAuthenticationExecutions(ctx, "test", "my-flow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reordered[0].Id != executions[1].Id || reordered[1].Id != executions[0].Id {
+		t.Error("expected the first two executions to be swapped")
+	}
+
+	if err := keycloakClient.DeleteAuthenticationFlow(ctx, "test", flow.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = keycloakClient.GetAuthenticationSubFlow(ctx, "test", "my-flow", subFlow.Id)
+	if err == nil {
+		t.Error("expected subflow to be deleted with its parent")
+	}
+}
+
+func TestServerRefreshesRevokedTokens(t *testing.T) {
+	ctx := context.Background()
+	server, keycloakClient := newTestClient(t)
+
+	tokenRequests := server.TokenRequests
+
+	server.RevokeTokens()
+
+	if _, err := keycloakClient.GetRealm(ctx, "master"); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.TokenRequests != tokenRequests+1 {
+		t.Errorf("expected the client to request a new token once, got %d requests", server.TokenRequests-tokenRequests)
+	}
+}
+
+func TestServerEvents(t *testing.T) {
+	ctx := context.Background()
+	server, keycloakClient := newTestClient(t)
+
+	newTestRealm(t, keycloakClient, "test")
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 150; i++ {
+		server.AddEvents("test", keycloak.Event{Time: day.Add(time.Duration(i) * time.Minute).UnixMilli(), Type: "LOGIN", ClientId: "app", UserId: "user"})
+	}
+	server.AddEvents("test", keycloak.Event{Time: day.Add(48 * time.Hour).UnixMilli(), Type: "LOGIN_ERROR", ClientId: "app", Error: "invalid_user_credentials"})
+
+	events, err := keycloakClient.SearchRealmEvents(ctx, "test", &keycloak.EventSearch{Types: []string{"LOGIN", "LOGIN_ERROR"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 151 || events[0].Type != "LOGIN_ERROR" {
+		t.Errorf("expected every event across pages, newest first, got %d events", len(events))
+	}
+
+	events, err = keycloakClient.SearchRealmEvents(ctx, "test", &keycloak.EventSearch{ClientId: "app", DateTo: "2024-03-01"}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 10 || events[0].Type != "LOGIN" {
+		t.Errorf("expected 10 LOGIN events of the first day, got %+v", events)
+	}
+
+	server.AddAdminEvents("test",
+		keycloak.AdminEvent{Time: day.UnixMilli(), OperationType: "DELETE", ResourceType: "CLIENT", ResourcePath: "clients/1234", AuthDetails: keycloak.AdminEventAuthDetails{UserId: "admin"}},
+		keycloak.AdminEvent{Time: day.UnixMilli(), OperationType: "CREATE", ResourceType: "USER", ResourcePath: "users/5678"},
+	)
+
+	adminEvents, err := keycloakClient.SearchRealmAdminEvents(ctx, "test", &keycloak.AdminEventSearch{OperationTypes: []string{"DELETE"}, ResourcePath: "clients/*"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adminEvents) != 1 || adminEvents[0].AuthDetails.UserId != "admin" {
+		t.Errorf("expected the client deletion, got %+v", adminEvents)
+	}
+}
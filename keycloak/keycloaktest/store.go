@@ -0,0 +1,191 @@
+package keycloaktest
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// object is the JSON representation of any Keycloak entity, as sent and received by the admin API
+type object = map[string]interface{}
+
+// collection is an insertion-ordered set of objects keyed by id
+type collection struct {
+	order []string
+	items map[string]object
+}
+
+func newCollection() *collection {
+	return &collection{
+		items: map[string]object{},
+	}
+}
+
+func (c *collection) get(id string) (object, bool) {
+	o, ok := c.items[id]
+
+	return o, ok
+}
+
+func (c *collection) put(id string, o object) {
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+
+	c.items[id] = o
+}
+
+func (c *collection) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+
+	delete(c.items, id)
+	for i, existing := range c.order {
+		if existing == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+func (c *collection) list() []object {
+	result := make([]object, 0, len(c.order))
+	for _, id := range c.order {
+		result = append(result, c.items[id])
+	}
+
+	return result
+}
+
+// find returns the first object for which match returns true
+func (c *collection) find(match func(o object) bool) (object, bool) {
+	for _, o := range c.list() {
+		if match(o) {
+			return o, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *collection) filter(match func(o object) bool) []object {
+	var result []object
+	for _, o := range c.list() {
+		if match(o) {
+			result = append(result, o)
+		}
+	}
+
+	return result
+}
+
+func newId() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+// merge copies the top level attributes of update into o, which is how most admin API PUT endpoints behave
+func merge(o, update object) {
+	for k, v := range update {
+		if k == "id" {
+			continue
+		}
+		o[k] = v
+	}
+}
+
+// clone returns a deep copy of o so callers can't mutate stored state through a response
+func clone(o object) object {
+	b, err := json.Marshal(o)
+	if err != nil {
+		panic(err)
+	}
+
+	var result object
+	if err := json.Unmarshal(b, &result); err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+func str(o object, key string) string {
+	s, _ := o[key].(string)
+
+	return s
+}
+
+func boolean(o object, key string) bool {
+	b, _ := o[key].(bool)
+
+	return b
+}
+
+// number reads numeric attributes, which are float64 when decoded from a request and int when set by the fake
+func number(o object, key string) int {
+	switch n := o[key].(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+
+	return 0
+}
+
+// attributes returns the multivalued `attributes` of users, groups and roles
+func attributes(o object) map[string][]string {
+	result := map[string][]string{}
+
+	attrs, _ := o["attributes"].(map[string]interface{})
+	for k, v := range attrs {
+		values, _ := v.([]interface{})
+		for _, value := range values {
+			if s, ok := value.(string); ok {
+				result[k] = append(result[k], s)
+			}
+		}
+	}
+
+	return result
+}
+
+// mergeAttributes handles the string `attributes` of clients and client scopes, which Keycloak merges on update and
+// where a null value removes the attribute
+func mergeAttributes(o, update object) {
+	result, _ := o["attributes"].(map[string]interface{})
+	if result == nil {
+		result = object{}
+	}
+
+	attrs, _ := update["attributes"].(map[string]interface{})
+	for k, v := range attrs {
+		if v == nil {
+			delete(result, k)
+		} else {
+			result[k] = v
+		}
+	}
+
+	delete(update, "attributes")
+	o["attributes"] = result
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func sortByNumber(objects []object, key string) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return number(objects[i], key) < number(objects[j], key)
+	})
+}
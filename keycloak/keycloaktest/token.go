@@ -0,0 +1,187 @@
+package keycloaktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+// the token endpoint issues tokens to the fake's own admin client for any grant, and to the clients of a realm for
+// the client_credentials, password and token exchange grants
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request, realmName string) {
+	s.TokenRequests++
+
+	realm, exists := s.realmState[realmName]
+	if !exists {
+		writeError(w, http.StatusNotFound, "Realm does not exist")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	form := r.PostForm
+
+	claims := jwt.MapClaims{
+		"azp":   form.Get("client_id"),
+		"realm": realmName,
+		"scope": strings.TrimSpace("profile email " + form.Get("scope")),
+	}
+	response := object{
+		"token_type": "Bearer",
+		"scope":      claims["scope"],
+	}
+
+	if form.Get("client_id") == ClientId && (form.Get("client_secret") == ClientSecret || form.Get("client_assertion") != "") {
+		claims["sub"] = ClientId
+	} else {
+		client, authenticated := realm.authenticateClient(form)
+		if !authenticated {
+			writeTokenError(w, http.StatusUnauthorized, "unauthorized_client", "Invalid client or Invalid client credentials")
+			return
+		}
+
+		status, code, description := s.grant(realm, client, form, claims, response)
+		if code != "" {
+			writeTokenError(w, status, code, description)
+			return
+		}
+	}
+
+	expiry := time.Now().Add(s.TokenLifetime)
+	claims["jti"] = newId()
+	claims["exp"] = jwt.NewNumericDate(expiry)
+	claims["iat"] = jwt.NewNumericDate(time.Now())
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(ClientSecret))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.tokens[accessToken] = expiry
+
+	response["access_token"] = accessToken
+	response["expires_in"] = int(s.TokenLifetime.Seconds())
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// authenticateClient checks the secret of confidential clients, client assertions are trusted as long as the client
+// is configured for signed JWTs
+func (r *realm) authenticateClient(form url.Values) (object, bool) {
+	client, exists := r.clientByClientId(form.Get("client_id"))
+	if !exists || boolean(client, "bearerOnly") {
+		return nil, false
+	}
+
+	if boolean(client, "publicClient") {
+		return client, true
+	}
+
+	if form.Get("client_assertion") != "" {
+		return client, str(client, "clientAuthenticatorType") == "client-jwt"
+	}
+
+	return client, form.Get("client_secret") == r.clientSecrets[str(client, "id")]
+}
+
+// grant checks the grant of a realm client and fills in the subject of the token, an error code is returned on failure
+func (s *Server) grant(r *realm, client object, form url.Values, claims jwt.MapClaims, response object) (int, string, string) {
+	switch form.Get("grant_type") {
+	case "client_credentials":
+		user, exists := r.serviceAccountUser(str(client, "id"))
+		if !exists {
+			return http.StatusUnauthorized, "unauthorized_client", "Client not enabled to retrieve service account"
+		}
+		claims["sub"] = str(user, "id")
+	case "password":
+		username := strings.ToLower(form.Get("username"))
+		user, exists := r.users.find(func(o object) bool { return str(o, "username") == username })
+		if !exists || !r.checkPassword(str(user, "id"), form.Get("password")) {
+			return http.StatusUnauthorized, "invalid_grant", "Invalid user credentials"
+		}
+		claims["sub"] = str(user, "id")
+		response["refresh_token"] = newId()
+	case tokenExchangeGrantType:
+		expiry, ok := s.tokens[form.Get("subject_token")]
+		if !ok || time.Now().After(expiry) {
+			return http.StatusBadRequest, "invalid_token", "Invalid token"
+		}
+		subject, err := jwt.Parse(form.Get("subject_token"), func(*jwt.Token) (interface{}, error) {
+			return []byte(ClientSecret), nil
+		})
+		if err != nil {
+			return http.StatusBadRequest, "invalid_token", err.Error()
+		}
+		claims["sub"] = subject.Claims.(jwt.MapClaims)["sub"]
+		if audience := form.Get("audience"); audience != "" {
+			if _, exists := r.clientByClientId(audience); !exists {
+				return http.StatusBadRequest, "invalid_client", "Audience not found"
+			}
+			claims["aud"] = audience
+		}
+		issuedTokenType := form.Get("requested_token_type")
+		if issuedTokenType == "" {
+			issuedTokenType = "urn:ietf:params:oauth:token-type:access_token"
+		}
+		response["issued_token_type"] = issuedTokenType
+	default:
+		return http.StatusBadRequest, "unsupported_grant_type", "Unsupported grant_type"
+	}
+
+	return 0, "", ""
+}
+
+func (r *realm) checkPassword(userId, password string) bool {
+	for _, credential := range r.credentials[userId] {
+		if str(credential, "type") == "password" {
+			return str(credential, "secretData") == `{"value":"`+password+`"}`
+		}
+	}
+
+	return false
+}
+
+func writeTokenError(w http.ResponseWriter, status int, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(object{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// handleDiscovery serves the OpenID Connect discovery document of a realm, which identity providers brokering to
+// the fake can be imported from
+func (s *Server) handleDiscovery(w http.ResponseWriter, realmName string) {
+	if _, exists := s.realmState[realmName]; !exists {
+		writeError(w, http.StatusNotFound, "Realm does not exist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.discoveryDocument(realmName))
+}
+
+func (s *Server) discoveryDocument(realmName string) object {
+	issuer := s.URL + "/realms/" + realmName
+
+	return object{
+		"issuer":                 issuer,
+		"authorization_endpoint": issuer + "/protocol/openid-connect/auth",
+		"token_endpoint":         issuer + "/protocol/openid-connect/token",
+		"userinfo_endpoint":      issuer + "/protocol/openid-connect/userinfo",
+		"end_session_endpoint":   issuer + "/protocol/openid-connect/logout",
+		"jwks_uri":               issuer + "/protocol/openid-connect/certs",
+	}
+}
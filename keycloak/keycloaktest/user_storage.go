@@ -0,0 +1,113 @@
+package keycloaktest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// There is no directory behind the user storage providers of the fake, synchronizing them never changes anything.
+// The user syncs of the providers connected to an LDAP server registered with FailLdapSync report failed entries.
+
+func (s *Server) handleUserStorage(req *request, r *realm, segments []string) *response {
+	if req.method != http.MethodPost || len(segments) < 2 {
+		return nil
+	}
+
+	provider, exists := r.components.get(segments[0])
+	if !exists || str(provider, "providerType") != "org.keycloak.storage.UserStorageProvider" {
+		return notFound("Could not find component")
+	}
+
+	switch {
+	case len(segments) == 2 && segments[1] == "sync":
+		action := req.queryValue("action")
+		if action != "triggerFullSync" && action != "triggerChangedUsersSync" {
+			return notFound("Unknown action: " + action)
+		}
+
+		if componentConfig(provider, "enabled") == "false" {
+			return ok(syncResult(true, "Provider is disabled"))
+		}
+
+		if failed := s.ldapSyncFailures[componentConfig(provider, "connectionUrl")]; failed > 0 {
+			result := syncResult(false, fmt.Sprintf("0 imported users, 0 updated users, %d users failed sync! See server log for more details", failed))
+			result["failed"] = failed
+			return ok(result)
+		}
+
+		return ok(syncResult(false, "0 imported users, 0 updated users"))
+	case len(segments) == 4 && segments[1] == "mappers" && segments[3] == "sync":
+		mapper, exists := r.components.get(segments[2])
+		if !exists || str(mapper, "parentId") != str(provider, "id") {
+			return notFound("Could not find mapper")
+		}
+
+		direction := req.queryValue("direction")
+		if direction != "fedToKeycloak" && direction != "keycloakToFed" {
+			return badRequest("Unknown direction: " + direction)
+		}
+
+		return ok(syncResult(false, "0 imported groups, 0 updated groups, 0 removed groups"))
+	}
+
+	return nil
+}
+
+// handleTestLdapConnection answers the LDAP connection tests with the LDAP servers registered with AddLdapServer
+func (s *Server) handleTestLdapConnection(req *request, r *realm) *response {
+	if req.method != http.MethodPost {
+		return nil
+	}
+
+	test := req.object()
+	action := str(test, "action")
+	if action != "testConnection" && action != "testAuthentication" {
+		return badRequest("ldapErrorUnknownAction")
+	}
+
+	bindCredentials, exists := s.ldapServers[str(test, "connectionUrl")]
+	if !exists {
+		return badRequest("Error when trying to connect to LDAP: 'CommunicationError'")
+	}
+
+	if action == "testConnection" {
+		return noContent()
+	}
+
+	// like keycloak, the stored credential of the provider is used when the masked one is sent back
+	bindCredential := str(test, "bindCredential")
+	if component, exists := r.components.get(str(test, "componentId")); exists && bindCredential == "**********" {
+		bindCredential = componentConfig(component, "bindCredential")
+	}
+
+	password, exists := bindCredentials[str(test, "bindDn")]
+	if str(test, "authType") != "simple" || !exists || password != bindCredential {
+		return badRequest("Error when trying to authenticate to LDAP: 'AuthenticationFailure'")
+	}
+
+	return noContent()
+}
+
+func syncResult(ignored bool, status string) object {
+	return object{
+		"ignored": ignored,
+		"added":   0,
+		"updated": 0,
+		"removed": 0,
+		"failed":  0,
+		"status":  status,
+	}
+}
+
+// componentConfig returns the first value of a component config entry
+func componentConfig(component object, key string) string {
+	config, _ := component["config"].(map[string]interface{})
+	values, _ := config[key].([]interface{})
+	if len(values) == 0 {
+		return ""
+	}
+
+	value, _ := values[0].(string)
+
+	return value
+}
@@ -0,0 +1,156 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type LdapCertificateMapper struct {
+	Id                   string
+	Name                 string
+	RealmId              string
+	LdapUserFederationId string
+
+	LdapAttribute           string
+	IsMandatoryInLdap       bool
+	ReadOnly                bool
+	AlwaysReadValueFromLdap bool
+	UserModelAttribute      string
+	ForceDefaultValue       bool
+	AttributeDefaultValue   string
+	IsBinaryAttribute       bool
+	IsDerFormatted          bool
+}
+
+func convertFromLdapCertificateMapperToComponent(ldapCertificateMapper *LdapCertificateMapper) *component {
+	return &component{
+		Id:           ldapCertificateMapper.Id,
+		Name:         ldapCertificateMapper.Name,
+		ProviderId:   "certificate-ldap-mapper",
+		ProviderType: "org.keycloak.storage.ldap.mappers.LDAPStorageMapper",
+		ParentId:     ldapCertificateMapper.LdapUserFederationId,
+		Config: map[string][]string{
+			"ldap.attribute": {
+				ldapCertificateMapper.LdapAttribute,
+			},
+			"is.mandatory.in.ldap": {
+				strconv.FormatBool(ldapCertificateMapper.IsMandatoryInLdap),
+			},
+			"read.only": {
+				strconv.FormatBool(ldapCertificateMapper.ReadOnly),
+			},
+			"always.read.value.from.ldap": {
+				strconv.FormatBool(ldapCertificateMapper.AlwaysReadValueFromLdap),
+			},
+			"user.model.attribute": {
+				ldapCertificateMapper.UserModelAttribute,
+			},
+			"attribute.force.default": {
+				strconv.FormatBool(ldapCertificateMapper.ForceDefaultValue),
+			},
+			"attribute.default.value": {
+				ldapCertificateMapper.AttributeDefaultValue,
+			},
+			"is.binary.attribute": {
+				strconv.FormatBool(ldapCertificateMapper.IsBinaryAttribute),
+			},
+			"is.der.formatted": {
+				strconv.FormatBool(ldapCertificateMapper.IsDerFormatted),
+			},
+		},
+	}
+}
+
+func convertFromComponentToLdapCertificateMapper(component *component, realmId string) (*LdapCertificateMapper, error) {
+	isMandatoryInLdap, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("is.mandatory.in.ldap"))
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("read.only"))
+	if err != nil {
+		return nil, err
+	}
+
+	alwaysReadValueFromLdap, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("always.read.value.from.ldap"))
+	if err != nil {
+		return nil, err
+	}
+
+	forceDefaultValue, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("attribute.force.default"))
+	if err != nil {
+		return nil, err
+	}
+
+	isBinaryAttribute, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("is.binary.attribute"))
+	if err != nil {
+		return nil, err
+	}
+
+	isDerFormatted, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("is.der.formatted"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &LdapCertificateMapper{
+		Id:                   component.Id,
+		Name:                 component.Name,
+		RealmId:              realmId,
+		LdapUserFederationId: component.ParentId,
+
+		LdapAttribute:           component.getConfig("ldap.attribute"),
+		IsMandatoryInLdap:       isMandatoryInLdap,
+		ReadOnly:                readOnly,
+		AlwaysReadValueFromLdap: alwaysReadValueFromLdap,
+		UserModelAttribute:      component.getConfig("user.model.attribute"),
+		ForceDefaultValue:       forceDefaultValue,
+		AttributeDefaultValue:   component.getConfig("attribute.default.value"),
+		IsBinaryAttribute:       isBinaryAttribute,
+		IsDerFormatted:          isDerFormatted,
+	}, nil
+}
+
+// ValidateLdapCertificateMapper mirrors the validation done by Keycloak: binary attributes are never stored in the
+// Keycloak database, so they can only be used when the value is always read from LDAP
+func (keycloakClient *KeycloakClient) ValidateLdapCertificateMapper(ctx context.Context, ldapCertificateMapper *LdapCertificateMapper) error {
+	if ldapCertificateMapper.IsBinaryAttribute && !ldapCertificateMapper.AlwaysReadValueFromLdap {
+		return fmt.Errorf("validation error: always_read_value_from_ldap must be true when is_binary_attribute is true")
+	}
+
+	if ldapCertificateMapper.IsDerFormatted && !ldapCertificateMapper.IsBinaryAttribute {
+		return fmt.Errorf("validation error: is_binary_attribute must be true when is_der_formatted is true, DER certificates can only be stored in binary attributes")
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) NewLdapCertificateMapper(ctx context.Context, ldapCertificateMapper *LdapCertificateMapper) error {
+	_, location, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/components", ldapCertificateMapper.RealmId), convertFromLdapCertificateMapperToComponent(ldapCertificateMapper))
+	if err != nil {
+		return err
+	}
+
+	ldapCertificateMapper.Id = getIdFromLocationHeader(location)
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetLdapCertificateMapper(ctx context.Context, realmId, id string) (*LdapCertificateMapper, error) {
+	var component *component
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), &component, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertFromComponentToLdapCertificateMapper(component, realmId)
+}
+
+func (keycloakClient *KeycloakClient) UpdateLdapCertificateMapper(ctx context.Context, ldapCertificateMapper *LdapCertificateMapper) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/components/%s", ldapCertificateMapper.RealmId, ldapCertificateMapper.Id), convertFromLdapCertificateMapperToComponent(ldapCertificateMapper))
+}
+
+func (keycloakClient *KeycloakClient) DeleteLdapCertificateMapper(ctx context.Context, realmId, id string) error {
+	return keycloakClient.DeleteComponent(ctx, realmId, id)
+}
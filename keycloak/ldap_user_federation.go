@@ -458,6 +458,47 @@ func (keycloakClient *KeycloakClient) ValidateLdapUserFederation(ctx context.Con
 	return nil
 }
 
+const (
+	LdapTestActionConnection     = "testConnection"
+	LdapTestActionAuthentication = "testAuthentication"
+)
+
+type testLdapConnection struct {
+	Action            string `json:"action"`
+	ComponentId       string `json:"componentId,omitempty"`
+	ConnectionUrl     string `json:"connectionUrl"`
+	AuthType          string `json:"authType,omitempty"`
+	BindDn            string `json:"bindDn,omitempty"`
+	BindCredential    string `json:"bindCredential,omitempty"`
+	UseTruststoreSpi  string `json:"useTruststoreSpi,omitempty"`
+	ConnectionTimeout string `json:"connectionTimeout,omitempty"`
+	StartTls          string `json:"startTls,omitempty"`
+}
+
+// TestLdapUserFederationConnection asks Keycloak to connect to the LDAP server of the user federation provider, action is
+// one of LdapTestActionConnection or LdapTestActionAuthentication. The latter also binds with the configured bind DN.
+func (keycloakClient *KeycloakClient) TestLdapUserFederationConnection(ctx context.Context, realmId string, ldap *LdapUserFederation, action string) error {
+	// the component carries the values the way keycloak expects them, like the connection timeout in milliseconds
+	component, err := convertFromLdapUserFederationToComponent(ldap)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/testLDAPConnection", realmId), &testLdapConnection{
+		Action:            action,
+		ComponentId:       ldap.Id,
+		ConnectionUrl:     component.getConfig("connectionUrl"),
+		AuthType:          component.getConfig("authType"),
+		BindDn:            component.getConfig("bindDn"),
+		BindCredential:    component.getConfig("bindCredential"),
+		UseTruststoreSpi:  component.getConfig("useTruststoreSpi"),
+		ConnectionTimeout: component.getConfig("connectionTimeout"),
+		StartTls:          component.getConfig("startTls"),
+	})
+
+	return err
+}
+
 func (keycloakClient *KeycloakClient) NewLdapUserFederation(ctx context.Context, realmId string, ldapUserFederation *LdapUserFederation) error {
 	component, err := convertFromLdapUserFederationToComponent(ldapUserFederation)
 	if err != nil {
@@ -534,6 +575,12 @@ func (keycloakClient *KeycloakClient) GetLdapUserFederationMappers(ctx context.C
 				return nil, err
 			}
 			ldapUserFederationMappers = append(ldapUserFederationMappers, mapper)
+		case "certificate-ldap-mapper":
+			mapper, err := convertFromComponentToLdapCertificateMapper(component, realmId)
+			if err != nil {
+				return nil, err
+			}
+			ldapUserFederationMappers = append(ldapUserFederationMappers, mapper)
 		case "role-ldap-mapper":
 			mapper, err := convertFromComponentToLdapRoleMapper(component, realmId)
 			if err != nil {
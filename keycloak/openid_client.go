@@ -87,6 +87,12 @@ type OpenidClientAttributes struct {
 	PostLogoutRedirectUris                   types.KeycloakSliceHashDelimited `json:"post.logout.redirect.uris,omitempty"`
 	StandardTokenExchangeEnabled             types.KeycloakBoolQuoted         `json:"standard.token.exchange.enabled,omitempty"`
 	AllowRefreshTokenInStandardTokenExchange string                           `json:"standard.token.exchange.enableRefreshRequestedTokenType,omitempty"`
+	UseJwksUrl                               types.KeycloakBoolQuoted         `json:"use.jwks.url"`
+	JwksUrl                                  string                           `json:"jwks.url"`
+	UseJwksString                            types.KeycloakBoolQuoted         `json:"use.jwks.string"`
+	JwksString                               string                           `json:"jwks.string"`
+	X509SubjectDn                            string                           `json:"x509.subjectdn"`
+	X509AllowRegexPatternComparison          types.KeycloakBoolQuoted         `json:"x509.allow.regex.pattern.comparison"`
 }
 
 type OpenidAuthenticationFlowBindingOverrides struct {
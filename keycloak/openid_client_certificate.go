@@ -0,0 +1,49 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// OpenidClientJwtCredentialAttribute is the attribute prefix of the certificate verifying the JWTs signed by clients
+// authenticating with the client-jwt authenticator
+const OpenidClientJwtCredentialAttribute = "jwt.credential"
+
+// OpenidClientCertificate is the certificate stored in the attributes of a client under an attribute prefix, like
+// jwt.credential. Keycloak only returns the private key when it generates the keypair.
+type OpenidClientCertificate struct {
+	PrivateKey  string `json:"privateKey,omitempty"`
+	PublicKey   string `json:"publicKey,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+	Kid         string `json:"kid,omitempty"`
+}
+
+func (keycloakClient *KeycloakClient) GetOpenidClientCertificate(ctx context.Context, realmId, clientId, attribute string) (*OpenidClientCertificate, error) {
+	var certificate OpenidClientCertificate
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/certificates/%s", realmId, clientId, attribute), &certificate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificate, nil
+}
+
+// UploadOpenidClientCertificate replaces the certificate of the client with a PEM encoded certificate, leaving the
+// private key alone
+func (keycloakClient *KeycloakClient) UploadOpenidClientCertificate(ctx context.Context, realmId, clientId, attribute, certificatePem string) (*OpenidClientCertificate, error) {
+	body, err := keycloakClient.postMultipart(ctx, fmt.Sprintf("/realms/%s/clients/%s/certificates/%s/upload-certificate", realmId, clientId, attribute), map[string]string{
+		"keystoreFormat": "Certificate PEM",
+	}, "file", "certificate.pem", []byte(certificatePem))
+	if err != nil {
+		return nil, err
+	}
+
+	var certificate OpenidClientCertificate
+	if err := json.Unmarshal(body, &certificate); err != nil {
+		return nil, err
+	}
+
+	return &certificate, nil
+}
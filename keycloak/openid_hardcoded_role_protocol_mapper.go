@@ -41,6 +41,24 @@ func (keycloakClient *KeycloakClient) getRolePropFromRole(ctx context.Context, r
 	return role.Name, nil
 }
 
+// getRoleFromRoleProp looks up the role referenced by a protocol mapper, either by its name for realm roles, or by the
+// client id and name of client roles
+func (keycloakClient *KeycloakClient) getRoleFromRoleProp(ctx context.Context, realmId, roleProp string) (*Role, error) {
+	roleClientId, roleName := parseRoleClientIdAndName(roleProp)
+
+	var roleClientUId = ""
+	if roleClientId != "" {
+		client, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, roleClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		roleClientUId = client.Id
+	}
+
+	return keycloakClient.GetRoleByName(ctx, realmId, roleClientUId, roleName)
+}
+
 func (mapper *OpenIdHardcodedRoleProtocolMapper) convertToGenericProtocolMapper(roleProp string) *protocolMapper {
 	return &protocolMapper{
 		Id:             mapper.Id,
@@ -73,19 +91,7 @@ func (keycloakClient *KeycloakClient) GetOpenIdHardcodedRoleProtocolMapper(ctx c
 		return nil, err
 	}
 
-	roleClientId, roleName := parseRoleClientIdAndName(protocolMapper.Config[roleField])
-
-	var roleClientUId = ""
-	if roleClientId != "" {
-		client, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, roleClientId)
-		if err != nil {
-			return nil, err
-		}
-
-		roleClientUId = client.Id
-	}
-
-	role, err := keycloakClient.GetRoleByName(ctx, realmId, roleClientUId, roleName)
+	role, err := keycloakClient.getRoleFromRoleProp(ctx, realmId, protocolMapper.Config[roleField])
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,84 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	OrganizationMembershipManaged   = "MANAGED"
+	OrganizationMembershipUnmanaged = "UNMANAGED"
+)
+
+// OrganizationMember is returned by GET /realms/${realm}/organizations/${organizationId}/members. Managed members
+// are brokered from one of the organization's identity providers, and removing them from the organization deletes them.
+type OrganizationMember struct {
+	Id             string `json:"id"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	MembershipType string `json:"membershipType"`
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationMembers(ctx context.Context, realm, organizationId string) ([]*OrganizationMember, error) {
+	var members []*OrganizationMember
+	var first, pagination = 0, 50
+	var iterationMembers []*OrganizationMember
+
+	// the members endpoint returns only 10 members by default
+	for ok := true; ok; ok = len(iterationMembers) > 0 {
+		iterationMembers = nil
+		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members?max=%d&first=%d", realm, organizationId, pagination, first), &iterationMembers, nil)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, iterationMembers...)
+		first += pagination
+	}
+
+	return members, nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationMember(ctx context.Context, realm, organizationId, userId string) (*OrganizationMember, error) {
+	var member OrganizationMember
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members/%s", realm, organizationId, userId), &member, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// AddOrganizationMember adds an existing user to the organization as an unmanaged member
+func (keycloakClient *KeycloakClient) AddOrganizationMember(ctx context.Context, realm, organizationId, userId string) error {
+	// the request body is the id of the user, as a JSON string
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members", realm, organizationId), userId)
+
+	return err
+}
+
+// InviteExistingUserToOrganization sends an email to the user, who only becomes a member once the invitation is accepted
+func (keycloakClient *KeycloakClient) InviteExistingUserToOrganization(ctx context.Context, realm, organizationId, userId string) error {
+	form := url.Values{}
+	form.Set("id", userId)
+
+	return keycloakClient.postForm(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members/invite-existing-user", realm, organizationId), form)
+}
+
+func (keycloakClient *KeycloakClient) RemoveOrganizationMember(ctx context.Context, realm, organizationId, userId string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members/%s", realm, organizationId, userId), nil)
+}
+
+func (keycloakClient *KeycloakClient) postForm(ctx context.Context, path string, form url.Values) error {
+	resourceUrl := keycloakClient.baseUrl + apiUrl + path
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, resourceUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-type", "application/x-www-form-urlencoded")
+	_, _, err = keycloakClient.sendRequest(ctx, request, []byte(form.Encode()))
+	return err
+}
@@ -21,6 +21,7 @@ var (
 	addToTokenIntrospectionField         = "introspection.token.claim"
 	attributeNameField                   = "attribute.name"
 	attributeNameFormatField             = "attribute.nameformat"
+	attributeValueField                  = "attribute.value"
 	claimNameField                       = "claim.name"
 	claimValueField                      = "claim.value"
 	claimValueTypeField                  = "jsonType.label"
@@ -29,6 +30,7 @@ var (
 	includedClientAudienceField          = "included.client.audience"
 	includedCustomAudienceField          = "included.custom.audience"
 	multivaluedField                     = "multivalued"
+	newRoleNameField                     = "new.role.name"
 	samlScriptField                      = "Script" // needs to start with uppercase S for SAML script mapper
 	scriptField                          = "script"
 	singleValueAttributeField            = "single"
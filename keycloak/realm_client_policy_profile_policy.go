@@ -27,6 +27,25 @@ func (keycloakClient *KeycloakClient) UpdateRealmClientPolicyProfilePolicies(ctx
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), policies)
 }
 
+// ModifyRealmClientPolicyProfilePolicies applies modify to the client policies of a realm and saves the result.
+// Keycloak only allows to replace the whole policies document, so concurrent modifications within the same realm are serialized.
+func (keycloakClient *KeycloakClient) ModifyRealmClientPolicyProfilePolicies(ctx context.Context, realmId string, modify func(policies *RealmClientPolicyProfilePolicies) error) error {
+	unlock := keycloakClient.lockRealmDocument(realmId, "client-policies/policies")
+	defer unlock()
+
+	policies, err := keycloakClient.GetAllRealmClientPolicyProfilePolices(ctx, realmId)
+	if err != nil {
+		return err
+	}
+
+	err = modify(policies)
+	if err != nil {
+		return err
+	}
+
+	return keycloakClient.UpdateRealmClientPolicyProfilePolicies(ctx, realmId, policies)
+}
+
 func (keycloakClient *KeycloakClient) GetAllRealmClientPolicyProfilePolices(ctx context.Context, realmId string) (*RealmClientPolicyProfilePolicies, error) {
 	var realmClientPolicyProfilePolicies *RealmClientPolicyProfilePolicies
 
@@ -49,6 +49,24 @@ ScopeNames:
 	return scopeIds, nil
 }
 
+// GetRealmClientScopeProtocols maps the name of every client scope of the realm to its protocol, which isn't part of the
+// default and optional client scopes returned by keycloak
+func (keycloakClient *KeycloakClient) GetRealmClientScopeProtocols(ctx context.Context, realmId string) (map[string]string, error) {
+	var clientScopes []OpenidClientScope
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-scopes", realmId), &clientScopes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	protocols := make(map[string]string, len(clientScopes))
+	for _, clientScope := range clientScopes {
+		protocols[clientScope.Name] = clientScope.Protocol
+	}
+
+	return protocols, nil
+}
+
 func (keycloakClient *KeycloakClient) resolveAndHandleClientScopes(ctx context.Context, realmId string, scopeNames []string, handler func(context.Context, string, string) error) error {
 	scopeIds, err := keycloakClient.resolveClientScopeNamesIntoIds(ctx, realmId, scopeNames)
 	if err != nil {
@@ -3,6 +3,8 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
 type RealmEventsConfig struct {
@@ -28,3 +30,166 @@ func (keycloakClient *KeycloakClient) GetRealmEventsConfig(ctx context.Context,
 func (keycloakClient *KeycloakClient) UpdateRealmEventsConfig(ctx context.Context, realmId string, realmEventsConfig *RealmEventsConfig) error {
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/events/config", realmId), realmEventsConfig)
 }
+
+type Event struct {
+	Time      int64             `json:"time"`
+	Type      string            `json:"type"`
+	RealmId   string            `json:"realmId"`
+	ClientId  string            `json:"clientId"`
+	UserId    string            `json:"userId"`
+	SessionId string            `json:"sessionId"`
+	IpAddress string            `json:"ipAddress"`
+	Error     string            `json:"error"`
+	Details   map[string]string `json:"details"`
+}
+
+// EventSearch holds the query parameters of the events endpoint, empty values are not sent. The dates are either
+// formatted as yyyy-MM-dd, or milliseconds since the epoch, which keycloak supports since version 23.
+type EventSearch struct {
+	Types     []string
+	ClientId  string
+	UserId    string
+	IpAddress string
+	DateFrom  string
+	DateTo    string
+}
+
+func (search *EventSearch) query() url.Values {
+	query := url.Values{}
+
+	for _, eventType := range search.Types {
+		query.Add("type", eventType)
+	}
+	addQueryValue(query, "client", search.ClientId)
+	addQueryValue(query, "user", search.UserId)
+	addQueryValue(query, "ipAddress", search.IpAddress)
+	addQueryValue(query, "dateFrom", search.DateFrom)
+	addQueryValue(query, "dateTo", search.DateTo)
+
+	return query
+}
+
+type AdminEventAuthDetails struct {
+	RealmId   string `json:"realmId"`
+	ClientId  string `json:"clientId"`
+	UserId    string `json:"userId"`
+	IpAddress string `json:"ipAddress"`
+}
+
+type AdminEvent struct {
+	Time           int64                 `json:"time"`
+	RealmId        string                `json:"realmId"`
+	AuthDetails    AdminEventAuthDetails `json:"authDetails"`
+	OperationType  string                `json:"operationType"`
+	ResourceType   string                `json:"resourceType"`
+	ResourcePath   string                `json:"resourcePath"`
+	Representation string                `json:"representation"`
+	Error          string                `json:"error"`
+	Details        map[string]string     `json:"details"`
+}
+
+// AdminEventSearch holds the query parameters of the admin events endpoint, see EventSearch for the format of the dates
+type AdminEventSearch struct {
+	OperationTypes []string
+	ResourceTypes  []string
+	ResourcePath   string
+	AuthRealmId    string
+	AuthClientId   string
+	AuthUserId     string
+	AuthIpAddress  string
+	DateFrom       string
+	DateTo         string
+}
+
+func (search *AdminEventSearch) query() url.Values {
+	query := url.Values{}
+
+	for _, operationType := range search.OperationTypes {
+		query.Add("operationTypes", operationType)
+	}
+	for _, resourceType := range search.ResourceTypes {
+		query.Add("resourceTypes", resourceType)
+	}
+	addQueryValue(query, "resourcePath", search.ResourcePath)
+	addQueryValue(query, "authRealm", search.AuthRealmId)
+	addQueryValue(query, "authClient", search.AuthClientId)
+	addQueryValue(query, "authUser", search.AuthUserId)
+	addQueryValue(query, "authIpAddress", search.AuthIpAddress)
+	addQueryValue(query, "dateFrom", search.DateFrom)
+	addQueryValue(query, "dateTo", search.DateTo)
+
+	return query
+}
+
+func addQueryValue(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// SearchRealmEvents returns the events of the realm matching the search, newest first, going through all pages of
+// results. When maxResults is greater than zero, no more than maxResults events are returned.
+func (keycloakClient *KeycloakClient) SearchRealmEvents(ctx context.Context, realmId string, search *EventSearch, maxResults int) ([]*Event, error) {
+	var events []*Event
+	var first, pagination = 0, 100
+
+	query := search.query()
+
+	for {
+		max := pagination
+		if maxResults > 0 && maxResults-len(events) < max {
+			max = maxResults - len(events)
+		}
+
+		query.Set("first", strconv.Itoa(first))
+		query.Set("max", strconv.Itoa(max))
+
+		var page []*Event
+		err := keycloakClient.getWithQuery(ctx, fmt.Sprintf("/realms/%s/events", realmId), &page, query)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, page...)
+		first += len(page)
+
+		if len(page) < max || (maxResults > 0 && len(events) >= maxResults) {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// SearchRealmAdminEvents is like SearchRealmEvents, for the events of the changes made through the admin API
+func (keycloakClient *KeycloakClient) SearchRealmAdminEvents(ctx context.Context, realmId string, search *AdminEventSearch, maxResults int) ([]*AdminEvent, error) {
+	var adminEvents []*AdminEvent
+	var first, pagination = 0, 100
+
+	query := search.query()
+
+	for {
+		max := pagination
+		if maxResults > 0 && maxResults-len(adminEvents) < max {
+			max = maxResults - len(adminEvents)
+		}
+
+		query.Set("first", strconv.Itoa(first))
+		query.Set("max", strconv.Itoa(max))
+
+		var page []*AdminEvent
+		err := keycloakClient.getWithQuery(ctx, fmt.Sprintf("/realms/%s/admin-events", realmId), &page, query)
+		if err != nil {
+			return nil, err
+		}
+
+		adminEvents = append(adminEvents, page...)
+		first += len(page)
+
+		if len(page) < max || (maxResults > 0 && len(adminEvents) >= maxResults) {
+			break
+		}
+	}
+
+	return adminEvents, nil
+}
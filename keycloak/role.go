@@ -65,10 +65,15 @@ func (keycloakClient *KeycloakClient) CreateRole(ctx context.Context, role *Role
 	return keycloakClient.UpdateRole(ctx, role)
 }
 
+// keycloak leaves the attributes out of the roles it lists, unless asked for their full representation
+var fullRoleRepresentation = map[string]string{
+	"briefRepresentation": "false",
+}
+
 func (keycloakClient *KeycloakClient) GetRealmRoles(ctx context.Context, realmId string) ([]*Role, error) {
 	var roles []*Role
 
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/roles", realmId), &roles, nil)
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/roles", realmId), &roles, fullRoleRepresentation)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +91,7 @@ func (keycloakClient *KeycloakClient) GetClientRoles(ctx context.Context, realmI
 	for _, client := range clients {
 		var rolesClient []*Role
 
-		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/roles", realmId, client.Id), &rolesClient, nil)
+		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/roles", realmId, client.Id), &rolesClient, fullRoleRepresentation)
 		if err != nil {
 			return nil, err
 		}
@@ -14,20 +14,21 @@ type RealmRoleRepresentation struct {
 	ContainerId string `json:"containerId"`
 }
 
-func roleScopeMappingUrl(realmId, clientId string, clientScopeId string, role *Role) string {
+// roleScopeMappingsUrl is the url of the roles in scope of either a client or a client scope
+func roleScopeMappingsUrl(realmId, clientId, clientScopeId string) string {
 	if clientId != "" {
-		if role.ClientRole {
-			return fmt.Sprintf("/realms/%s/clients/%s/scope-mappings/clients/%s", realmId, clientId, role.ClientId)
-		} else {
-			return fmt.Sprintf("/realms/%s/clients/%s/scope-mappings/realm", realmId, clientId)
-		}
+		return fmt.Sprintf("/realms/%s/clients/%s/scope-mappings", realmId, clientId)
 	}
 
+	return fmt.Sprintf("/realms/%s/client-scopes/%s/scope-mappings", realmId, clientScopeId)
+}
+
+func roleScopeMappingUrl(realmId, clientId string, clientScopeId string, role *Role) string {
 	if role.ClientRole {
-		return fmt.Sprintf("/realms/%s/client-scopes/%s/scope-mappings/clients/%s", realmId, clientScopeId, role.ClientId)
-	} else {
-		return fmt.Sprintf("/realms/%s/client-scopes/%s/scope-mappings/realm", realmId, clientScopeId)
+		return fmt.Sprintf("%s/clients/%s", roleScopeMappingsUrl(realmId, clientId, clientScopeId), role.ClientId)
 	}
+
+	return fmt.Sprintf("%s/realm", roleScopeMappingsUrl(realmId, clientId, clientScopeId))
 }
 
 func (keycloakClient *KeycloakClient) CreateRoleScopeMapping(ctx context.Context, realmId string, clientId string, clientScopeId string, role *Role) error {
@@ -77,3 +78,36 @@ func (keycloakClient *KeycloakClient) DeleteRoleScopeMapping(ctx context.Context
 		return keycloakClient.delete(ctx, roleUrl, body)
 	}
 }
+
+// GetRoleScopeMappings returns all the realm and client roles in scope of a client, or of a client scope when clientId
+// is empty
+func (keycloakClient *KeycloakClient) GetRoleScopeMappings(ctx context.Context, realmId, clientId, clientScopeId string) (*RoleMapping, error) {
+	var roleMapping *RoleMapping
+
+	err := keycloakClient.get(ctx, roleScopeMappingsUrl(realmId, clientId, clientScopeId), &roleMapping, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return roleMapping, nil
+}
+
+func (keycloakClient *KeycloakClient) AddRealmRoleScopeMappings(ctx context.Context, realmId, clientId, clientScopeId string, roles []*Role) error {
+	_, _, err := keycloakClient.post(ctx, roleScopeMappingsUrl(realmId, clientId, clientScopeId)+"/realm", roles)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) AddClientRoleScopeMappings(ctx context.Context, realmId, clientId, clientScopeId, roleClientId string, roles []*Role) error {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("%s/clients/%s", roleScopeMappingsUrl(realmId, clientId, clientScopeId), roleClientId), roles)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) RemoveRealmRoleScopeMappings(ctx context.Context, realmId, clientId, clientScopeId string, roles []*Role) error {
+	return keycloakClient.delete(ctx, roleScopeMappingsUrl(realmId, clientId, clientScopeId)+"/realm", roles)
+}
+
+func (keycloakClient *KeycloakClient) RemoveClientRoleScopeMappings(ctx context.Context, realmId, clientId, clientScopeId, roleClientId string, roles []*Role) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("%s/clients/%s", roleScopeMappingsUrl(realmId, clientId, clientScopeId), roleClientId), roles)
+}
@@ -149,6 +149,10 @@ func (keycloakClient *KeycloakClient) GetSamlClientDefaultScopes(ctx context.Con
 	return keycloakClient.getSamlClientScopes(ctx, realmId, clientId, "default")
 }
 
+func (keycloakClient *KeycloakClient) GetSamlClientOptionalScopes(ctx context.Context, realmId, clientId string) ([]*SamlClientScope, error) {
+	return keycloakClient.getSamlClientScopes(ctx, realmId, clientId, "optional")
+}
+
 func (keycloakClient *KeycloakClient) attachSamlClientScopes(ctx context.Context, realmId, clientId, t string, scopeNames []string) error {
 	_, err := keycloakClient.GetSamlClient(ctx, realmId, clientId)
 	if err != nil && ErrorIs404(err) {
@@ -176,6 +180,10 @@ func (keycloakClient *KeycloakClient) AttachSamlClientDefaultScopes(ctx context.
 	return keycloakClient.attachSamlClientScopes(ctx, realmId, clientId, "default", scopeNames)
 }
 
+func (keycloakClient *KeycloakClient) AttachSamlClientOptionalScopes(ctx context.Context, realmId, clientId string, scopeNames []string) error {
+	return keycloakClient.attachSamlClientScopes(ctx, realmId, clientId, "optional", scopeNames)
+}
+
 func (keycloakClient *KeycloakClient) detachSamlClientScopes(ctx context.Context, realmId, clientId, t string, scopeNames []string) error {
 	allSamlClientScopes, err := keycloakClient.ListSamlClientScopesWithFilter(ctx, realmId, includeSamlClientScopesMatchingNames(scopeNames))
 	if err != nil {
@@ -196,6 +204,10 @@ func (keycloakClient *KeycloakClient) DetachSamlClientDefaultScopes(ctx context.
 	return keycloakClient.detachSamlClientScopes(ctx, realmId, clientId, "default", scopeNames)
 }
 
+func (keycloakClient *KeycloakClient) DetachSamlClientOptionalScopes(ctx context.Context, realmId, clientId string, scopeNames []string) error {
+	return keycloakClient.detachSamlClientScopes(ctx, realmId, clientId, "optional", scopeNames)
+}
+
 func (f *SamlClientAttributes) UnmarshalJSON(data []byte) error {
 	return unmarshalExtraConfig(data, reflect.ValueOf(f).Elem(), &f.ExtraConfig)
 }
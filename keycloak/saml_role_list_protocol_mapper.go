@@ -0,0 +1,108 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type SamlRoleListProtocolMapper struct {
+	Id            string
+	Name          string
+	RealmId       string
+	ClientId      string
+	ClientScopeId string
+
+	FriendlyName            string
+	SamlAttributeName       string
+	SamlAttributeNameFormat string
+	SingleRoleAttribute     bool
+}
+
+func (mapper *SamlRoleListProtocolMapper) convertToGenericProtocolMapper() *protocolMapper {
+	return &protocolMapper{
+		Id:             mapper.Id,
+		Name:           mapper.Name,
+		Protocol:       "saml",
+		ProtocolMapper: "saml-role-list-mapper",
+		Config: map[string]string{
+			attributeNameField:        mapper.SamlAttributeName,
+			attributeNameFormatField:  mapper.SamlAttributeNameFormat,
+			friendlyNameField:         mapper.FriendlyName,
+			singleValueAttributeField: strconv.FormatBool(mapper.SingleRoleAttribute),
+		},
+	}
+}
+
+func (protocolMapper *protocolMapper) convertToSamlRoleListProtocolMapper(realmId, clientId, clientScopeId string) (*SamlRoleListProtocolMapper, error) {
+	singleRoleAttribute, err := parseBoolAndTreatEmptyStringAsFalse(protocolMapper.Config[singleValueAttributeField])
+	if err != nil {
+		return nil, err
+	}
+
+	return &SamlRoleListProtocolMapper{
+		Id:            protocolMapper.Id,
+		Name:          protocolMapper.Name,
+		RealmId:       realmId,
+		ClientId:      clientId,
+		ClientScopeId: clientScopeId,
+
+		FriendlyName:            protocolMapper.Config[friendlyNameField],
+		SamlAttributeName:       protocolMapper.Config[attributeNameField],
+		SamlAttributeNameFormat: protocolMapper.Config[attributeNameFormatField],
+		SingleRoleAttribute:     singleRoleAttribute,
+	}, nil
+}
+
+func (keycloakClient *KeycloakClient) GetSamlRoleListProtocolMapper(ctx context.Context, realmId, clientId, clientScopeId, mapperId string) (*SamlRoleListProtocolMapper, error) {
+	var protocolMapper *protocolMapper
+
+	err := keycloakClient.get(ctx, individualProtocolMapperPath(realmId, clientId, clientScopeId, mapperId), &protocolMapper, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return protocolMapper.convertToSamlRoleListProtocolMapper(realmId, clientId, clientScopeId)
+}
+
+func (keycloakClient *KeycloakClient) DeleteSamlRoleListProtocolMapper(ctx context.Context, realmId, clientId, clientScopeId, mapperId string) error {
+	return keycloakClient.delete(ctx, individualProtocolMapperPath(realmId, clientId, clientScopeId, mapperId), nil)
+}
+
+func (keycloakClient *KeycloakClient) NewSamlRoleListProtocolMapper(ctx context.Context, mapper *SamlRoleListProtocolMapper) error {
+	path := protocolMapperPath(mapper.RealmId, mapper.ClientId, mapper.ClientScopeId)
+
+	_, location, err := keycloakClient.post(ctx, path, mapper.convertToGenericProtocolMapper())
+	if err != nil {
+		return err
+	}
+
+	mapper.Id = getIdFromLocationHeader(location)
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateSamlRoleListProtocolMapper(ctx context.Context, mapper *SamlRoleListProtocolMapper) error {
+	path := individualProtocolMapperPath(mapper.RealmId, mapper.ClientId, mapper.ClientScopeId, mapper.Id)
+
+	return keycloakClient.put(ctx, path, mapper.convertToGenericProtocolMapper())
+}
+
+func (keycloakClient *KeycloakClient) ValidateSamlRoleListProtocolMapper(ctx context.Context, mapper *SamlRoleListProtocolMapper) error {
+	if mapper.ClientId == "" && mapper.ClientScopeId == "" {
+		return fmt.Errorf("validation error: one of ClientId or ClientScopeId must be set")
+	}
+
+	protocolMappers, err := keycloakClient.listGenericProtocolMappers(ctx, mapper.RealmId, mapper.ClientId, mapper.ClientScopeId)
+	if err != nil {
+		return err
+	}
+
+	for _, protocolMapper := range protocolMappers {
+		if protocolMapper.Name == mapper.Name && protocolMapper.Id != mapper.Id {
+			return fmt.Errorf("validation error: a protocol mapper with name %s already exists for this client", mapper.Name)
+		}
+	}
+
+	return nil
+}
@@ -7,7 +7,14 @@ type SystemInfo struct {
 }
 
 type ComponentType struct {
-	Id string `json:"id"`
+	Id         string                  `json:"id"`
+	Properties []ComponentTypeProperty `json:"properties"`
+}
+
+type ComponentTypeProperty struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
 }
 
 type ProviderType struct {
@@ -54,6 +61,16 @@ func (serverInfo *ServerInfo) ComponentTypeIsInstalled(componentType, componentT
 	return false
 }
 
+func (serverInfo *ServerInfo) GetComponentType(componentType, componentTypeId string) (*ComponentType, bool) {
+	for _, c := range serverInfo.ComponentTypes[componentType] {
+		if c.Id == componentTypeId {
+			return &c, true
+		}
+	}
+
+	return nil, false
+}
+
 func (serverInfo *ServerInfo) getInstalledProvidersNames(providerType string) []string {
 	providers := serverInfo.ProviderTypes[providerType].Providers
 	keys := make([]string, 0, len(providers))
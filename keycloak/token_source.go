@@ -0,0 +1,123 @@
+package keycloak
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Tokens expiring within this window are refreshed before being used, so requests don't have to fail with a 401 first.
+const tokenExpiryLeeway = time.Second * 10
+
+type token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+
+	expiry time.Time
+}
+
+// setExpiry determines when the token expires, preferring the `exp` claim of the access token and falling back to the
+// `expires_in` attribute of the token response. Opaque tokens without either never expire proactively.
+func (t *token) setExpiry(issuedAt time.Time) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(t.AccessToken, claims); err == nil {
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			t.expiry = exp.Time
+			return
+		}
+	}
+
+	if t.ExpiresIn > 0 {
+		t.expiry = issuedAt.Add(time.Second * time.Duration(t.ExpiresIn))
+	}
+}
+
+func (t *token) expiresWithin(d time.Duration) bool {
+	if t.expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(d).After(t.expiry)
+}
+
+// tokenSource owns the token used to authenticate against the admin API. Terraform runs many operations in parallel
+// against a single KeycloakClient, so every read and refresh of the token goes through the mutex. The mutex is held
+// while a new token is fetched, which means concurrent callers wait for the one refresh in flight instead of each
+// requesting their own token.
+type tokenSource struct {
+	mutex sync.Mutex
+	token *token
+	// static is true when the provider was given an access token, which can never be refreshed
+	static bool
+	fetch  func(ctx context.Context) (*token, error)
+}
+
+func newStaticTokenSource(accessToken string) *tokenSource {
+	t := &token{
+		AccessToken: accessToken,
+		TokenType:   "bearer",
+	}
+
+	return &tokenSource{
+		token:  t,
+		static: true,
+	}
+}
+
+func newTokenSource(fetch func(ctx context.Context) (*token, error)) *tokenSource {
+	return &tokenSource{
+		fetch: fetch,
+	}
+}
+
+// Token returns the current token, fetching a new one if there is none yet or if it is about to expire.
+func (ts *tokenSource) Token(ctx context.Context) (*token, error) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	if ts.static || (ts.token != nil && !ts.token.expiresWithin(tokenExpiryLeeway)) {
+		return ts.token, nil
+	}
+
+	return ts.refreshLocked(ctx)
+}
+
+// Invalidate is called after Keycloak rejected the given token. A new token is only fetched if no other caller has
+// already replaced the rejected one in the meantime.
+func (ts *tokenSource) Invalidate(ctx context.Context, rejected *token) (*token, error) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	if ts.static || (ts.token != nil && ts.token != rejected) {
+		return ts.token, nil
+	}
+
+	return ts.refreshLocked(ctx)
+}
+
+// Refresh unconditionally fetches a new token.
+func (ts *tokenSource) Refresh(ctx context.Context) (*token, error) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	if ts.static {
+		return ts.token, nil
+	}
+
+	return ts.refreshLocked(ctx)
+}
+
+func (ts *tokenSource) refreshLocked(ctx context.Context) (*token, error) {
+	t, err := ts.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.token = t
+
+	return t, nil
+}
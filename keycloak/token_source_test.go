@@ -0,0 +1,241 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenServer is a minimal Keycloak that issues JWTs from its token endpoint and only accepts the most recently
+// issued token on the admin API
+type tokenServer struct {
+	*httptest.Server
+
+	mutex        sync.Mutex
+	validToken   string
+	tokenCalls   int32
+	unauthorized int32
+	// lifetimes of the issued tokens, in order. The last one is reused once exhausted.
+	lifetimes []time.Duration
+}
+
+func newTokenServer(t *testing.T, lifetimes ...time.Duration) *tokenServer {
+	ts := &tokenServer{
+		lifetimes: lifetimes,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&ts.tokenCalls, 1)
+
+		lifetime := ts.lifetimes[len(ts.lifetimes)-1]
+		if int(n) <= len(ts.lifetimes) {
+			lifetime = ts.lifetimes[n-1]
+		}
+
+		accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"jti": fmt.Sprintf("token-%d", n),
+			"exp": jwt.NewNumericDate(time.Now().Add(lifetime)),
+		}).SignedString([]byte("secret"))
+		if err != nil {
+			t.Errorf("%s", err)
+		}
+
+		// simulate a slow token endpoint so concurrent callers pile up behind the refresh in flight
+		time.Sleep(time.Millisecond * 50)
+
+		ts.mutex.Lock()
+		ts.validToken = accessToken
+		ts.mutex.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": accessToken,
+			"token_type":   "Bearer",
+			"expires_in":   int(lifetime.Seconds()),
+		})
+	})
+	mux.HandleFunc("/admin/realms/foo", func(w http.ResponseWriter, r *http.Request) {
+		ts.mutex.Lock()
+		valid := r.Header.Get("Authorization") == "Bearer "+ts.validToken
+		ts.mutex.Unlock()
+
+		if !valid {
+			atomic.AddInt32(&ts.unauthorized, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":    "foo",
+			"realm": "foo",
+		})
+	})
+
+	ts.Server = httptest.NewServer(mux)
+	t.Cleanup(ts.Server.Close)
+
+	return ts
+}
+
+// revoke invalidates all tokens issued so far
+func (ts *tokenServer) revoke() {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	ts.validToken = ""
+}
+
+func newTokenServerClient(t *testing.T, server *tokenServer) *KeycloakClient {
+	keycloakClient, err := NewKeycloakClient(context.Background(), server.URL, "", "", "terraform", "secret", "master", "", "", "", "", "", false, 5, "", false, "", "", "", false, nil, "", testRetryConfig())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return keycloakClient
+}
+
+func TestTokenSourceRefreshesOnceForConcurrentUnauthorizedRequests(t *testing.T) {
+	server := newTokenServer(t, time.Hour)
+	keycloakClient := newTokenServerClient(t, server)
+	ctx := context.Background()
+
+	_, err := keycloakClient.GetRealm(ctx, "foo")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	server.revoke()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := keycloakClient.GetRealm(ctx, "foo")
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("%s", err)
+	}
+
+	if tokenCalls := atomic.LoadInt32(&server.tokenCalls); tokenCalls != 2 {
+		t.Fatalf("expected the token endpoint to be called twice (login and a single refresh), got %d", tokenCalls)
+	}
+}
+
+func TestTokenSourceRefreshesExpiringTokenProactively(t *testing.T) {
+	// the first token expires within tokenExpiryLeeway, so it must be replaced before the second request
+	server := newTokenServer(t, time.Second*5, time.Hour)
+	keycloakClient := newTokenServerClient(t, server)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := keycloakClient.GetRealm(ctx, "foo")
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	if tokenCalls := atomic.LoadInt32(&server.tokenCalls); tokenCalls != 2 {
+		t.Fatalf("expected the token endpoint to be called twice, got %d", tokenCalls)
+	}
+
+	if unauthorized := atomic.LoadInt32(&server.unauthorized); unauthorized != 0 {
+		t.Fatalf("expected no request to be rejected, got %d unauthorized responses", unauthorized)
+	}
+}
+
+func TestTokenSourceConcurrentProactiveRefresh(t *testing.T) {
+	server := newTokenServer(t, time.Second*5, time.Hour)
+	keycloakClient := newTokenServerClient(t, server)
+	ctx := context.Background()
+
+	_, err := keycloakClient.GetRealm(ctx, "foo")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := keycloakClient.GetRealm(ctx, "foo")
+			if err != nil {
+				t.Errorf("%s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tokenCalls := atomic.LoadInt32(&server.tokenCalls); tokenCalls != 2 {
+		t.Fatalf("expected the token endpoint to be called twice, got %d", tokenCalls)
+	}
+}
+
+func TestTokenSourceStaticTokenIsNeverRefreshed(t *testing.T) {
+	ts := newStaticTokenSource("opaque")
+	ctx := context.Background()
+
+	first, err := ts.Token(ctx)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	second, err := ts.Invalidate(ctx, first)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if first != second || second.AccessToken != "opaque" {
+		t.Fatalf("expected static token to be kept after invalidation")
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	issuedAt := time.Now()
+	exp := issuedAt.Add(time.Minute).Truncate(time.Second)
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": jwt.NewNumericDate(exp),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	jwtToken := &token{AccessToken: accessToken, ExpiresIn: 3600}
+	jwtToken.setExpiry(issuedAt)
+	if !jwtToken.expiry.Equal(exp) {
+		t.Fatalf("expected expiry to be taken from the exp claim (%s), got %s", exp, jwtToken.expiry)
+	}
+
+	opaqueToken := &token{AccessToken: "opaque", ExpiresIn: 60}
+	opaqueToken.setExpiry(issuedAt)
+	if !opaqueToken.expiry.Equal(issuedAt.Add(time.Minute)) {
+		t.Fatalf("expected expiry to be taken from expires_in, got %s", opaqueToken.expiry)
+	}
+
+	unknownToken := &token{AccessToken: "opaque"}
+	unknownToken.setExpiry(issuedAt)
+	if unknownToken.expiresWithin(time.Hour * 24 * 365) {
+		t.Fatalf("expected token without expiry information to never expire")
+	}
+}
@@ -3,6 +3,9 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type FederatedIdentity struct {
@@ -78,12 +81,95 @@ func (keycloakClient *KeycloakClient) ResetUserPassword(ctx context.Context, rea
 	return nil
 }
 
+// UserSearch holds the query parameters of the users endpoint, empty values are not sent
+type UserSearch struct {
+	Search         string
+	Username       string
+	Email          string
+	IdpAlias       string
+	Enabled        *bool
+	Exact          bool
+	AttributeQuery map[string]string
+}
+
+func (search *UserSearch) params() map[string]string {
+	params := map[string]string{
+		"briefRepresentation": "false",
+	}
+
+	if search.Search != "" {
+		params["search"] = search.Search
+	}
+	if search.Username != "" {
+		params["username"] = escapeBackslashes(search.Username)
+	}
+	if search.Email != "" {
+		params["email"] = search.Email
+	}
+	if search.IdpAlias != "" {
+		params["idpAlias"] = search.IdpAlias
+	}
+	if search.Enabled != nil {
+		params["enabled"] = strconv.FormatBool(*search.Enabled)
+	}
+	if search.Exact {
+		params["exact"] = "true"
+	}
+	if len(search.AttributeQuery) != 0 {
+		// the attributes are sorted so that the same search always results in the same request
+		var query []string
+		for key, value := range search.AttributeQuery {
+			query = append(query, fmt.Sprintf("%s:%s", quoteSearchQueryTerm(key), quoteSearchQueryTerm(value)))
+		}
+		sort.Strings(query)
+		params["q"] = strings.Join(query, " ")
+	}
+
+	return params
+}
+
+var searchQueryTermEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quoteSearchQueryTerm quotes a key or value of the q parameter, so that terms containing spaces or colons
+// are not split by Keycloak
+func quoteSearchQueryTerm(term string) string {
+	return `"` + searchQueryTermEscaper.Replace(term) + `"`
+}
+
+// GetUsers returns every user of the realm
 func (keycloakClient *KeycloakClient) GetUsers(ctx context.Context, realmId string) ([]*User, error) {
+	return keycloakClient.SearchUsers(ctx, realmId, &UserSearch{}, 0)
+}
+
+// SearchUsers returns the users matching the search, going through all pages of results.
+// When maxResults is greater than zero, no more than maxResults users are returned.
+func (keycloakClient *KeycloakClient) SearchUsers(ctx context.Context, realmId string, search *UserSearch, maxResults int) ([]*User, error) {
 	var users []*User
+	var first, pagination = 0, 100
 
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/users", realmId), &users, nil)
-	if err != nil {
-		return nil, err
+	params := search.params()
+
+	for {
+		max := pagination
+		if maxResults > 0 && maxResults-len(users) < max {
+			max = maxResults - len(users)
+		}
+
+		params["first"] = strconv.Itoa(first)
+		params["max"] = strconv.Itoa(max)
+
+		var page []*User
+		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/users", realmId), &page, params)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page...)
+		first += len(page)
+
+		if len(page) < max || (maxResults > 0 && len(users) >= maxResults) {
+			break
+		}
 	}
 
 	for _, user := range users {
@@ -0,0 +1,207 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type UserCredential struct {
+	Id             string `json:"id,omitempty"`
+	RealmId        string `json:"-"`
+	UserId         string `json:"-"`
+	Type           string `json:"type"`
+	UserLabel      string `json:"userLabel,omitempty"`
+	CreatedDate    int64  `json:"createdDate,omitempty"`
+	CredentialData string `json:"credentialData,omitempty"`
+	SecretData     string `json:"secretData,omitempty"`
+}
+
+// OtpCredentialData is the credentialData of an OTP credential, as stored by Keycloak
+type OtpCredentialData struct {
+	SubType   string `json:"subType"`
+	Digits    int    `json:"digits"`
+	Counter   int    `json:"counter"`
+	Period    int    `json:"period"`
+	Algorithm string `json:"algorithm"`
+}
+
+type otpSecretData struct {
+	Value string `json:"value"`
+}
+
+// GetUserCredentials returns the credentials of a user, ordered by priority
+func (keycloakClient *KeycloakClient) GetUserCredentials(ctx context.Context, realmId, userId string) ([]*UserCredential, error) {
+	var credentials []*UserCredential
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/users/%s/credentials", realmId, userId), &credentials, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, credential := range credentials {
+		credential.RealmId = realmId
+		credential.UserId = userId
+	}
+
+	return credentials, nil
+}
+
+func (keycloakClient *KeycloakClient) GetUserCredential(ctx context.Context, realmId, userId, credentialId string) (*UserCredential, error) {
+	credentials, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, credential := range credentials {
+		if credential.Id == credentialId {
+			return credential, nil
+		}
+	}
+
+	return nil, &ApiError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("credential %s of user %s not found", credentialId, userId),
+	}
+}
+
+func (keycloakClient *KeycloakClient) DeleteUserCredential(ctx context.Context, realmId, userId, credentialId string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/users/%s/credentials/%s", realmId, userId, credentialId), nil)
+}
+
+// DeleteUserCredentialsByType removes every credential of the given type, which forces the user to enroll again
+// if the matching required action is set. The ids of the removed credentials are returned.
+func (keycloakClient *KeycloakClient) DeleteUserCredentialsByType(ctx context.Context, realmId, userId, credentialType string) ([]string, error) {
+	credentials, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, credential := range credentials {
+		if credential.Type != credentialType {
+			continue
+		}
+
+		err = keycloakClient.DeleteUserCredential(ctx, realmId, userId, credential.Id)
+		if err != nil && !ErrorIs404(err) {
+			return removed, err
+		}
+
+		removed = append(removed, credential.Id)
+	}
+
+	return removed, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateUserCredentialLabel(ctx context.Context, realmId, userId, credentialId, label string) error {
+	resourceUrl := keycloakClient.baseUrl + apiUrl + fmt.Sprintf("/realms/%s/users/%s/credentials/%s/userLabel", realmId, userId, credentialId)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, resourceUrl, strings.NewReader(label))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-type", "text/plain")
+
+	_, _, err = keycloakClient.sendRequest(ctx, request, []byte(label))
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) MoveUserCredentialToFirst(ctx context.Context, realmId, userId, credentialId string) error {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/users/%s/credentials/%s/moveToFirst", realmId, userId, credentialId), nil)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) MoveUserCredentialAfter(ctx context.Context, realmId, userId, credentialId, previousCredentialId string) error {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/users/%s/credentials/%s/moveAfter/%s", realmId, userId, credentialId, previousCredentialId), nil)
+
+	return err
+}
+
+// NewUserOtpCredential stores a TOTP credential with a known secret for the user, so that automated clients can generate
+// valid codes. The admin API has no dedicated endpoint for this, the credential is added through a user update instead.
+func (keycloakClient *KeycloakClient) NewUserOtpCredential(ctx context.Context, realmId, userId, label, secret string, otpData OtpCredentialData) (*UserCredential, error) {
+	credentialData, err := json.Marshal(otpData)
+	if err != nil {
+		return nil, err
+	}
+
+	secretData, err := json.Marshal(otpSecretData{Value: secret})
+	if err != nil {
+		return nil, err
+	}
+
+	return keycloakClient.newUserCredential(ctx, realmId, userId, &UserCredential{
+		Type:           "otp",
+		UserLabel:      label,
+		CredentialData: string(credentialData),
+		SecretData:     string(secretData),
+	})
+}
+
+func (keycloakClient *KeycloakClient) newUserCredential(ctx context.Context, realmId, userId string, credential *UserCredential) (*UserCredential, error) {
+	existing, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the user is sent back as is, so that attributes unknown to this provider are left untouched
+	var user map[string]interface{}
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/users/%s", realmId, userId), &user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user["credentials"] = []*UserCredential{credential}
+
+	err = keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/users/%s", realmId, userId), user)
+	if err != nil {
+		return nil, err
+	}
+
+	return keycloakClient.findNewUserCredential(ctx, realmId, userId, credential.Type, existing)
+}
+
+// SetUserPasswordCredential sets the password of the user and returns the resulting password credential
+func (keycloakClient *KeycloakClient) SetUserPasswordCredential(ctx context.Context, realmId, userId, password string, temporary bool) (*UserCredential, error) {
+	err := keycloakClient.ResetUserPassword(ctx, realmId, userId, password, temporary)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, credential := range credentials {
+		if credential.Type == "password" {
+			return credential, nil
+		}
+	}
+
+	return nil, fmt.Errorf("password credential of user %s not found after setting it", userId)
+}
+
+func (keycloakClient *KeycloakClient) findNewUserCredential(ctx context.Context, realmId, userId, credentialType string, existing []*UserCredential) (*UserCredential, error) {
+	credentials, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	existingIds := make(map[string]bool, len(existing))
+	for _, credential := range existing {
+		existingIds[credential.Id] = true
+	}
+
+	for _, credential := range credentials {
+		if credential.Type == credentialType && !existingIds[credential.Id] {
+			return credential, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s credential of user %s not found after creating it", credentialType, userId)
+}
@@ -0,0 +1,59 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	UserStorageSyncActionFull         = "triggerFullSync"
+	UserStorageSyncActionChangedUsers = "triggerChangedUsersSync"
+
+	UserStorageMapperSyncFedToKeycloak = "fedToKeycloak"
+	UserStorageMapperSyncKeycloakToFed = "keycloakToFed"
+)
+
+// UserStorageSyncResult is the outcome of synchronizing a user storage provider or one of its mappers
+type UserStorageSyncResult struct {
+	Ignored bool   `json:"ignored"`
+	Added   int    `json:"added"`
+	Updated int    `json:"updated"`
+	Removed int    `json:"removed"`
+	Failed  int    `json:"failed"`
+	Status  string `json:"status"`
+}
+
+// SyncUserStorage synchronizes the users of a user storage provider, action is one of UserStorageSyncActionFull or
+// UserStorageSyncActionChangedUsers. Users that failed to synchronize are counted in the result, they don't cause an error.
+func (keycloakClient *KeycloakClient) SyncUserStorage(ctx context.Context, realmId, id, action string) (*UserStorageSyncResult, error) {
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/user-storage/%s/sync?action=%s", realmId, id, action), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result UserStorageSyncResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SyncUserStorageMapper synchronizes the data of a mapper of a user storage provider, like the groups of an LDAP group
+// mapper, in the given direction
+func (keycloakClient *KeycloakClient) SyncUserStorageMapper(ctx context.Context, realmId, id, mapperId, direction string) (*UserStorageSyncResult, error) {
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/user-storage/%s/mappers/%s/sync?direction=%s", realmId, id, mapperId, direction), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result UserStorageSyncResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,42 @@
+package keycloak
+
+import "testing"
+
+func TestUserSearchAttributeQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		attributeQuery map[string]string
+		expected       string
+	}{
+		{
+			name:           "single term",
+			attributeQuery: map[string]string{"team": "blue"},
+			expected:       `"team":"blue"`,
+		},
+		{
+			name:           "value with a space",
+			attributeQuery: map[string]string{"team": "blue team"},
+			expected:       `"team":"blue team"`,
+		},
+		{
+			name:           "quotes and backslashes",
+			attributeQuery: map[string]string{`say "hi"`: `C:\users`},
+			expected:       `"say \"hi\"":"C:\\users"`,
+		},
+		{
+			name:           "sorted terms",
+			attributeQuery: map[string]string{"team": "blue", "office": "new york"},
+			expected:       `"office":"new york" "team":"blue"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			search := &UserSearch{AttributeQuery: test.attributeQuery}
+
+			if actual := search.params()["q"]; actual != test.expected {
+				t.Errorf("expected q to be %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
@@ -44,14 +44,18 @@ func (v Version) AsVersion() *version.Version {
 	return vv
 }
 
-func (KeycloakClient *KeycloakClient) Version(ctx context.Context) (*version.Version, error) {
-	if KeycloakClient.version == nil {
-		err := KeycloakClient.login(ctx)
+func (keycloakClient *KeycloakClient) Version(ctx context.Context) (*version.Version, error) {
+	keycloakClient.versionMutex.Lock()
+	defer keycloakClient.versionMutex.Unlock()
+
+	if keycloakClient.version == nil {
+		v, err := keycloakClient.fetchVersion(ctx)
 		if err != nil {
 			return nil, err
 		}
+		keycloakClient.version = v
 	}
-	return KeycloakClient.version, nil
+	return keycloakClient.version, nil
 }
 
 func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/keycloak/terraform-provider-keycloak/provider"
 )
 
@@ -13,13 +16,22 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.KeycloakProvider(nil)
-		},
-		Debug: debugMode,
-		// using local provider address for debugging:
-		ProviderAddr: "terraform.local/keycloak/keycloak",
+	// the SDK provider is muxed with a framework provider serving the ephemeral resources, which the SDK doesn't support
+	providerServer, err := provider.NewProviderServer(context.Background(), provider.KeycloakProvider(nil))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	// using local provider address for debugging:
+	err = tf5server.Serve("terraform.local/keycloak/keycloak", func() tfprotov5.ProviderServer {
+		return providerServer
+	}, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
 	}
-	plugin.Serve(opts)
 }
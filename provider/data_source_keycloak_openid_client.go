@@ -46,6 +46,30 @@ func dataSourceKeycloakOpenidClient() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"use_jwks_url": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"jwks_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"jwks": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"x509_subject_dn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"x509_allow_regex_pattern_comparison": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"standard_flow_enabled": {
 				Type:     schema.TypeBool,
 				Computed: true,
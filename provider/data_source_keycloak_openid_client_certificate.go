@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakOpenidClientCertificate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakOpenidClientCertificateRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     keycloak.OpenidClientJwtCredentialAttribute,
+				Description: "The attribute prefix the certificate is stored under in the client.",
+			},
+			"certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"certificate_pem": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakOpenidClientCertificateRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	clientId := data.Get("client_id").(string)
+	attribute := data.Get("attribute").(string)
+
+	certificate, err := keycloakClient.GetOpenidClientCertificate(ctx, realmId, clientId, attribute)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// keycloak returns the certificate base64 encoded without the PEM armor, which most tools expect
+	var certificatePem string
+	if certificate.Certificate != "" {
+		der, err := base64.StdEncoding.DecodeString(certificate.Certificate)
+		if err != nil {
+			return diag.Errorf("error decoding the certificate of client %s: %s", clientId, err)
+		}
+		certificatePem = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s/%s", realmId, clientId, attribute))
+	data.Set("certificate", certificate.Certificate)
+	data.Set("certificate_pem", certificatePem)
+	data.Set("public_key", certificate.PublicKey)
+	data.Set("kid", certificate.Kid)
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceOpenidClientCertificate_basic(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc-test")
+	_, certificate := generateKeyAndCert(2048)
+	dataSourceName := "data.keycloak_openid_client_certificate.certificate"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeycloakOpenidClientCertificateConfig(clientId, certificate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "client_id", "keycloak_openid_client.client", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "attribute", "jwt.credential"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificate", certificate),
+					resource.TestCheckResourceAttr(dataSourceName, "certificate_pem", testCertificatePemLines(certificate)),
+				),
+			},
+		},
+	})
+}
+
+// testCertificatePemLines is like testCertificatePem, with the lines of 64 characters of encoding/pem
+func testCertificatePemLines(certificate string) string {
+	pem := "-----BEGIN CERTIFICATE-----\n"
+	for len(certificate) > 64 {
+		pem += certificate[:64] + "\n"
+		certificate = certificate[64:]
+	}
+
+	return pem + certificate + "\n-----END CERTIFICATE-----\n"
+}
+
+func testAccKeycloakOpenidClientCertificateConfig(clientId, certificate string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                  = data.keycloak_realm.realm.id
+	client_id                 = "%s"
+	access_type               = "CONFIDENTIAL"
+	client_authenticator_type = "client-jwt"
+	client_certificate        = <<EOT
+%sEOT
+}
+
+data "keycloak_openid_client_certificate" "certificate" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client.id
+}
+`, testAccRealm.Realm, clientId, testCertificatePem(certificate))
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var keycloakAdminEventOperationTypes = []string{"CREATE", "UPDATE", "DELETE", "ACTION"}
+
+func dataSourceKeycloakRealmAdminEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmAdminEventsRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"operation_types": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(keycloakAdminEventOperationTypes, false),
+				},
+				Optional:    true,
+				Description: "Only return the admin events of these operations: CREATE, UPDATE, DELETE or ACTION.",
+			},
+			"resource_types": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return the admin events of these resource types, like CLIENT or USER.",
+			},
+			"resource_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the admin events of the resources matching this path, `*` matches any part of the path, like clients/*.",
+			},
+			"auth_realm_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the admin events of the administrators authenticated in the realm with this id.",
+			},
+			"auth_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the admin events made through the client with this id.",
+			},
+			"auth_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the admin events made by the user with this id.",
+			},
+			"auth_ip_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the admin events made from this IP address.",
+			},
+			"date_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEventDate,
+				Description:  "Only return the admin events since this date (yyyy-MM-dd) or RFC 3339 timestamp.",
+			},
+			"date_to": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEventDate,
+				Description:  "Only return the admin events until this date (yyyy-MM-dd), which is included, or RFC 3339 timestamp.",
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of admin events to return, 0 returns every matching admin event.",
+			},
+			"admin_events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operation_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"representation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_realm_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmAdminEventsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	maxResults := data.Get("max_results").(int)
+
+	// the types are sorted so that the same search always results in the same id
+	operationTypes := interfaceSliceToStringSlice(data.Get("operation_types").(*schema.Set).List())
+	sort.Strings(operationTypes)
+	resourceTypes := interfaceSliceToStringSlice(data.Get("resource_types").(*schema.Set).List())
+	sort.Strings(resourceTypes)
+
+	search := &keycloak.AdminEventSearch{
+		OperationTypes: operationTypes,
+		ResourceTypes:  resourceTypes,
+		ResourcePath:   data.Get("resource_path").(string),
+		AuthRealmId:    data.Get("auth_realm_id").(string),
+		AuthClientId:   data.Get("auth_client_id").(string),
+		AuthUserId:     data.Get("auth_user_id").(string),
+		AuthIpAddress:  data.Get("auth_ip_address").(string),
+	}
+
+	var diags diag.Diagnostics
+	if search.DateFrom, search.DateTo, diags = eventSearchDates(ctx, keycloakClient, data); diags.HasError() {
+		return diags
+	}
+
+	adminEvents, err := keycloakClient.SearchRealmAdminEvents(ctx, realmId, search, maxResults)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := make([]interface{}, 0, len(adminEvents))
+	for _, adminEvent := range adminEvents {
+		result = append(result, map[string]interface{}{
+			"time":            int(adminEvent.Time),
+			"date":            eventDate(adminEvent.Time),
+			"operation_type":  adminEvent.OperationType,
+			"resource_type":   adminEvent.ResourceType,
+			"resource_path":   adminEvent.ResourcePath,
+			"representation":  adminEvent.Representation,
+			"error":           adminEvent.Error,
+			"auth_realm_id":   adminEvent.AuthDetails.RealmId,
+			"auth_client_id":  adminEvent.AuthDetails.ClientId,
+			"auth_user_id":    adminEvent.AuthDetails.UserId,
+			"auth_ip_address": adminEvent.AuthDetails.IpAddress,
+			"details":         adminEvent.Details,
+		})
+	}
+
+	data.SetId(searchId(realmId, strings.Join(search.OperationTypes, ","), strings.Join(search.ResourceTypes, ","), search.ResourcePath,
+		search.AuthRealmId, search.AuthClientId, search.AuthUserId, search.AuthIpAddress, search.DateFrom, search.DateTo, strconv.Itoa(maxResults)))
+	data.Set("admin_events", result)
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestAccKeycloakDataSourceRealmAdminEvents(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	roleName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmAdminEvents(realmName, roleName, time.Now().Add(-24*time.Hour).UTC().Format(time.RFC3339)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_realm_admin_events.roles", "admin_events.#", "1"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_admin_events.roles", "admin_events.0.operation_type", "CREATE"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_admin_events.roles", "admin_events.0.resource_type", "REALM_ROLE"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_admin_events.roles", "admin_events.0.resource_path", "roles/"+roleName),
+					resource.TestCheckResourceAttr("data.keycloak_realm_admin_events.client_deletions", "admin_events.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakDataSourceRealmAdminEvents(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	userId := acctest.RandomWithPrefix("tf-unit")
+	now := time.Now()
+
+	testAccServer.AddAdminEvents(testAccRealm.Realm,
+		keycloak.AdminEvent{
+			Time:          now.Add(-48 * time.Hour).UnixMilli(),
+			AuthDetails:   keycloak.AdminEventAuthDetails{UserId: userId},
+			OperationType: "DELETE",
+			ResourceType:  "CLIENT",
+			ResourcePath:  "clients/old",
+		},
+		keycloak.AdminEvent{
+			Time:           now.Add(-time.Hour).UnixMilli(),
+			AuthDetails:    keycloak.AdminEventAuthDetails{UserId: userId, IpAddress: "10.0.0.1"},
+			OperationType:  "DELETE",
+			ResourceType:   "CLIENT",
+			ResourcePath:   "clients/recent",
+			Representation: `{"clientId":"recent"}`,
+		},
+		keycloak.AdminEvent{
+			Time:          now.Add(-time.Hour).UnixMilli(),
+			AuthDetails:   keycloak.AdminEventAuthDetails{UserId: userId},
+			OperationType: "CREATE",
+			ResourceType:  "CLIENT",
+			ResourcePath:  "clients/new",
+		},
+		keycloak.AdminEvent{
+			Time:          now.Add(-time.Minute).UnixMilli(),
+			AuthDetails:   keycloak.AdminEventAuthDetails{UserId: userId},
+			OperationType: "DELETE",
+			ResourceType:  "USER",
+			ResourcePath:  "users/someone",
+		},
+	)
+
+	adminEventsDataSource := testAccProvider.DataSourcesMap["keycloak_realm_admin_events"]
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		raw["realm_id"] = testAccRealm.Realm
+		raw["auth_user_id"] = userId
+		data := schema.TestResourceDataRaw(t, adminEventsDataSource.Schema, raw)
+		if diags := adminEventsDataSource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+
+	all := read(map[string]interface{}{})
+	if count := len(all.Get("admin_events").([]interface{})); count != 4 {
+		t.Errorf("expected 4 admin events, got %d", count)
+	}
+
+	deletions := read(map[string]interface{}{
+		"operation_types": []interface{}{"DELETE"},
+		"resource_types":  []interface{}{"CLIENT"},
+		"date_from":       now.Add(-24 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	if count := len(deletions.Get("admin_events").([]interface{})); count != 1 {
+		t.Fatalf("expected 1 client deletion in the last 24 hours, got %d", count)
+	}
+	if deletions.Get("admin_events.0.resource_path") != "clients/recent" ||
+		deletions.Get("admin_events.0.representation") != `{"clientId":"recent"}` ||
+		deletions.Get("admin_events.0.auth_ip_address") != "10.0.0.1" {
+		t.Errorf("unexpected admin event %v", deletions.Get("admin_events.0"))
+	}
+
+	clients := read(map[string]interface{}{
+		"resource_path": "clients/*",
+	})
+	if count := len(clients.Get("admin_events").([]interface{})); count != 3 {
+		t.Errorf("expected 3 admin events of clients, got %d", count)
+	}
+	if clients.Id() == all.Id() {
+		t.Error("expected different searches to have different ids")
+	}
+}
+
+// TestUnitKeycloakDataSourceRealmAdminEvents_timestampVersion checks that timestamps are rejected before Keycloak 23
+func TestUnitKeycloakDataSourceRealmAdminEvents_timestampVersion(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.Version = "22.0.5"
+
+	client, err := server.NewClient(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adminEventsDataSource := testAccProvider.DataSourcesMap["keycloak_realm_admin_events"]
+	read := func(dateFrom string) diag.Diagnostics {
+		data := schema.TestResourceDataRaw(t, adminEventsDataSource.Schema, map[string]interface{}{
+			"realm_id":  "master",
+			"date_from": dateFrom,
+		})
+		return adminEventsDataSource.ReadContext(testCtx, data, client)
+	}
+
+	if diags := read("2024-03-01T12:00:00Z"); !diags.HasError() || !strings.Contains(diags[0].Summary, "require Keycloak v23 or higher") {
+		t.Errorf("expected timestamps to be rejected by Keycloak 22, got %v", diags)
+	}
+	if diags := read("2024-03-01"); diags.HasError() {
+		t.Errorf("expected dates to be accepted by Keycloak 22, got %v", diags)
+	}
+}
+
+func testDataSourceKeycloakRealmAdminEvents(realm, role, dateFrom string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_events" "realm_events" {
+	realm_id             = keycloak_realm.realm.id
+	admin_events_enabled = true
+}
+
+resource "keycloak_role" "role" {
+	realm_id = keycloak_realm_events.realm_events.realm_id
+	name     = "%s"
+}
+
+data "keycloak_realm_admin_events" "roles" {
+	realm_id        = keycloak_realm.realm.id
+	operation_types = ["CREATE"]
+	resource_types  = ["REALM_ROLE"]
+
+	depends_on = [keycloak_role.role]
+}
+
+data "keycloak_realm_admin_events" "client_deletions" {
+	realm_id        = keycloak_realm.realm.id
+	operation_types = ["DELETE"]
+	resource_types  = ["CLIENT"]
+	date_from       = "%s"
+
+	depends_on = [keycloak_role.role]
+}
+	`, realm, role, dateFrom)
+}
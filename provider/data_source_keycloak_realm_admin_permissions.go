@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRealmAdminPermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmAdminPermissionsRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource server id representing the admin-permissions client on which permissions and policies are managed",
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmAdminPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	if diags := checkAdminPermissionsV2Supported(ctx, keycloakClient); diags.HasError() {
+		return diags
+	}
+
+	realmId := data.Get("realm_id").(string)
+
+	// admin permissions are enabled with the admin_permissions_enabled attribute of keycloak_realm, this only reads the
+	// client keycloak creates for them
+	realm, err := keycloakClient.GetRealm(ctx, realmId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !realm.AdminPermissionsEnabled {
+		return diag.Errorf("admin permissions are not enabled for realm %s, set admin_permissions_enabled on the keycloak_realm resource", realmId)
+	}
+
+	adminPermissionsClient, err := keycloakClient.GetAdminPermissionsClient(ctx, realmId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(realmId)
+	data.Set("resource_server_id", adminPermissionsClient.Id)
+
+	return nil
+}
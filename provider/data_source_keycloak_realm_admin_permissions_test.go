@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestUnitKeycloakDataSourceRealmAdminPermissions(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	realm := &keycloak.Realm{
+		Realm:   acctest.RandomWithPrefix("tf-unit"),
+		Enabled: true,
+	}
+	if err := keycloakClient.NewRealm(testCtx, realm); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteRealm(testCtx, realm.Realm)
+	})
+
+	adminPermissionsDataSource := testAccProvider.DataSourcesMap["keycloak_realm_admin_permissions"]
+	attributes := map[string]interface{}{
+		"realm_id": realm.Realm,
+	}
+
+	// the data source doesn't enable admin permissions, keycloak_realm does
+	data := schema.TestResourceDataRaw(t, adminPermissionsDataSource.Schema, attributes)
+	diags := adminPermissionsDataSource.ReadContext(testCtx, data, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "admin permissions are not enabled") {
+		t.Fatalf("expected an error as admin permissions are not enabled, got %v", diags)
+	}
+	if _, err := keycloakClient.GetAdminPermissionsClient(testCtx, realm.Realm); err == nil {
+		t.Errorf("expected the %s client not to be created by the data source", keycloak.AdminPermissionsClientId)
+	}
+
+	realm.AdminPermissionsEnabled = true
+	if err := keycloakClient.UpdateRealm(testCtx, realm); err != nil {
+		t.Fatal(err)
+	}
+
+	data = schema.TestResourceDataRaw(t, adminPermissionsDataSource.Schema, attributes)
+	if diags := adminPermissionsDataSource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	adminPermissionsClient, err := keycloakClient.GetAdminPermissionsClient(testCtx, realm.Realm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Get("resource_server_id").(string) != adminPermissionsClient.Id {
+		t.Errorf("expected resource_server_id to be %s, got %s", adminPermissionsClient.Id, data.Get("resource_server_id"))
+	}
+	if data.Id() != realm.Realm {
+		t.Errorf("expected the data source to be identified by its realm, got %s", data.Id())
+	}
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRealmEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmEventsRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"types": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return the events of these types, like LOGIN or LOGIN_ERROR.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events of the client with this client id.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events of the user with this id.",
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events coming from this IP address.",
+			},
+			"date_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEventDate,
+				Description:  "Only return the events since this date (yyyy-MM-dd) or RFC 3339 timestamp.",
+			},
+			"date_to": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEventDate,
+				Description:  "Only return the events until this date (yyyy-MM-dd), which is included, or RFC 3339 timestamp.",
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of events to return, 0 returns every matching event.",
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"session_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateEventDate accepts the dates keycloak accepts, as well as RFC 3339 timestamps, which are more convenient to
+// compute with terraform functions like timeadd
+func validateEventDate(value interface{}, key string) ([]string, []error) {
+	if _, err := eventSearchDate(value.(string)); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a date formatted as yyyy-MM-dd or an RFC 3339 timestamp, got %s", key, value)}
+	}
+
+	return nil, nil
+}
+
+// eventSearchDate converts RFC 3339 timestamps to milliseconds since the epoch, which keycloak supports since version 23
+func eventSearchDate(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return value, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(timestamp.UnixMilli(), 10), nil
+}
+
+// eventSearchDates returns the date_from and date_to search parameters. Timestamps are sent as milliseconds since the
+// epoch, which older versions of keycloak reject.
+func eventSearchDates(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData) (string, string, diag.Diagnostics) {
+	dateFrom, err := eventSearchDate(data.Get("date_from").(string))
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+	dateTo, err := eventSearchDate(data.Get("date_to").(string))
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+
+	if dateFrom == data.Get("date_from").(string) && dateTo == data.Get("date_to").(string) {
+		return dateFrom, dateTo, nil
+	}
+
+	ok, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_23)
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+	if !ok {
+		return "", "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "RFC 3339 timestamps in date_from and date_to require Keycloak v23 or higher, use dates formatted as yyyy-MM-dd instead",
+		}}
+	}
+
+	return dateFrom, dateTo, nil
+}
+
+func eventDate(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+func dataSourceKeycloakRealmEventsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	maxResults := data.Get("max_results").(int)
+
+	// the types are sorted so that the same search always results in the same id
+	types := interfaceSliceToStringSlice(data.Get("types").(*schema.Set).List())
+	sort.Strings(types)
+
+	search := &keycloak.EventSearch{
+		Types:     types,
+		ClientId:  data.Get("client_id").(string),
+		UserId:    data.Get("user_id").(string),
+		IpAddress: data.Get("ip_address").(string),
+	}
+
+	var diags diag.Diagnostics
+	if search.DateFrom, search.DateTo, diags = eventSearchDates(ctx, keycloakClient, data); diags.HasError() {
+		return diags
+	}
+
+	events, err := keycloakClient.SearchRealmEvents(ctx, realmId, search, maxResults)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		result = append(result, map[string]interface{}{
+			"time":       int(event.Time),
+			"date":       eventDate(event.Time),
+			"type":       event.Type,
+			"client_id":  event.ClientId,
+			"user_id":    event.UserId,
+			"session_id": event.SessionId,
+			"ip_address": event.IpAddress,
+			"error":      event.Error,
+			"details":    event.Details,
+		})
+	}
+
+	data.SetId(searchId(realmId, strings.Join(search.Types, ","), search.ClientId, search.UserId, search.IpAddress, search.DateFrom, search.DateTo, strconv.Itoa(maxResults)))
+	data.Set("events", result)
+
+	return nil
+}
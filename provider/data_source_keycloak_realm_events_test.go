@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestAccKeycloakDataSourceRealmEvents(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmEvents(realmName, time.Now().Add(-24*time.Hour).UTC().Format(time.RFC3339)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_realm_events.login_errors", "events.#", "0"),
+					resource.TestCheckResourceAttrSet("data.keycloak_realm_events.login_errors", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakDataSourceRealmEvents(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	clientId := acctest.RandomWithPrefix("tf-unit")
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var events []keycloak.Event
+	for i := 0; i < 150; i++ {
+		event := keycloak.Event{
+			Time:      start.Add(time.Duration(i) * time.Hour).UnixMilli(),
+			Type:      "LOGIN",
+			ClientId:  clientId,
+			UserId:    fmt.Sprintf("user-%d", i%3),
+			IpAddress: "10.0.0.1",
+			Details:   map[string]string{"username": fmt.Sprintf("user-%d", i%3)},
+		}
+		if i%10 == 0 {
+			event.Type = "LOGIN_ERROR"
+			event.Error = "invalid_user_credentials"
+		}
+		events = append(events, event)
+	}
+	testAccServer.AddEvents(testAccRealm.Realm, events...)
+
+	eventsDataSource := testAccProvider.DataSourcesMap["keycloak_realm_events"]
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		raw["realm_id"] = testAccRealm.Realm
+		raw["client_id"] = clientId
+		data := schema.TestResourceDataRaw(t, eventsDataSource.Schema, raw)
+		if diags := eventsDataSource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+
+	all := read(map[string]interface{}{})
+	if count := len(all.Get("events").([]interface{})); count != 150 {
+		t.Errorf("expected 150 events, got %d", count)
+	}
+	if date := all.Get("events.0.date"); date != "2024-03-07T17:00:00Z" {
+		t.Errorf("expected the newest event first, got %s", date)
+	}
+
+	errors := read(map[string]interface{}{
+		"types":   []interface{}{"LOGIN_ERROR"},
+		"user_id": "user-0",
+	})
+	if count := len(errors.Get("events").([]interface{})); count != 5 {
+		t.Errorf("expected 5 login errors of user-0, got %d", count)
+	}
+	if errors.Get("events.0.error") != "invalid_user_credentials" || errors.Get("events.0.details.username") != "user-0" {
+		t.Errorf("expected the error and details of the event, got %v", errors.Get("events.0"))
+	}
+
+	day := read(map[string]interface{}{
+		"date_from": "2024-03-02",
+		"date_to":   "2024-03-02",
+	})
+	if count := len(day.Get("events").([]interface{})); count != 24 {
+		t.Errorf("expected 24 events on 2024-03-02, got %d", count)
+	}
+
+	hours := read(map[string]interface{}{
+		"date_from":   "2024-03-01T12:00:00Z",
+		"date_to":     "2024-03-01T14:00:00+00:00",
+		"max_results": 2,
+	})
+	if count := len(hours.Get("events").([]interface{})); count != 2 {
+		t.Errorf("expected 2 events, got %d", count)
+	}
+	if hours.Id() == all.Id() {
+		t.Error("expected different searches to have different ids")
+	}
+}
+
+func TestUnitKeycloakDataSourceRealmEvents_date(t *testing.T) {
+	for _, date := range []string{"yesterday", "2024-13-01", "2024-03-01 12:00:00"} {
+		if _, errors := validateEventDate(date, "date_from"); len(errors) == 0 {
+			t.Errorf("expected %s to be invalid", date)
+		}
+	}
+	for _, date := range []string{"2024-03-01", "2024-03-01T12:00:00Z"} {
+		if _, errors := validateEventDate(date, "date_from"); len(errors) != 0 {
+			t.Errorf("expected %s to be valid, got %v", date, errors)
+		}
+	}
+}
+
+// TestUnitKeycloakDataSourceRealmEvents_timestampVersion checks that timestamps are rejected before Keycloak 23
+func TestUnitKeycloakDataSourceRealmEvents_timestampVersion(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.Version = "22.0.5"
+
+	client, err := server.NewClient(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eventsDataSource := testAccProvider.DataSourcesMap["keycloak_realm_events"]
+	read := func(dateFrom string) diag.Diagnostics {
+		data := schema.TestResourceDataRaw(t, eventsDataSource.Schema, map[string]interface{}{
+			"realm_id":  "master",
+			"date_from": dateFrom,
+		})
+		return eventsDataSource.ReadContext(testCtx, data, client)
+	}
+
+	if diags := read("2024-03-01T12:00:00Z"); !diags.HasError() || !strings.Contains(diags[0].Summary, "require Keycloak v23 or higher") {
+		t.Errorf("expected timestamps to be rejected by Keycloak 22, got %v", diags)
+	}
+	if diags := read("2024-03-01"); diags.HasError() {
+		t.Errorf("expected dates to be accepted by Keycloak 22, got %v", diags)
+	}
+}
+
+func testDataSourceKeycloakRealmEvents(realm, dateFrom string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_events" "realm_events" {
+	realm_id       = keycloak_realm.realm.id
+	events_enabled = true
+}
+
+data "keycloak_realm_events" "login_errors" {
+	realm_id  = keycloak_realm_events.realm_events.realm_id
+	types     = ["LOGIN_ERROR"]
+	date_from = "%s"
+}
+	`, realm, dateFrom)
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRolesRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "When set, the roles of the client with this id are returned instead of the realm roles.",
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the roles whose name starts with this prefix.",
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only return the roles with all of these attribute values.",
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"composite": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"composite_roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"attributes": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// roleHasAttributes checks that the role has all the attribute values, the values of multivalued attributes being
+// matched one by one
+func roleHasAttributes(role *keycloak.Role, attributes map[string]string) bool {
+	for key, value := range attributes {
+		if !stringSliceContains(role.Attributes[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// roleSearchId identifies the data source by its search parameters, the attributes are sorted so that the same search
+// results in the same id
+func roleSearchId(realmId, clientId, namePrefix string, attributes map[string]string) string {
+	var query []string
+	for key, value := range attributes {
+		query = append(query, fmt.Sprintf("%s:%s", key, value))
+	}
+	sort.Strings(query)
+
+	return searchId(realmId, clientId, namePrefix, strings.Join(query, " "))
+}
+
+func dataSourceKeycloakRolesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	clientId := data.Get("client_id").(string)
+	namePrefix := data.Get("name_prefix").(string)
+
+	attributes := map[string]string{}
+	for key, value := range data.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+
+	var roles []*keycloak.Role
+	var err error
+	if clientId != "" {
+		roles, err = keycloakClient.GetClientRoles(ctx, realmId, []*keycloak.OpenidClient{{Id: clientId}})
+	} else {
+		roles, err = keycloakClient.GetRealmRoles(ctx, realmId)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+
+	result := make([]interface{}, 0, len(roles))
+	ids := make([]string, 0, len(roles))
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !strings.HasPrefix(role.Name, namePrefix) || !roleHasAttributes(role, attributes) {
+			continue
+		}
+
+		compositeRoles := make([]string, 0)
+		if role.Composite {
+			composites, err := keycloakClient.GetRoleComposites(ctx, role)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, composite := range composites {
+				compositeRoles = append(compositeRoles, composite.Id)
+			}
+			sort.Strings(compositeRoles)
+		}
+
+		roleAttributes := map[string]string{}
+		for k, v := range role.Attributes {
+			roleAttributes[k] = strings.Join(v, MULTIVALUE_ATTRIBUTE_SEPARATOR)
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":              role.Id,
+			"name":            role.Name,
+			"description":     role.Description,
+			"client_id":       role.ClientId,
+			"composite":       role.Composite,
+			"composite_roles": compositeRoles,
+			"attributes":      roleAttributes,
+		})
+		ids = append(ids, role.Id)
+		names = append(names, role.Name)
+	}
+
+	data.SetId(roleSearchId(realmId, clientId, namePrefix, attributes))
+	data.Set("roles", result)
+	data.Set("ids", ids)
+	data.Set("names", names)
+
+	return nil
+}
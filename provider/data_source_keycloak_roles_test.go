@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakDataSourceRoles(t *testing.T) {
+	t.Parallel()
+
+	prefix := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRoles(prefix),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_roles.realm", "roles.#", "3"),
+					resource.TestCheckResourceAttr("data.keycloak_roles.realm", "names.0", prefix+"-admin"),
+					resource.TestCheckResourceAttr("data.keycloak_roles.realm", "roles.0.composite", "true"),
+					resource.TestCheckResourceAttr("data.keycloak_roles.realm", "roles.0.composite_roles.#", "2"),
+					resource.TestCheckResourceAttr("data.keycloak_roles.team", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.keycloak_roles.team", "ids.0", "keycloak_role.reader", "id"),
+					resource.TestCheckResourceAttr("data.keycloak_roles.team", "roles.0.attributes.team", "blue"),
+					resource.TestCheckResourceAttr("data.keycloak_roles.client", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.keycloak_roles.client", "roles.0.client_id", "keycloak_openid_client.client", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakDataSourceRoles(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	prefix := acctest.RandomWithPrefix("tf-unit")
+	client := &keycloak.OpenidClient{
+		RealmId:  testAccRealm.Realm,
+		ClientId: prefix,
+		Enabled:  true,
+	}
+	if err := keycloakClient.NewOpenidClient(testCtx, client); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteOpenidClient(testCtx, testAccRealm.Realm, client.Id)
+	})
+
+	createRole := func(clientId, name string, attributes map[string][]string) *keycloak.Role {
+		role := &keycloak.Role{
+			RealmId:    testAccRealm.Realm,
+			ClientId:   clientId,
+			Name:       name,
+			Attributes: attributes,
+		}
+		if err := keycloakClient.CreateRole(testCtx, role); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = keycloakClient.DeleteRole(testCtx, testAccRealm.Realm, role.Id)
+		})
+		return role
+	}
+
+	reader := createRole("", prefix+"-reader", map[string][]string{"team": {"blue", "red"}})
+	writer := createRole("", prefix+"-writer", map[string][]string{"team": {"red"}})
+	admin := createRole("", prefix+"-admin", nil)
+	clientRole := createRole(client.Id, "manage", map[string][]string{"team": {"blue"}})
+	if err := keycloakClient.AddCompositesToRole(testCtx, admin, []*keycloak.Role{writer, reader, clientRole}); err != nil {
+		t.Fatal(err)
+	}
+
+	rolesDataSource := testAccProvider.DataSourcesMap["keycloak_roles"]
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		raw["realm_id"] = testAccRealm.Realm
+		data := schema.TestResourceDataRaw(t, rolesDataSource.Schema, raw)
+		if diags := rolesDataSource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+
+	realmRoles := read(map[string]interface{}{"name_prefix": prefix})
+	if names := realmRoles.Get("names").([]interface{}); len(names) != 3 || names[0] != admin.Name {
+		t.Fatalf("expected the 3 realm roles sorted by name, got %v", names)
+	}
+	if composites := realmRoles.Get("roles.0.composite_roles").([]interface{}); len(composites) != 3 {
+		t.Errorf("expected the admin role to have 3 composites, got %v", composites)
+	}
+	if realmRoles.Get("roles.1.attributes.team") != "blue##red" {
+		t.Errorf("expected the attributes of the reader role, got %v", realmRoles.Get("roles.1.attributes"))
+	}
+
+	blue := read(map[string]interface{}{
+		"name_prefix": prefix,
+		"attributes":  map[string]interface{}{"team": "blue"},
+	})
+	if ids := blue.Get("ids").([]interface{}); len(ids) != 1 || ids[0] != reader.Id {
+		t.Errorf("expected only the reader role to be in team blue, got %v", ids)
+	}
+
+	clientRoles := read(map[string]interface{}{"client_id": client.Id})
+	if ids := clientRoles.Get("ids").([]interface{}); len(ids) != 1 || ids[0] != clientRole.Id {
+		t.Errorf("expected the client role, got %v", ids)
+	}
+	if clientRoles.Get("roles.0.client_id") != client.Id {
+		t.Errorf("expected the client id of the client role, got %s", clientRoles.Get("roles.0.client_id"))
+	}
+	if clientRoles.Id() == realmRoles.Id() {
+		t.Error("expected different searches to have different ids")
+	}
+}
+
+func testDataSourceKeycloakRoles(prefix string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id    = data.keycloak_realm.realm.id
+	client_id   = "%s"
+	access_type = "PUBLIC"
+}
+
+resource "keycloak_role" "client_role" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client.id
+	name      = "manage"
+}
+
+resource "keycloak_role" "reader" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s-reader"
+
+	attributes = {
+		team = "blue"
+	}
+}
+
+resource "keycloak_role" "writer" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s-writer"
+
+	attributes = {
+		team = "red"
+	}
+}
+
+resource "keycloak_role" "admin" {
+	realm_id        = data.keycloak_realm.realm.id
+	name            = "%s-admin"
+	composite_roles = [keycloak_role.reader.id, keycloak_role.writer.id]
+}
+
+data "keycloak_roles" "realm" {
+	realm_id    = data.keycloak_realm.realm.id
+	name_prefix = "%s"
+
+	depends_on = [keycloak_role.reader, keycloak_role.writer, keycloak_role.admin]
+}
+
+data "keycloak_roles" "team" {
+	realm_id    = data.keycloak_realm.realm.id
+	name_prefix = "%s"
+
+	attributes = {
+		team = "blue"
+	}
+
+	depends_on = [keycloak_role.reader, keycloak_role.writer, keycloak_role.admin]
+}
+
+data "keycloak_roles" "client" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_role.client_role.client_id
+}
+	`, testAccRealm.Realm, prefix, prefix, prefix, prefix, prefix, prefix)
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakUserCredentials() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakUserCredentialsRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the credentials of this type.",
+			},
+			"credentials": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_date": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The position of the credential in the priority order of all credentials of the user, starting at 0.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakUserCredentialsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	userId := data.Get("user_id").(string)
+	credentialType := data.Get("type").(string)
+
+	credentials, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := make([]interface{}, 0, len(credentials))
+	for i, credential := range credentials {
+		if credentialType != "" && credential.Type != credentialType {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":           credential.Id,
+			"type":         credential.Type,
+			"user_label":   credential.UserLabel,
+			"created_date": credential.CreatedDate,
+			"priority":     i,
+		})
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s", realmId, userId))
+	data.Set("credentials", result)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceUserCredentials(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakUserCredentials(username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_user_credentials.all", "credentials.#", "2"),
+					resource.TestCheckResourceAttr("data.keycloak_user_credentials.all", "credentials.0.type", "password"),
+					resource.TestCheckResourceAttr("data.keycloak_user_credentials.all", "credentials.0.priority", "0"),
+					resource.TestCheckResourceAttr("data.keycloak_user_credentials.otp", "credentials.#", "1"),
+					resource.TestCheckResourceAttr("data.keycloak_user_credentials.otp", "credentials.0.user_label", "authenticator"),
+					resource.TestCheckResourceAttrPair("data.keycloak_user_credentials.otp", "credentials.0.priority", "data.keycloak_user_credentials.all", "credentials.1.priority"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakUserCredentials(username string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_user" "user" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+
+	initial_password {
+		value = "My password"
+	}
+}
+
+resource "keycloak_user_credential" "otp" {
+	realm_id   = data.keycloak_realm.realm.id
+	user_id    = keycloak_user.user.id
+	type       = "otp"
+	user_label = "authenticator"
+
+	otp {
+		secret = "12345678901234567890"
+	}
+}
+
+data "keycloak_user_credentials" "all" {
+	realm_id = data.keycloak_realm.realm.id
+	user_id  = keycloak_user.user.id
+
+	depends_on = [keycloak_user_credential.otp]
+}
+
+data "keycloak_user_credentials" "otp" {
+	realm_id = data.keycloak_realm.realm.id
+	user_id  = keycloak_user.user.id
+	type     = "otp"
+
+	depends_on = [keycloak_user_credential.otp]
+}
+	`, testAccRealm.Realm, username)
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakUsersRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A string contained in the username, email, first name or last name of the users. Use \"*\" to match every user.",
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"idp_alias": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the users linked to this identity provider.",
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"exact": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, username and email have to match exactly instead of being contained in the user's values.",
+			},
+			"attribute_query": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only return the users with all of these attribute values.",
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of users to return, 0 returns every matching user.",
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"first_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"attributes": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"usernames": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func getUserSearchFromData(data *schema.ResourceData) *keycloak.UserSearch {
+	search := &keycloak.UserSearch{
+		Search:         data.Get("search").(string),
+		Username:       data.Get("username").(string),
+		Email:          data.Get("email").(string),
+		IdpAlias:       data.Get("idp_alias").(string),
+		Exact:          data.Get("exact").(bool),
+		AttributeQuery: map[string]string{},
+	}
+
+	if enabled, ok := data.GetOkExists("enabled"); ok {
+		value := enabled.(bool)
+		search.Enabled = &value
+	}
+
+	for key, value := range data.Get("attribute_query").(map[string]interface{}) {
+		search.AttributeQuery[key] = value.(string)
+	}
+
+	return search
+}
+
+// userSearchId identifies the data source by its search parameters, the same search results in the same id
+func userSearchId(realmId string, search *keycloak.UserSearch, maxResults int) string {
+	var query []string
+	for key, value := range search.AttributeQuery {
+		query = append(query, fmt.Sprintf("%s:%s", key, value))
+	}
+	sort.Strings(query)
+
+	enabled := ""
+	if search.Enabled != nil {
+		enabled = fmt.Sprintf("%t", *search.Enabled)
+	}
+
+	return searchId(realmId, search.Search, search.Username, search.Email, search.IdpAlias, enabled, fmt.Sprintf("%t", search.Exact), strings.Join(query, " "), fmt.Sprintf("%d", maxResults))
+}
+
+func dataSourceKeycloakUsersRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	maxResults := data.Get("max_results").(int)
+	search := getUserSearchFromData(data)
+
+	users, err := keycloakClient.SearchUsers(ctx, realmId, search, maxResults)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := make([]interface{}, 0, len(users))
+	ids := make([]string, 0, len(users))
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		attributes := map[string]string{}
+		for k, v := range user.Attributes {
+			attributes[k] = strings.Join(v, MULTIVALUE_ATTRIBUTE_SEPARATOR)
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":         user.Id,
+			"username":   user.Username,
+			"email":      user.Email,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"enabled":    user.Enabled,
+			"attributes": attributes,
+		})
+		ids = append(ids, user.Id)
+		usernames = append(usernames, user.Username)
+	}
+
+	data.SetId(userSearchId(realmId, search, maxResults))
+	data.Set("users", result)
+	data.Set("ids", ids)
+	data.Set("usernames", usernames)
+
+	return nil
+}
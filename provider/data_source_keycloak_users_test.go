@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakDataSourceUsers(t *testing.T) {
+	prefix := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakUsers(prefix),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_users.search", "users.#", "3"),
+					resource.TestCheckResourceAttr("data.keycloak_users.search", "usernames.#", "3"),
+					resource.TestCheckResourceAttr("data.keycloak_users.team", "users.#", "2"),
+					resource.TestCheckResourceAttr("data.keycloak_users.team", "users.0.attributes.team", "blue"),
+					resource.TestCheckResourceAttr("data.keycloak_users.disabled", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.keycloak_users.disabled", "ids.0", "keycloak_user.user.2", "id"),
+					resource.TestCheckResourceAttr("data.keycloak_users.exact", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.keycloak_users.exact", "users.0.email", fmt.Sprintf("%s-0@example.com", prefix)),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitKeycloakDataSourceUsers_pagination searches more users than fit on a single page of results
+func TestUnitKeycloakDataSourceUsers_pagination(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	prefix := acctest.RandomWithPrefix("tf-unit")
+	for i := 0; i < 250; i++ {
+		user := &keycloak.User{
+			RealmId:  testAccRealm.Realm,
+			Username: fmt.Sprintf("%s-%03d", prefix, i),
+			Enabled:  i%2 == 0,
+			Attributes: map[string][]string{
+				"shard": {fmt.Sprintf("%d", i%5)},
+				"team":  {fmt.Sprintf("team %d", i%2)},
+			},
+		}
+		if err := keycloakClient.NewUser(testCtx, user); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = keycloakClient.DeleteUser(testCtx, testAccRealm.Realm, user.Id)
+		})
+	}
+
+	usersDataSource := testAccProvider.DataSourcesMap["keycloak_users"]
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		raw["realm_id"] = testAccRealm.Realm
+		data := schema.TestResourceDataRaw(t, usersDataSource.Schema, raw)
+		if diags := usersDataSource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+
+	all := read(map[string]interface{}{"search": prefix})
+	if count := len(all.Get("ids").([]interface{})); count != 250 {
+		t.Errorf("expected 250 users, got %d", count)
+	}
+
+	filtered := read(map[string]interface{}{
+		"search":          prefix,
+		"enabled":         false,
+		"attribute_query": map[string]interface{}{"shard": "1"},
+	})
+	if count := len(filtered.Get("users").([]interface{})); count != 25 {
+		t.Errorf("expected 25 disabled users of shard 1, got %d", count)
+	}
+	if filtered.Get("users.0.attributes.shard") != "1" {
+		t.Errorf("expected attributes to be returned, got %v", filtered.Get("users.0.attributes"))
+	}
+
+	// the value contains a space, which must not split the attribute query
+	team := read(map[string]interface{}{
+		"search":          prefix,
+		"attribute_query": map[string]interface{}{"team": "team 1"},
+	})
+	if count := len(team.Get("ids").([]interface{})); count != 125 {
+		t.Errorf("expected 125 users of team 1, got %d", count)
+	}
+
+	limited := read(map[string]interface{}{"search": prefix, "max_results": 120})
+	if count := len(limited.Get("usernames").([]interface{})); count != 120 {
+		t.Errorf("expected 120 users, got %d", count)
+	}
+	if limited.Id() == all.Id() {
+		t.Error("expected different searches to have different ids")
+	}
+}
+
+func testDataSourceKeycloakUsers(prefix string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_user" "user" {
+	count    = 3
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s-${count.index}"
+	email    = "%s-${count.index}@example.com"
+	enabled  = count.index != 2
+
+	attributes = {
+		team = count.index == 1 ? "red" : "blue"
+	}
+}
+
+data "keycloak_users" "search" {
+	realm_id = data.keycloak_realm.realm.id
+	search   = "%s"
+
+	depends_on = [keycloak_user.user]
+}
+
+data "keycloak_users" "team" {
+	realm_id = data.keycloak_realm.realm.id
+	search   = "%s"
+
+	attribute_query = {
+		team = "blue"
+	}
+
+	depends_on = [keycloak_user.user]
+}
+
+data "keycloak_users" "disabled" {
+	realm_id = data.keycloak_realm.realm.id
+	search   = "%s"
+	enabled  = false
+
+	depends_on = [keycloak_user.user]
+}
+
+data "keycloak_users" "exact" {
+	realm_id = data.keycloak_realm.realm.id
+	email    = "%s-0@example.com"
+	exact    = true
+
+	depends_on = [keycloak_user.user]
+}
+	`, testAccRealm.Realm, prefix, prefix, prefix, prefix, prefix, prefix)
+}
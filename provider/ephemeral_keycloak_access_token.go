@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var (
+	keycloakAccessTokenGrantTypes = map[string]string{
+		"client_credentials": "client_credentials",
+		"password":           "password",
+		"token_exchange":     keycloak.TokenExchangeGrantType,
+	}
+)
+
+const keycloakAccessTokenType = "urn:ietf:params:oauth:token-type:access_token"
+
+// keycloakAccessTokenEphemeralResource requests a token on open, there's nothing to renew or close
+type keycloakAccessTokenEphemeralResource struct {
+	keycloakClient *keycloak.KeycloakClient
+}
+
+type keycloakAccessTokenModel struct {
+	RealmId            types.String `tfsdk:"realm_id"`
+	ClientId           types.String `tfsdk:"client_id"`
+	ClientSecret       types.String `tfsdk:"client_secret"`
+	JwtSigningKey      types.String `tfsdk:"jwt_signing_key"`
+	JwtSigningAlg      types.String `tfsdk:"jwt_signing_alg"`
+	GrantType          types.String `tfsdk:"grant_type"`
+	Scopes             types.List   `tfsdk:"scopes"`
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	SubjectToken       types.String `tfsdk:"subject_token"`
+	SubjectTokenType   types.String `tfsdk:"subject_token_type"`
+	RequestedTokenType types.String `tfsdk:"requested_token_type"`
+	Audience           types.String `tfsdk:"audience"`
+	AccessToken        types.String `tfsdk:"access_token"`
+	RefreshToken       types.String `tfsdk:"refresh_token"`
+	TokenType          types.String `tfsdk:"token_type"`
+	IssuedTokenType    types.String `tfsdk:"issued_token_type"`
+	Scope              types.String `tfsdk:"scope"`
+	ExpiresIn          types.Int64  `tfsdk:"expires_in"`
+	ExpiresAt          types.String `tfsdk:"expires_at"`
+}
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure      = &keycloakAccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &keycloakAccessTokenEphemeralResource{}
+)
+
+func newKeycloakAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &keycloakAccessTokenEphemeralResource{}
+}
+
+func (r *keycloakAccessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *keycloakAccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = ephemeralschema.Schema{
+		Description: "Requests an access token from a client of a realm. The token is never stored in the state.",
+		Attributes: map[string]ephemeralschema.Attribute{
+			"realm_id":             ephemeralschema.StringAttribute{Required: true},
+			"client_id":            ephemeralschema.StringAttribute{Required: true, Description: "The client id of the client requesting the token."},
+			"client_secret":        ephemeralschema.StringAttribute{Optional: true, Sensitive: true},
+			"jwt_signing_key":      ephemeralschema.StringAttribute{Optional: true, Sensitive: true, Description: "The PEM-formatted private key used to sign the client assertion of clients authenticated with a signed JWT."},
+			"jwt_signing_alg":      ephemeralschema.StringAttribute{Optional: true, Description: "The algorithm used to sign the client assertion. Defaults to RS256."},
+			"grant_type":           ephemeralschema.StringAttribute{Optional: true, Description: "One of client_credentials, password or token_exchange. Defaults to client_credentials."},
+			"scopes":               ephemeralschema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"username":             ephemeralschema.StringAttribute{Optional: true, Description: "The username of the user, for the password grant."},
+			"password":             ephemeralschema.StringAttribute{Optional: true, Sensitive: true, Description: "The password of the user, for the password grant."},
+			"subject_token":        ephemeralschema.StringAttribute{Optional: true, Sensitive: true, Description: "The token to exchange, for the token_exchange grant."},
+			"subject_token_type":   ephemeralschema.StringAttribute{Optional: true, Description: "The type of subject_token. Defaults to an access token."},
+			"requested_token_type": ephemeralschema.StringAttribute{Optional: true},
+			"audience":             ephemeralschema.StringAttribute{Optional: true, Description: "The client id of the client the exchanged token is intended for."},
+			"access_token":         ephemeralschema.StringAttribute{Computed: true, Sensitive: true},
+			"refresh_token":        ephemeralschema.StringAttribute{Computed: true, Sensitive: true},
+			"token_type":           ephemeralschema.StringAttribute{Computed: true},
+			"issued_token_type":    ephemeralschema.StringAttribute{Computed: true},
+			"scope":                ephemeralschema.StringAttribute{Computed: true},
+			"expires_in":           ephemeralschema.Int64Attribute{Computed: true},
+			"expires_at":           ephemeralschema.StringAttribute{Computed: true, Description: "When the access token expires, in RFC 3339 format. Empty for tokens that don't expire."},
+		},
+	}
+}
+
+func (r *keycloakAccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// the provider data is only set once the provider has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	keycloakClient, ok := req.ProviderData.(*keycloak.KeycloakClient)
+	if !ok {
+		resp.Diagnostics.AddError("unexpected provider data", fmt.Sprintf("expected a keycloak client, got %T", req.ProviderData))
+		return
+	}
+
+	r.keycloakClient = keycloakClient
+}
+
+func (r *keycloakAccessTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config keycloakAccessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKeycloakAccessToken(config)...)
+}
+
+func validateKeycloakAccessToken(config keycloakAccessTokenModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.GrantType.IsUnknown() {
+		return nil
+	}
+	grantType := keycloakAccessTokenGrantType(config)
+	if _, ok := keycloakAccessTokenGrantTypes[grantType]; !ok {
+		diags.AddAttributeError(path.Root("grant_type"), fmt.Sprintf("grant_type must be one of client_credentials, password or token_exchange, got %s", grantType), "")
+	}
+
+	if config.ClientSecret.ValueString() != "" && config.JwtSigningKey.ValueString() != "" {
+		diags.AddAttributeError(path.Root("jwt_signing_key"), "only one of client_secret and jwt_signing_key can be set", "")
+	}
+
+	// the attributes required by a grant may only be known during apply, they're checked once known
+	type requiredAttribute struct {
+		name  string
+		value types.String
+	}
+	required := map[string][]requiredAttribute{
+		"password":       {{"username", config.Username}, {"password", config.Password}},
+		"token_exchange": {{"subject_token", config.SubjectToken}},
+	}
+	for _, attribute := range required[grantType] {
+		if !attribute.value.IsUnknown() && attribute.value.ValueString() == "" {
+			diags.AddAttributeError(path.Root(attribute.name), fmt.Sprintf("%s is required for the %s grant", attribute.name, grantType), "")
+		}
+	}
+
+	return diags
+}
+
+func keycloakAccessTokenGrantType(config keycloakAccessTokenModel) string {
+	if grantType := config.GrantType.ValueString(); grantType != "" {
+		return grantType
+	}
+
+	return "client_credentials"
+}
+
+func (r *keycloakAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.keycloakClient == nil {
+		resp.Diagnostics.AddError("the provider has not been configured", "")
+		return
+	}
+
+	var config keycloakAccessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// values that were unknown during validation are known now
+	resp.Diagnostics.Append(validateKeycloakAccessToken(config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenRequest := &keycloak.AccessTokenRequest{
+		RealmId:            config.RealmId.ValueString(),
+		ClientId:           config.ClientId.ValueString(),
+		ClientSecret:       config.ClientSecret.ValueString(),
+		JWTSigningKey:      config.JwtSigningKey.ValueString(),
+		JWTSigningAlg:      config.JwtSigningAlg.ValueString(),
+		GrantType:          keycloakAccessTokenGrantTypes[keycloakAccessTokenGrantType(config)],
+		Username:           config.Username.ValueString(),
+		Password:           config.Password.ValueString(),
+		SubjectToken:       config.SubjectToken.ValueString(),
+		SubjectTokenType:   config.SubjectTokenType.ValueString(),
+		RequestedTokenType: config.RequestedTokenType.ValueString(),
+		Audience:           config.Audience.ValueString(),
+	}
+	resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &tokenRequest.Scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tokenRequest.JWTSigningAlg == "" {
+		tokenRequest.JWTSigningAlg = "RS256"
+	}
+	if tokenRequest.SubjectTokenType == "" {
+		tokenRequest.SubjectTokenType = keycloakAccessTokenType
+	}
+
+	accessToken, err := r.keycloakClient.NewAccessToken(ctx, tokenRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("unable to request an access token for client %s", tokenRequest.ClientId), err.Error())
+		return
+	}
+
+	expiresAt := ""
+	if !accessToken.ExpiresAt.IsZero() {
+		expiresAt = accessToken.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	config.AccessToken = types.StringValue(accessToken.AccessToken)
+	config.RefreshToken = types.StringValue(accessToken.RefreshToken)
+	config.TokenType = types.StringValue(accessToken.TokenType)
+	config.IssuedTokenType = types.StringValue(accessToken.IssuedTokenType)
+	config.Scope = types.StringValue(accessToken.Scope)
+	config.ExpiresIn = types.Int64Value(int64(accessToken.ExpiresIn))
+	config.ExpiresAt = types.StringValue(expiresAt)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// the token is handed to a second provider, which can only read the realm if the token was issued
+func TestAccKeycloakEphemeralAccessToken_clientCredentials(t *testing.T) {
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakEphemeralAccessToken_clientCredentials(clientId),
+				Check:  resource.TestCheckResourceAttr("data.keycloak_realm.with_token", "realm", testAccRealm.Realm),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakEphemeralAccessToken(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	client := &keycloak.OpenidClient{
+		RealmId:                   testAccRealm.Realm,
+		ClientId:                  acctest.RandomWithPrefix("tf-unit"),
+		ClientSecret:              "client-secret",
+		ClientAuthenticatorType:   "client-secret",
+		Enabled:                   true,
+		ServiceAccountsEnabled:    true,
+		DirectAccessGrantsEnabled: true,
+	}
+	if err := keycloakClient.NewOpenidClient(testCtx, client); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteOpenidClient(testCtx, testAccRealm.Realm, client.Id)
+	})
+
+	user := createTestUser(t, acctest.RandomWithPrefix("tf-unit"))
+	if err := keycloakClient.ResetUserPassword(testCtx, testAccRealm.Realm, user, "user-password", false); err != nil {
+		t.Fatal(err)
+	}
+	username := testAccUsernameOf(t, user)
+
+	server, err := NewProviderServer(testCtx, testAccProvider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	providerSchema, err := server.GetProviderSchema(testCtx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(providerSchema.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %s: %s", providerSchema.Diagnostics[0].Summary, providerSchema.Diagnostics[0].Detail)
+	}
+	tokenSchema := providerSchema.EphemeralResourceSchemas["keycloak_access_token"]
+	if tokenSchema == nil || providerSchema.ResourceSchemas["keycloak_realm"] == nil {
+		t.Fatal("expected the ephemeral resources to be served next to the resources of the SDK")
+	}
+
+	// the SDK provider is configured with the client of the tests, which is then shared with the framework provider
+	configured, err := server.ConfigureProvider(testCtx, &tfprotov5.ConfigureProviderRequest{Config: testAccProtoV5Config(t, providerSchema.Provider, nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configured.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %s: %s", configured.Diagnostics[0].Summary, configured.Diagnostics[0].Detail)
+	}
+
+	open := func(attributes map[string]tftypes.Value) *tfprotov5.OpenEphemeralResourceResponse {
+		config := testAccProtoV5Config(t, tokenSchema, attributes)
+
+		validation, err := server.ValidateEphemeralResourceConfig(testCtx, &tfprotov5.ValidateEphemeralResourceConfigRequest{TypeName: "keycloak_access_token", Config: config})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(validation.Diagnostics) != 0 {
+			return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: validation.Diagnostics}
+		}
+
+		resp, err := server.OpenEphemeralResource(testCtx, &tfprotov5.OpenEphemeralResourceRequest{TypeName: "keycloak_access_token", Config: config})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	result := func(resp *tfprotov5.OpenEphemeralResourceResponse) map[string]string {
+		t.Helper()
+		if len(resp.Diagnostics) != 0 {
+			t.Fatalf("unexpected diagnostics: %s: %s", resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+		}
+		return testAccProtoV5Strings(t, tokenSchema, resp.Result)
+	}
+
+	clientCredentials := result(open(map[string]tftypes.Value{
+		"realm_id":      tftypes.NewValue(tftypes.String, testAccRealm.Realm),
+		"client_id":     tftypes.NewValue(tftypes.String, client.ClientId),
+		"client_secret": tftypes.NewValue(tftypes.String, "client-secret"),
+		"scopes":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "roles")}),
+	}))
+	accessToken := clientCredentials["access_token"]
+	if accessToken == "" {
+		t.Fatal("expected an access token")
+	}
+	if clientCredentials["expires_at"] == "" {
+		t.Error("expected expires_at to be set")
+	}
+	if scope := clientCredentials["scope"]; !regexp.MustCompile(`\broles\b`).MatchString(scope) {
+		t.Errorf("expected the requested scope to be granted, got %s", scope)
+	}
+	if clientCredentials["client_secret"] != "client-secret" {
+		t.Error("expected the configured values to be kept")
+	}
+
+	exchanged := result(open(map[string]tftypes.Value{
+		"realm_id":      tftypes.NewValue(tftypes.String, testAccRealm.Realm),
+		"client_id":     tftypes.NewValue(tftypes.String, client.ClientId),
+		"client_secret": tftypes.NewValue(tftypes.String, "client-secret"),
+		"grant_type":    tftypes.NewValue(tftypes.String, "token_exchange"),
+		"subject_token": tftypes.NewValue(tftypes.String, accessToken),
+		"audience":      tftypes.NewValue(tftypes.String, client.ClientId),
+	}))
+	if issuedTokenType := exchanged["issued_token_type"]; issuedTokenType != keycloakAccessTokenType {
+		t.Errorf("unexpected issued token type %s", issuedTokenType)
+	}
+
+	password := result(open(map[string]tftypes.Value{
+		"realm_id":      tftypes.NewValue(tftypes.String, testAccRealm.Realm),
+		"client_id":     tftypes.NewValue(tftypes.String, client.ClientId),
+		"client_secret": tftypes.NewValue(tftypes.String, "client-secret"),
+		"grant_type":    tftypes.NewValue(tftypes.String, "password"),
+		"username":      tftypes.NewValue(tftypes.String, username),
+		"password":      tftypes.NewValue(tftypes.String, "user-password"),
+	}))
+	if password["refresh_token"] == "" {
+		t.Error("expected a refresh token for the password grant")
+	}
+
+	for name, test := range map[string]struct {
+		attributes map[string]tftypes.Value
+		error      string
+	}{
+		"missing username": {
+			attributes: map[string]tftypes.Value{"grant_type": tftypes.NewValue(tftypes.String, "password")},
+			error:      "username is required for the password grant",
+		},
+		"unknown grant": {
+			attributes: map[string]tftypes.Value{"grant_type": tftypes.NewValue(tftypes.String, "implicit")},
+			error:      "grant_type must be one of",
+		},
+		"invalid secret": {
+			attributes: map[string]tftypes.Value{"client_secret": tftypes.NewValue(tftypes.String, "wrong")},
+			error:      "Invalid client or Invalid client credentials",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]tftypes.Value{
+				"realm_id":  tftypes.NewValue(tftypes.String, testAccRealm.Realm),
+				"client_id": tftypes.NewValue(tftypes.String, client.ClientId),
+			}
+			for k, v := range test.attributes {
+				attributes[k] = v
+			}
+
+			resp := open(attributes)
+			if len(resp.Diagnostics) == 0 || !regexp.MustCompile(test.error).MatchString(resp.Diagnostics[0].Summary+": "+resp.Diagnostics[0].Detail) {
+				t.Errorf("expected error %s, got %v", test.error, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func testAccUsernameOf(t *testing.T, userId string) string {
+	user, err := keycloakClient.GetUser(testCtx, testAccRealm.Realm, userId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user.Username
+}
+
+// testAccProtoV5Config builds a configuration matching the schema, attributes that aren't given are null
+func testAccProtoV5Config(t *testing.T, configSchema *tfprotov5.Schema, attributes map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	objectType := configSchema.ValueType().(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+
+	config, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &config
+}
+
+// testAccProtoV5Strings decodes the string attributes of a value matching the schema, null strings are empty
+func testAccProtoV5Strings(t *testing.T, valueSchema *tfprotov5.Schema, dynamicValue *tfprotov5.DynamicValue) map[string]string {
+	value, err := dynamicValue.Unmarshal(valueSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	strings := map[string]string{}
+	for name, attribute := range attributes {
+		if !attribute.Type().Is(tftypes.String) || attribute.IsNull() {
+			continue
+		}
+		var s string
+		if err := attribute.As(&s); err != nil {
+			t.Fatal(err)
+		}
+		strings[name] = s
+	}
+
+	return strings
+}
+
+func testKeycloakEphemeralAccessToken_clientCredentials(clientId string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                 = data.keycloak_realm.realm.id
+	client_id                = "%s"
+	access_type              = "CONFIDENTIAL"
+	service_accounts_enabled = true
+}
+
+data "keycloak_openid_client" "realm_management" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "realm-management"
+}
+
+resource "keycloak_openid_client_service_account_role" "view_realm" {
+	realm_id                = data.keycloak_realm.realm.id
+	service_account_user_id = keycloak_openid_client.client.service_account_user_id
+	client_id               = data.keycloak_openid_client.realm_management.id
+	role                    = "view-realm"
+}
+
+ephemeral "keycloak_access_token" "token" {
+	realm_id      = data.keycloak_realm.realm.id
+	client_id     = keycloak_openid_client.client.client_id
+	client_secret = keycloak_openid_client.client.client_secret
+
+	depends_on = [keycloak_openid_client_service_account_role.view_realm]
+}
+
+provider "keycloak" {
+	alias         = "with_token"
+	url           = "%s"
+	access_token  = ephemeral.keycloak_access_token.token.access_token
+	initial_login = false
+}
+
+data "keycloak_realm" "with_token" {
+	provider = keycloak.with_token
+	realm    = data.keycloak_realm.realm.realm
+}
+	`, testAccRealm.Realm, clientId, os.Getenv("KEYCLOAK_URL"))
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// NewProviderServer serves the SDK provider together with the framework provider, which holds the ephemeral resources
+// the plugin SDK doesn't support
+func NewProviderServer(ctx context.Context, sdkProvider *schema.Provider) (tfprotov5.ProviderServer, error) {
+	// the mux server configures the providers in order, the SDK provider has to come first as its client is shared
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(&frameworkProvider{sdkProvider: sdkProvider}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer(), nil
+}
+
+// frameworkProvider is the part of the provider built on the plugin framework. The configuration is handled by the SDK
+// provider, the keycloak client it creates is handed to the ephemeral resources.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var _ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "keycloak"
+}
+
+// Schema returns the schema of the SDK provider, muxed providers must have identical provider schemas
+func (p *frameworkProvider) Schema(ctx context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	sdkSchema, err := p.sdkProvider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("unable to read the schema of the provider", err.Error())
+		return
+	}
+
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{},
+	}
+	for _, attribute := range sdkSchema.Provider.Block.Attributes {
+		switch {
+		case attribute.Type.Is(tftypes.String):
+			resp.Schema.Attributes[attribute.Name] = fwschema.StringAttribute{
+				Required:    attribute.Required,
+				Optional:    attribute.Optional,
+				Sensitive:   attribute.Sensitive,
+				Description: attribute.Description,
+			}
+		case attribute.Type.Is(tftypes.Bool):
+			resp.Schema.Attributes[attribute.Name] = fwschema.BoolAttribute{
+				Required:    attribute.Required,
+				Optional:    attribute.Optional,
+				Sensitive:   attribute.Sensitive,
+				Description: attribute.Description,
+			}
+		case attribute.Type.Is(tftypes.Number):
+			resp.Schema.Attributes[attribute.Name] = fwschema.Int64Attribute{
+				Required:    attribute.Required,
+				Optional:    attribute.Optional,
+				Sensitive:   attribute.Sensitive,
+				Description: attribute.Description,
+			}
+		case attribute.Type.Is(tftypes.Map{ElementType: tftypes.String}):
+			resp.Schema.Attributes[attribute.Name] = fwschema.MapAttribute{
+				ElementType: types.StringType,
+				Required:    attribute.Required,
+				Optional:    attribute.Optional,
+				Sensitive:   attribute.Sensitive,
+				Description: attribute.Description,
+			}
+		default:
+			resp.Diagnostics.AddError("unsupported provider attribute", fmt.Sprintf("attribute %s of type %s can't be served by the framework provider", attribute.Name, attribute.Type))
+		}
+	}
+}
+
+func (p *frameworkProvider) Configure(_ context.Context, _ fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	if keycloakClient, ok := p.sdkProvider.Meta().(*keycloak.KeycloakClient); ok {
+		resp.EphemeralResourceData = keycloakClient
+	}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newKeycloakAccessTokenEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() fwresource.Resource {
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -324,3 +325,103 @@ func resourceKeycloakIdentityProviderUpdate(getIdentityProviderFromData identity
 		return diag.FromErr(setDataFromIdentityProvider(data, identityProvider, keycloakVersion))
 	}
 }
+
+// identityProviderImport describes attributes of an identity provider which can be imported from the metadata the
+// identity provider publishes, like an OpenID Connect discovery document or a SAML entity descriptor. The attributes
+// which aren't configured explicitly are planned from the metadata, so a change of the metadata shows up as a diff.
+type identityProviderImport struct {
+	// sources are the attributes the metadata is fetched with, it can't be planned while one of them is unknown
+	sources []string
+	// attributes are the string attributes imported from the metadata
+	attributes []string
+	// values returns the imported value of each attribute, they are all empty when there is no metadata to import
+	values func(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data identityProviderImportData) (map[string]string, error)
+}
+
+// identityProviderImportData is implemented by both schema.ResourceData and schema.ResourceDiff
+type identityProviderImportData interface {
+	Get(key string) interface{}
+}
+
+// apply makes the imported attributes computed, and imports them on plan as well as on create and update
+func (i *identityProviderImport) apply(identityProviderResource *schema.Resource) {
+	for _, attribute := range i.attributes {
+		identityProviderResource.Schema[attribute].Required = false
+		identityProviderResource.Schema[attribute].Optional = true
+		identityProviderResource.Schema[attribute].Computed = true
+		identityProviderResource.Schema[attribute].Default = nil
+	}
+
+	identityProviderResource.CreateContext = i.importBefore(identityProviderResource.CreateContext)
+	identityProviderResource.UpdateContext = i.importBefore(identityProviderResource.UpdateContext)
+	identityProviderResource.CustomizeDiff = i.customizeDiff
+}
+
+func (i *identityProviderImport) customizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+
+	for _, source := range i.sources {
+		if !d.NewValueKnown(source) {
+			return i.setNewComputed(d, rawConfig)
+		}
+	}
+
+	values, err := i.values(ctx, meta.(*keycloak.KeycloakClient), d)
+	if keycloak.ErrorIs404(err) {
+		// the realm may only be created during the same apply, the metadata is imported once it exists
+		return i.setNewComputed(d, rawConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, attribute := range i.attributes {
+		if isAttributeConfigured(rawConfig, attribute) || d.Get(attribute).(string) == values[attribute] {
+			continue
+		}
+		if err := d.SetNew(attribute, values[attribute]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setNewComputed plans the attributes which aren't configured as unknown, they are imported on create or update
+func (i *identityProviderImport) setNewComputed(d *schema.ResourceDiff, rawConfig cty.Value) error {
+	for _, attribute := range i.attributes {
+		if isAttributeConfigured(rawConfig, attribute) {
+			continue
+		}
+		if err := d.SetNewComputed(attribute); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importBefore imports the attributes before creating or updating the identity provider, for when they couldn't be
+// planned because the metadata wasn't known yet. Empty imported values are applied as well, so that an endpoint removed
+// from the metadata is removed from the identity provider.
+func (i *identityProviderImport) importBefore(apply func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		values, err := i.values(ctx, meta.(*keycloak.KeycloakClient), data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		rawConfig := data.GetRawConfig()
+		for _, attribute := range i.attributes {
+			if value, ok := values[attribute]; ok && !isAttributeConfigured(rawConfig, attribute) {
+				data.Set(attribute, value)
+			}
+		}
+
+		return apply(ctx, data, meta)
+	}
+}
+
+func isAttributeConfigured(rawConfig cty.Value, attribute string) bool {
+	return !rawConfig.IsNull() && rawConfig.IsKnown() && !rawConfig.GetAttr(attribute).IsNull()
+}
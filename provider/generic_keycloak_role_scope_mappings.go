@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// roleScopeMappings describes where the roles in scope are mapped: either a client, or a client scope. Only one of
+// clientId and clientScopeId is set, like for the role scope mapping functions of keycloak.KeycloakClient.
+type roleScopeMappings struct {
+	realmId       string
+	clientId      string
+	clientScopeId string
+}
+
+// resourceKeycloakRoleScopeMappings builds an authoritative resource of all the roles in scope of the client, or of the
+// client scope, identified by containerAttribute
+func resourceKeycloakRoleScopeMappings(containerAttribute, containerDescription string) *schema.Resource {
+	getRoleScopeMappings := func(data *schema.ResourceData) *roleScopeMappings {
+		mappings := &roleScopeMappings{realmId: data.Get("realm_id").(string)}
+		if containerAttribute == "client_id" {
+			mappings.clientId = data.Get(containerAttribute).(string)
+		} else {
+			mappings.clientScopeId = data.Get(containerAttribute).(string)
+		}
+
+		return mappings
+	}
+
+	reconcile := resourceKeycloakRoleScopeMappingsReconcile(getRoleScopeMappings)
+	read := resourceKeycloakRoleScopeMappingsRead(getRoleScopeMappings)
+
+	return &schema.Resource{
+		CreateContext: reconcile,
+		ReadContext:   read,
+		UpdateContext: reconcile,
+		DeleteContext: resourceKeycloakRoleScopeMappingsDelete(getRoleScopeMappings),
+		// This resource can be imported using {{realm}}/{{client_id}} or {{realm}}/{{client_scope_id}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRoleScopeMappingsImport(containerAttribute, read),
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			containerAttribute: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: fmt.Sprintf("The id of the %s the roles are in scope of.", containerDescription),
+			},
+			"role_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+				Description: fmt.Sprintf("The ids of all the realm and client roles in scope of the %s, any other role is removed from its scope.", containerDescription),
+			},
+		},
+	}
+}
+
+func (mappings *roleScopeMappings) id() string {
+	if mappings.clientId != "" {
+		return fmt.Sprintf("%s/%s", mappings.realmId, mappings.clientId)
+	}
+
+	return fmt.Sprintf("%s/%s", mappings.realmId, mappings.clientScopeId)
+}
+
+// add and remove batch the roles, with one request for the realm roles and one request for the roles of each client
+func (mappings *roleScopeMappings) add(ctx context.Context, keycloakClient *keycloak.KeycloakClient, clientRoles map[string][]*keycloak.Role, realmRoles []*keycloak.Role) error {
+	if len(realmRoles) != 0 {
+		err := keycloakClient.AddRealmRoleScopeMappings(ctx, mappings.realmId, mappings.clientId, mappings.clientScopeId, realmRoles)
+		if err != nil {
+			return err
+		}
+	}
+
+	for roleClientId, roles := range clientRoles {
+		if len(roles) != 0 {
+			err := keycloakClient.AddClientRoleScopeMappings(ctx, mappings.realmId, mappings.clientId, mappings.clientScopeId, roleClientId, roles)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (mappings *roleScopeMappings) remove(ctx context.Context, keycloakClient *keycloak.KeycloakClient, clientRoles map[string][]*keycloak.Role, realmRoles []*keycloak.Role) error {
+	if len(realmRoles) != 0 {
+		err := keycloakClient.RemoveRealmRoleScopeMappings(ctx, mappings.realmId, mappings.clientId, mappings.clientScopeId, realmRoles)
+		if err != nil {
+			return err
+		}
+	}
+
+	for roleClientId, roles := range clientRoles {
+		if len(roles) != 0 {
+			err := keycloakClient.RemoveClientRoleScopeMappings(ctx, mappings.realmId, mappings.clientId, mappings.clientScopeId, roleClientId, roles)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceKeycloakRoleScopeMappingsReconcile(getRoleScopeMappings func(*schema.ResourceData) *roleScopeMappings) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		mappings := getRoleScopeMappings(data)
+		roleIds := interfaceSliceToStringSlice(data.Get("role_ids").(*schema.Set).List())
+
+		tfRoles, err := getExtendedRoleMapping(ctx, keycloakClient, mappings.realmId, roleIds)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		roleMappings, err := keycloakClient.GetRoleScopeMappings(ctx, mappings.realmId, mappings.clientId, mappings.clientScopeId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		updates := calculateRoleMappingUpdates(tfRoles, intoRoleMapping(roleMappings))
+
+		if err = mappings.add(ctx, keycloakClient, updates.clientRolesToAdd, updates.realmRolesToAdd); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err = mappings.remove(ctx, keycloakClient, updates.clientRolesToRemove, updates.realmRolesToRemove); err != nil {
+			return diag.FromErr(err)
+		}
+
+		data.SetId(mappings.id())
+
+		return resourceKeycloakRoleScopeMappingsRead(getRoleScopeMappings)(ctx, data, meta)
+	}
+}
+
+func resourceKeycloakRoleScopeMappingsRead(getRoleScopeMappings func(*schema.ResourceData) *roleScopeMappings) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		mappings := getRoleScopeMappings(data)
+
+		// the scope mappings can't be found once the client or the client scope is gone
+		roleMappings, err := keycloakClient.GetRoleScopeMappings(ctx, mappings.realmId, mappings.clientId, mappings.clientScopeId)
+		if err != nil {
+			return handleNotFoundError(ctx, err, data)
+		}
+
+		var roleIds []string
+
+		for _, realmRole := range roleMappings.RealmMappings {
+			roleIds = append(roleIds, realmRole.Id)
+		}
+
+		for _, clientRoleMapping := range roleMappings.ClientMappings {
+			for _, clientRole := range clientRoleMapping.Mappings {
+				roleIds = append(roleIds, clientRole.Id)
+			}
+		}
+
+		data.Set("role_ids", roleIds)
+		data.SetId(mappings.id())
+
+		return nil
+	}
+}
+
+func resourceKeycloakRoleScopeMappingsDelete(getRoleScopeMappings func(*schema.ResourceData) *roleScopeMappings) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		mappings := getRoleScopeMappings(data)
+		roleIds := interfaceSliceToStringSlice(data.Get("role_ids").(*schema.Set).List())
+
+		rolesToRemove, err := getExtendedRoleMapping(ctx, keycloakClient, mappings.realmId, roleIds)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = mappings.remove(ctx, keycloakClient, rolesToRemove.clientRoles, rolesToRemove.realmRoles)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+}
+
+func resourceKeycloakRoleScopeMappingsImport(containerAttribute string, read func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{%s}}.", containerAttribute)
+		}
+
+		d.Set("realm_id", parts[0])
+		d.Set(containerAttribute, parts[1])
+
+		diagnostics := read(ctx, d, meta)
+		if diagnostics.HasError() {
+			return nil, errors.New(diagnostics[0].Summary)
+		}
+		if d.Id() == "" {
+			return nil, fmt.Errorf("could not find the role scope mappings of %s", parts[1])
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"fmt"
+
+	"dario.cat/mergo"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/types"
+)
+
+// socialIdentityProvider describes one of the social identity providers built into keycloak. Its own settings are
+// string attributes listed in config, which maps them to their key in the identity provider config, so that they don't
+// need a field in keycloak.IdentityProviderConfig.
+type socialIdentityProvider struct {
+	providerId  string
+	displayName string
+	// defaultScopes is the default value of default_scopes, the attribute is left out when empty
+	defaultScopes string
+	// withoutClientCredentials leaves out client_id and client_secret, for providers that don't need them
+	withoutClientCredentials bool
+
+	schema map[string]*schema.Schema
+	config map[string]string
+}
+
+func resourceKeycloakSocialIdentityProvider(socialProvider *socialIdentityProvider) *schema.Resource {
+	socialSchema := map[string]*schema.Schema{
+		"alias": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("The alias uniquely identifies an identity provider and it is also used to build the redirect uri. In case of %s this is computed and always %s", socialProvider.displayName, socialProvider.providerId),
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The human-friendly name of the identity provider, used in the log in form.",
+		},
+		"provider_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     socialProvider.providerId,
+			Description: fmt.Sprintf("provider id, is always %s, unless you have a extended custom implementation", socialProvider.providerId),
+		},
+		"hide_on_login_page": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Hide On Login Page.",
+		},
+	}
+
+	if !socialProvider.withoutClientCredentials {
+		socialSchema["client_id"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: fmt.Sprintf("The client identifier registered with %s.", socialProvider.displayName),
+		}
+		socialSchema["client_secret"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: fmt.Sprintf("The client secret registered with %s.", socialProvider.displayName),
+		}
+	}
+
+	if socialProvider.defaultScopes != "" {
+		socialSchema["default_scopes"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     socialProvider.defaultScopes,
+			Description: fmt.Sprintf("The scopes to be sent when asking for authorization. Defaults to '%s'", socialProvider.defaultScopes),
+		}
+	}
+
+	getter := getSocialIdentityProviderFromData(socialProvider)
+	setter := setSocialIdentityProviderData(socialProvider)
+
+	socialResource := resourceKeycloakIdentityProvider()
+	socialResource.Schema = mergeSchemas(mergeSchemas(socialResource.Schema, socialSchema), socialProvider.schema)
+	socialResource.CreateContext = resourceKeycloakIdentityProviderCreate(getter, setter)
+	socialResource.ReadContext = resourceKeycloakIdentityProviderRead(setter)
+	socialResource.UpdateContext = resourceKeycloakIdentityProviderUpdate(getter, setter)
+
+	return socialResource
+}
+
+func getSocialIdentityProviderFromData(socialProvider *socialIdentityProvider) identityProviderDataGetterFunc {
+	return func(data *schema.ResourceData, keycloakVersion *version.Version) (*keycloak.IdentityProvider, error) {
+		rec, defaultConfig := getIdentityProviderFromData(data, keycloakVersion)
+		rec.ProviderId = data.Get("provider_id").(string)
+
+		aliasRaw, ok := data.GetOk("alias")
+		if ok {
+			rec.Alias = aliasRaw.(string)
+		} else {
+			rec.Alias = socialProvider.providerId
+		}
+
+		socialIdentityProviderConfig := &keycloak.IdentityProviderConfig{
+			//since keycloak v26 moved to IdentityProvider - still here fore backward compatibility
+			HideOnLoginPage: types.KeycloakBoolQuoted(data.Get("hide_on_login_page").(bool)),
+		}
+
+		if !socialProvider.withoutClientCredentials {
+			socialIdentityProviderConfig.ClientId = data.Get("client_id").(string)
+			socialIdentityProviderConfig.ClientSecret = data.Get("client_secret").(string)
+		}
+
+		if socialProvider.defaultScopes != "" {
+			socialIdentityProviderConfig.DefaultScope = data.Get("default_scopes").(string)
+		}
+
+		if err := mergo.Merge(socialIdentityProviderConfig, defaultConfig); err != nil {
+			return nil, err
+		}
+
+		values := map[string]string{}
+		for attribute, configKey := range socialProvider.config {
+			values[configKey] = data.Get(attribute).(string)
+		}
+		socialIdentityProviderConfig.SetValues(values)
+
+		rec.Config = socialIdentityProviderConfig
+
+		return rec, nil
+	}
+}
+
+func setSocialIdentityProviderData(socialProvider *socialIdentityProvider) identityProviderDataSetterFunc {
+	return func(data *schema.ResourceData, identityProvider *keycloak.IdentityProvider, keycloakVersion *version.Version) error {
+		setIdentityProviderData(data, identityProvider, keycloakVersion)
+		data.Set("provider_id", identityProvider.ProviderId)
+
+		if !socialProvider.withoutClientCredentials {
+			data.Set("client_id", identityProvider.Config.ClientId)
+		}
+
+		if socialProvider.defaultScopes != "" {
+			data.Set("default_scopes", identityProvider.Config.DefaultScope)
+		}
+
+		values, err := identityProvider.Config.Values()
+		if err != nil {
+			return err
+		}
+
+		for attribute, configKey := range socialProvider.config {
+			data.Set(attribute, values[configKey])
+		}
+
+		if keycloakVersion.LessThan(keycloak.Version_26.AsVersion()) {
+			// Since keycloak v26 the attribute "hideOnLoginPage" is not part of the identity provider config anymore!
+			data.Set("hide_on_login_page", identityProvider.Config.HideOnLoginPage)
+		}
+
+		return nil
+	}
+}
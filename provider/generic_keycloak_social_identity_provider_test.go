@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestUnitKeycloakSocialIdentityProviders(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	testCases := []struct {
+		resourceType string
+		providerId   string
+		attributes   map[string]interface{}
+		config       map[string]string
+	}{
+		{
+			resourceType: "keycloak_oidc_microsoft_identity_provider",
+			providerId:   "microsoft",
+			attributes:   map[string]interface{}{"client_id": "id", "client_secret": "secret", "tenant_id": "my-tenant"},
+			config:       map[string]string{"clientId": "id", "tenantId": "my-tenant", "defaultScope": "openid profile email"},
+		},
+		{
+			resourceType: "keycloak_oidc_gitlab_identity_provider",
+			providerId:   "gitlab",
+			attributes:   map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+			config:       map[string]string{"clientId": "id", "defaultScope": "openid read_user"},
+		},
+		{
+			resourceType: "keycloak_oidc_apple_identity_provider",
+			providerId:   "apple",
+			attributes:   map[string]interface{}{"client_id": "id", "client_secret": "secret", "team_id": "my-team", "key_id": "my-key"},
+			config:       map[string]string{"clientId": "id", "teamId": "my-team", "keyId": "my-key"},
+		},
+		{
+			resourceType: "keycloak_oidc_linkedin_identity_provider",
+			providerId:   "linkedin-openid-connect",
+			attributes:   map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+			config:       map[string]string{"clientId": "id", "defaultScope": "openid profile email"},
+		},
+		{
+			resourceType: "keycloak_oidc_bitbucket_identity_provider",
+			providerId:   "bitbucket",
+			attributes:   map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+			config:       map[string]string{"clientId": "id", "defaultScope": "account email"},
+		},
+		{
+			resourceType: "keycloak_oidc_kubernetes_identity_provider",
+			providerId:   "kubernetes",
+			attributes:   map[string]interface{}{"issuer": "https://kubernetes.default.svc"},
+			config:       map[string]string{"issuer": "https://kubernetes.default.svc"},
+		},
+		{
+			resourceType: "keycloak_oidc_openshift_identity_provider",
+			providerId:   "openshift-v4",
+			attributes:   map[string]interface{}{"client_id": "id", "client_secret": "secret", "base_url": "https://api.openshift.example.com:6443"},
+			config:       map[string]string{"clientId": "id", "baseUrl": "https://api.openshift.example.com:6443", "defaultScope": "user:full"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.resourceType, func(t *testing.T) {
+			socialResource := testAccProvider.ResourcesMap[testCase.resourceType]
+
+			attributes := map[string]interface{}{
+				"realm":        testAccRealm.Realm,
+				"extra_config": map[string]interface{}{"dummyConfig": "dummy"},
+			}
+			for attribute, value := range testCase.attributes {
+				attributes[attribute] = value
+			}
+
+			data := schema.TestResourceDataRaw(t, socialResource.Schema, attributes)
+			if diags := socialResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+				t.Fatal(diags)
+			}
+			t.Cleanup(func() {
+				_ = keycloakClient.DeleteIdentityProvider(testCtx, testAccRealm.Realm, testCase.providerId)
+			})
+
+			if data.Id() != testCase.providerId {
+				t.Errorf("expected the alias to default to %s, got %s", testCase.providerId, data.Id())
+			}
+
+			identityProvider, err := keycloakClient.GetIdentityProvider(testCtx, testAccRealm.Realm, testCase.providerId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identityProvider.ProviderId != testCase.providerId {
+				t.Errorf("expected provider id %s, got %s", testCase.providerId, identityProvider.ProviderId)
+			}
+
+			config := testCase.config
+			config["dummyConfig"] = "dummy"
+			if err := checkIdentityProviderConfigValues(identityProvider, config); err != nil {
+				t.Error(err)
+			}
+
+			for attribute, value := range testCase.attributes {
+				if attribute != "client_secret" && data.Get(attribute) != value {
+					t.Errorf("expected %s to be read as %v, got %v", attribute, value, data.Get(attribute))
+				}
+			}
+			if len(data.Get("extra_config").(map[string]interface{})) != 1 {
+				t.Errorf("expected the provider settings to stay out of extra_config, got %v", data.Get("extra_config"))
+			}
+
+			if diags := socialResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+				t.Fatal(diags)
+			}
+		})
+	}
+}
+
+func checkIdentityProviderConfigValues(identityProvider *keycloak.IdentityProvider, expected map[string]string) error {
+	values, err := identityProvider.Config.Values()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range expected {
+		if values[key] != value {
+			return fmt.Errorf("expected config %s of identity provider %s to be %s, got %v", key, identityProvider.Alias, value, values[key])
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm"]
+		alias := rs.Primary.Attributes["alias"]
+
+		identityProvider, err := keycloakClient.GetIdentityProvider(testCtx, realm, alias)
+		if err != nil {
+			return fmt.Errorf("error getting identity provider with alias %s: %s", alias, err)
+		}
+
+		return checkIdentityProviderConfigValues(identityProvider, expected)
+	}
+}
+
+func testAccCheckKeycloakSocialIdentityProviderDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm"]
+
+			idp, _ := keycloakClient.GetIdentityProvider(testCtx, realm, id)
+			if idp != nil {
+				return fmt.Errorf("identity provider with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var (
+	keycloakLdapUserFederationSyncModes = map[string]string{
+		"FULL":          keycloak.UserStorageSyncActionFull,
+		"CHANGED_USERS": keycloak.UserStorageSyncActionChangedUsers,
+	}
+	keycloakLdapMapperSyncDirections = map[string]string{
+		"FED_TO_KEYCLOAK": keycloak.UserStorageMapperSyncFedToKeycloak,
+		"KEYCLOAK_TO_FED": keycloak.UserStorageMapperSyncKeycloakToFed,
+	}
+)
+
+func ldapSyncResultSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The result of the synchronization triggered by the last apply.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ignored": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"added": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"updated": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"removed": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"failed": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// ldapSyncOnApplyDiff marks the sync result as unknown when the apply is going to trigger a synchronization, which
+// happens when the resource is created or changed, and until the last synchronization succeeded
+func ldapSyncOnApplyDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("sync_on_apply").(string) == "" {
+		return nil
+	}
+
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 && ldapSyncSucceeded(d.Get("sync_result").([]interface{})) {
+		return nil
+	}
+
+	return d.SetNewComputed("sync_result")
+}
+
+// ldapSyncSucceeded tells whether the synchronization of the last apply went through, a failed request leaves no result
+func ldapSyncSucceeded(syncResult []interface{}) bool {
+	if len(syncResult) == 0 || syncResult[0] == nil {
+		return false
+	}
+
+	return syncResult[0].(map[string]interface{})["failed"].(int) == 0
+}
+
+func syncLdapUserFederationOnApply(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, realmId, id string) error {
+	mode := data.Get("sync_on_apply").(string)
+	if mode == "" {
+		return nil
+	}
+
+	result, err := keycloakClient.SyncUserStorage(ctx, realmId, id, keycloakLdapUserFederationSyncModes[mode])
+	if err != nil {
+		data.Set("sync_result", nil)
+		return fmt.Errorf("error synchronizing the users of ldap user federation %s: %s", id, err)
+	}
+
+	return setLdapSyncResultData(data, result)
+}
+
+func syncLdapMapperOnApply(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, realmId, ldapUserFederationId, id string) error {
+	direction := data.Get("sync_on_apply").(string)
+	if direction == "" {
+		return nil
+	}
+
+	result, err := keycloakClient.SyncUserStorageMapper(ctx, realmId, ldapUserFederationId, id, keycloakLdapMapperSyncDirections[direction])
+	if err != nil {
+		data.Set("sync_result", nil)
+		return fmt.Errorf("error synchronizing ldap mapper %s: %s", id, err)
+	}
+
+	return setLdapSyncResultData(data, result)
+}
+
+// setLdapSyncResultData stores the result of a synchronization, entries that failed to synchronize fail the apply and
+// are synchronized again by the next one
+func setLdapSyncResultData(data *schema.ResourceData, result *keycloak.UserStorageSyncResult) error {
+	data.Set("sync_result", []interface{}{
+		map[string]interface{}{
+			"ignored": result.Ignored,
+			"added":   result.Added,
+			"updated": result.Updated,
+			"removed": result.Removed,
+			"failed":  result.Failed,
+			"status":  result.Status,
+		},
+	})
+
+	if result.Failed > 0 {
+		return fmt.Errorf("synchronization failed for %d entries: %s", result.Failed, result.Status)
+	}
+
+	return nil
+}
+
+// ldapSyncOnCreateDiagnostics turns a failed synchronization into a warning. The component was already created, failing
+// the apply would taint the resource and replacing it deletes everything it imported. The next plan syncs it again.
+func ldapSyncOnCreateDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  err.Error(),
+			Detail:   "the synchronization will be triggered again by the next apply",
+		},
+	}
+}
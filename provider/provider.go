@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
@@ -18,12 +20,19 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_realm_client_registration_policy":   dataSourceKeycloakRealmClientRegistrationPolicy(),
 			"keycloak_openid_client":                      dataSourceKeycloakOpenidClient(),
 			"keycloak_openid_client_authorization_policy": dataSourceKeycloakOpenidClientAuthorizationPolicy(),
+			"keycloak_openid_client_certificate":          dataSourceKeycloakOpenidClientCertificate(),
 			"keycloak_openid_client_scope":                dataSourceKeycloakOpenidClientScope(),
 			"keycloak_openid_client_service_account_user": dataSourceKeycloakOpenidClientServiceAccountUser(),
 			"keycloak_realm":                              dataSourceKeycloakRealm(),
 			"keycloak_realm_keys":                         dataSourceKeycloakRealmKeys(),
+			"keycloak_realm_admin_permissions":            dataSourceKeycloakRealmAdminPermissions(),
+			"keycloak_realm_events":                       dataSourceKeycloakRealmEvents(),
+			"keycloak_realm_admin_events":                 dataSourceKeycloakRealmAdminEvents(),
 			"keycloak_role":                               dataSourceKeycloakRole(),
+			"keycloak_roles":                              dataSourceKeycloakRoles(),
 			"keycloak_user":                               dataSourceKeycloakUser(),
+			"keycloak_users":                              dataSourceKeycloakUsers(),
+			"keycloak_user_credentials":                   dataSourceKeycloakUserCredentials(),
 			"keycloak_user_realm_roles":                   dataSourceKeycloakUserRealmRoles(),
 			"keycloak_saml_client_installation_provider":  dataSourceKeycloakSamlClientInstallationProvider(),
 			"keycloak_saml_client":                        dataSourceKeycloakSamlClient(),
@@ -40,6 +49,7 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_realm_optional_client_scopes":                      resourceKeycloakRealmOptionalClientScopes(),
 			"keycloak_realm_client_policy_profile":                       resourceKeycloakRealmClientPolicyProfile(),
 			"keycloak_realm_client_policy_profile_policy":                resourceKeycloakRealmClientPolicyProfilePolicy(),
+			"keycloak_realm_client_policy":                               resourceKeycloakRealmClientPolicy(),
 			"keycloak_realm_client_registration_policy":                  resourceKeycloakRealmClientRegistrationPolicy(),
 			"keycloak_realm_keystore_aes_generated":                      resourceKeycloakRealmKeystoreAesGenerated(),
 			"keycloak_realm_keystore_ecdsa_generated":                    resourceKeycloakRealmKeystoreEcdsaGenerated(),
@@ -56,11 +66,15 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_default_roles":                                     resourceKeycloakDefaultRoles(),
 			"keycloak_group_roles":                                       resourceKeycloakGroupRoles(),
 			"keycloak_user":                                              resourceKeycloakUser(),
+			"keycloak_user_credential":                                   resourceKeycloakUserCredential(),
+			"keycloak_user_credentials_reset":                            resourceKeycloakUserCredentialsReset(),
 			"keycloak_user_roles":                                        resourceKeycloakUserRoles(),
 			"keycloak_openid_client":                                     resourceKeycloakOpenidClient(),
 			"keycloak_openid_client_scope":                               resourceKeycloakOpenidClientScope(),
 			"keycloak_ldap_user_federation":                              resourceKeycloakLdapUserFederation(),
+			"keycloak_kerberos_user_federation":                          resourceKeycloakKerberosUserFederation(),
 			"keycloak_ldap_user_attribute_mapper":                        resourceKeycloakLdapUserAttributeMapper(),
+			"keycloak_ldap_certificate_mapper":                           resourceKeycloakLdapCertificateMapper(),
 			"keycloak_hardcoded_attribute_mapper":                        resourceKeycloakHardcodedAttributeMapper(),
 			"keycloak_ldap_group_mapper":                                 resourceKeycloakLdapGroupMapper(),
 			"keycloak_ldap_role_mapper":                                  resourceKeycloakLdapRoleMapper(),
@@ -88,16 +102,26 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_openid_client_default_scopes":                      resourceKeycloakOpenidClientDefaultScopes(),
 			"keycloak_openid_client_optional_scopes":                     resourceKeycloakOpenidClientOptionalScopes(),
 			"keycloak_organization":                                      resourceKeycloakOrganization(),
+			"keycloak_organization_member":                               resourceKeycloakOrganizationMember(),
+			"keycloak_organization_members":                              resourceKeycloakOrganizationMembers(),
 			"keycloak_saml_client":                                       resourceKeycloakSamlClient(),
 			"keycloak_saml_client_scope":                                 resourceKeycloakSamlClientScope(),
 			"keycloak_saml_client_default_scopes":                        resourceKeycloakSamlClientDefaultScopes(),
+			"keycloak_saml_client_optional_scopes":                       resourceKeycloakSamlClientOptionalScopes(),
 			"keycloak_generic_client_protocol_mapper":                    resourceKeycloakGenericClientProtocolMapper(),
 			"keycloak_generic_client_role_mapper":                        resourceKeycloakGenericClientRoleMapper(),
 			"keycloak_generic_protocol_mapper":                           resourceKeycloakGenericProtocolMapper(),
 			"keycloak_generic_role_mapper":                               resourceKeycloakGenericRoleMapper(),
+			"keycloak_openid_client_role_scope_mappings":                 resourceKeycloakOpenidClientRoleScopeMappings(),
+			"keycloak_client_scope_role_mappings":                        resourceKeycloakClientScopeRoleMappings(),
 			"keycloak_saml_user_attribute_protocol_mapper":               resourceKeycloakSamlUserAttributeProtocolMapper(),
 			"keycloak_saml_user_property_protocol_mapper":                resourceKeycloakSamlUserPropertyProtocolMapper(),
 			"keycloak_saml_script_protocol_mapper":                       resourceKeycloakSamlScriptProtocolMapper(),
+			"keycloak_saml_role_list_protocol_mapper":                    resourceKeycloakSamlRoleListProtocolMapper(),
+			"keycloak_saml_group_membership_protocol_mapper":             resourceKeycloakSamlGroupMembershipProtocolMapper(),
+			"keycloak_saml_hardcoded_attribute_protocol_mapper":          resourceKeycloakSamlHardcodedAttributeProtocolMapper(),
+			"keycloak_saml_hardcoded_role_protocol_mapper":               resourceKeycloakSamlHardcodedRoleProtocolMapper(),
+			"keycloak_saml_role_name_protocol_mapper":                    resourceKeycloakSamlRoleNameProtocolMapper(),
 			"keycloak_hardcoded_attribute_identity_provider_mapper":      resourceKeycloakHardcodedAttributeIdentityProviderMapper(),
 			"keycloak_hardcoded_group_identity_provider_mapper":          resourceKeycloakHardcodedGroupIdentityProviderMapper(),
 			"keycloak_hardcoded_role_identity_provider_mapper":           resourceKeycloakHardcodedRoleIdentityProviderMapper(),
@@ -109,7 +133,15 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_oidc_google_identity_provider":                     resourceKeycloakOidcGoogleIdentityProvider(),
 			"keycloak_oidc_facebook_identity_provider":                   resourceKeycloakOidcFacebookIdentityProvider(),
 			"keycloak_oidc_github_identity_provider":                     resourceKeycloakOidcGithubIdentityProvider(),
+			"keycloak_oidc_microsoft_identity_provider":                  resourceKeycloakOidcMicrosoftIdentityProvider(),
+			"keycloak_oidc_gitlab_identity_provider":                     resourceKeycloakOidcGitlabIdentityProvider(),
+			"keycloak_oidc_apple_identity_provider":                      resourceKeycloakOidcAppleIdentityProvider(),
+			"keycloak_oidc_linkedin_identity_provider":                   resourceKeycloakOidcLinkedinIdentityProvider(),
+			"keycloak_oidc_bitbucket_identity_provider":                  resourceKeycloakOidcBitbucketIdentityProvider(),
+			"keycloak_oidc_kubernetes_identity_provider":                 resourceKeycloakOidcKubernetesIdentityProvider(),
+			"keycloak_oidc_openshift_identity_provider":                  resourceKeycloakOidcOpenshiftIdentityProvider(),
 			"keycloak_oidc_identity_provider":                            resourceKeycloakOidcIdentityProvider(),
+			"keycloak_oidc_keycloak_identity_provider":                   resourceKeycloakOidcKeycloakIdentityProvider(),
 			"keycloak_openid_client_authorization_resource":              resourceKeycloakOpenidClientAuthorizationResource(),
 			"keycloak_openid_client_group_policy":                        resourceKeycloakOpenidClientAuthorizationGroupPolicy(),
 			"keycloak_openid_client_role_policy":                         resourceKeycloakOpenidClientAuthorizationRolePolicy(),
@@ -124,7 +156,9 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_openid_client_service_account_role":                resourceKeycloakOpenidClientServiceAccountRole(),
 			"keycloak_openid_client_service_account_realm_role":          resourceKeycloakOpenidClientServiceAccountRealmRole(),
 			"keycloak_role":                                              resourceKeycloakRole(),
+			"keycloak_role_composites":                                   resourceKeycloakRoleComposites(),
 			"keycloak_authentication_flow":                               resourceKeycloakAuthenticationFlow(),
+			"keycloak_authentication_flow_tree":                          resourceKeycloakAuthenticationFlowTree(),
 			"keycloak_authentication_subflow":                            resourceKeycloakAuthenticationSubFlow(),
 			"keycloak_authentication_execution":                          resourceKeycloakAuthenticationExecution(),
 			"keycloak_authentication_execution_config":                   resourceKeycloakAuthenticationExecutionConfig(),
@@ -133,6 +167,9 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_users_permissions":                                 resourceKeycloakUsersPermissions(),
 			"keycloak_user_groups":                                       resourceKeycloakUserGroups(),
 			"keycloak_group_permissions":                                 resourceKeycloakGroupPermissions(),
+			"keycloak_role_permissions":                                  resourceKeycloakRolePermissions(),
+			"keycloak_identity_provider_permissions":                     resourceKeycloakIdentityProviderPermissions(),
+			"keycloak_admin_permission":                                  resourceKeycloakAdminPermission(),
 			"keycloak_authentication_bindings":                           resourceKeycloakAuthenticationBindings(),
 		},
 		Schema: map[string]*schema.Schema{
@@ -245,6 +282,33 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 					Type: schema.TypeString,
 				},
 			},
+			"max_retries": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of times a request to Keycloak is retried when it fails with a 429, a 5xx or a connection error",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MAX_RETRIES", 5),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Minimum time (in seconds) to wait before retrying a failed request",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_WAIT_MIN", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum time (in seconds) to wait before retrying a failed request",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_WAIT_MAX", 60),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"honor_retry_after": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "When true, the Retry-After header sent by Keycloak on 429 and 503 responses is used as the wait time before retrying",
+				Default:     true,
+			},
 			"keycloak_version": {
 				Optional:    true,
 				Type:        schema.TypeString,
@@ -282,12 +346,22 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			additionalHeaders[k] = v.(string)
 		}
 		keycloakVersion := data.Get("keycloak_version").(string)
+		retryConfig := keycloak.RetryConfig{
+			MaxRetries:      data.Get("max_retries").(int),
+			WaitMin:         time.Second * time.Duration(data.Get("retry_wait_min").(int)),
+			WaitMax:         time.Second * time.Duration(data.Get("retry_wait_max").(int)),
+			HonorRetryAfter: data.Get("honor_retry_after").(bool),
+		}
 
 		var diags diag.Diagnostics
 
+		if retryConfig.WaitMin > retryConfig.WaitMax {
+			return nil, diag.Errorf("retry_wait_min (%d) must be less than or equal to retry_wait_max (%d)", data.Get("retry_wait_min").(int), data.Get("retry_wait_max").(int))
+		}
+
 		userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", provider.TerraformVersion, meta.SDKVersionString())
 
-		keycloakClient, err := keycloak.NewKeycloakClient(ctx, url, basePath, adminUrl, clientId, clientSecret, realm, username, password, accessToken, jwtSigningAlg, jwtSigningKey, initialLogin, clientTimeout, rootCaCertificate, tlsInsecureSkipVerify, tlsClientCertificate, tlsClientPrivateKey, userAgent, redHatSSO, additionalHeaders, keycloakVersion)
+		keycloakClient, err := keycloak.NewKeycloakClient(ctx, url, basePath, adminUrl, clientId, clientSecret, realm, username, password, accessToken, jwtSigningAlg, jwtSigningKey, initialLogin, clientTimeout, rootCaCertificate, tlsInsecureSkipVerify, tlsClientCertificate, tlsClientPrivateKey, userAgent, redHatSSO, additionalHeaders, keycloakVersion, retryConfig)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -8,14 +8,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/keycloak/terraform-provider-keycloak/helper"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
 )
 
 var testAccProviderFactories map[string]func() (*schema.Provider, error)
+var testAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
 var testAccProvider *schema.Provider
 var keycloakClient *keycloak.KeycloakClient
 var testAccRealm *keycloak.Realm
@@ -24,6 +28,9 @@ var testAccRealmUserFederation *keycloak.Realm
 var testAccRealmOrganization *keycloak.Realm
 var testCtx context.Context
 
+// testAccServer is only set when running without TF_ACC
+var testAccServer *keycloaktest.Server
+
 func init() {
 	testCtx = context.Background()
 	userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", schema.Provider{}.TerraformVersion, meta.SDKVersionString())
@@ -33,10 +40,17 @@ func init() {
 
 	helper.UpdateEnvFromTestEnvIfPresent()
 
-	initialLogin := os.Getenv("KEYCLOAK_ACCESS_TOKEN") == ""
-	keycloakClient, err = keycloak.NewKeycloakClient(testCtx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), "", "", os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", initialLogin, 120, os.Getenv("KEYCLOAK_TLS_CA_CERT"), false, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), userAgent, false, map[string]string{
-		"foo": "bar",
-	}, os.Getenv("KEYCLOAK_VERSION"))
+	// without TF_ACC the acceptance tests are skipped, so there's no need for a real Keycloak: the fake is enough for
+	// TestMain and the unit tests
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		testAccServer = keycloaktest.NewServer()
+		keycloakClient, err = testAccServer.NewClient(testCtx)
+	} else {
+		initialLogin := os.Getenv("KEYCLOAK_ACCESS_TOKEN") == ""
+		keycloakClient, err = keycloak.NewKeycloakClient(testCtx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_ADMIN_URL"), os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), "", "", os.Getenv("KEYCLOAK_ACCESS_TOKEN"), "", "", initialLogin, 120, os.Getenv("KEYCLOAK_TLS_CA_CERT"), false, os.Getenv("KEYCLOAK_TLS_CLIENT_CERT"), os.Getenv("KEYCLOAK_TLS_CLIENT_KEY"), userAgent, false, map[string]string{
+			"foo": "bar",
+		}, os.Getenv("KEYCLOAK_VERSION"), keycloak.DefaultRetryConfig())
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -46,6 +60,11 @@ func init() {
 			return testAccProvider, nil
 		},
 	}
+	testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"keycloak": func() (tfprotov5.ProviderServer, error) {
+			return NewProviderServer(testCtx, testAccProvider)
+		},
+	}
 }
 
 func TestMain(m *testing.M) {
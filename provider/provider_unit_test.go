@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// unit tests run against the fake Keycloak from the keycloaktest package, so they only need a terraform binary
+
+// testUnitFakeOnly skips the test when it doesn't run against the fake Keycloak, it is enough for the tests calling the
+// resource functions directly
+func testUnitFakeOnly(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("unit tests only run against the fake Keycloak, unset TF_ACC to run them")
+	}
+}
+
+func testUnitPreCheck(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform binary not found, set TF_ACC_TERRAFORM_PATH to run unit tests")
+		}
+	}
+}
+
+func TestUnitKeycloakGroup_basic(t *testing.T) {
+	testUnitPreCheck(t)
+
+	groupName := acctest.RandomWithPrefix("tf-unit")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKeycloakGroupDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakGroup_basic(groupName, "foo", "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakGroupExists("keycloak_group.group"),
+					resource.TestCheckResourceAttr("keycloak_group.group", "path", "/"+groupName),
+					resource.TestCheckResourceAttr("keycloak_group.group", "attributes.foo", "bar"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_group.group",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+// TestUnitKeycloakGroup_crud drives the resource functions directly, so it also runs without a terraform binary
+func TestUnitKeycloakGroup_crud(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	groupResource := testAccProvider.ResourcesMap["keycloak_group"]
+	data := schema.TestResourceDataRaw(t, groupResource.Schema, map[string]interface{}{
+		"realm_id": testAccRealm.Realm,
+		"name":     acctest.RandomWithPrefix("tf-unit"),
+		"attributes": map[string]interface{}{
+			"foo": "bar",
+		},
+	})
+
+	if diags := groupResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatalf("unable to create group: %v", diags)
+	}
+
+	group, err := keycloakClient.GetGroup(testCtx, testAccRealm.Realm, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Path != "/"+data.Get("name").(string) || group.Attributes["foo"][0] != "bar" {
+		t.Errorf("unexpected group %+v", group)
+	}
+
+	if diags := groupResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatalf("unable to delete group: %v", diags)
+	}
+
+	if diags := groupResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatalf("unable to read group: %v", diags)
+	}
+	if data.Id() != "" {
+		t.Error("expected group to be removed from state once deleted")
+	}
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakAdminPermission() *schema.Resource {
+	var resourceTypes []string
+	for resourceType := range keycloak.AdminPermissionsResourceTypes {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	return &schema.Resource{
+		CreateContext: resourceKeycloakAdminPermissionCreate,
+		ReadContext:   resourceKeycloakAdminPermissionRead,
+		DeleteContext: resourceKeycloakAdminPermissionDelete,
+		UpdateContext: resourceKeycloakAdminPermissionUpdate,
+		// This resource can be imported using {{realmId}}/{{permissionId}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakAdminPermissionImport,
+		},
+		CustomizeDiff: resourceKeycloakAdminPermissionDiff,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"resource_server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource server id representing the admin-permissions client on which this permission is managed",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"decision_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakOpenidClientResourcePermissionDecisionStrategies, false),
+				Default:      "UNANIMOUS",
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(resourceTypes, false),
+			},
+			"resources": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Ids of the users, groups, clients or roles this permission applies to. When empty, the permission applies to all resources of the given type.",
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+			"policies": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceKeycloakAdminPermissionDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	resourceType := diff.Get("resource_type").(string)
+
+	supportedScopes, ok := keycloak.AdminPermissionsResourceTypes[resourceType]
+	if !ok {
+		return nil
+	}
+
+	for _, scope := range diff.Get("scopes").(*schema.Set).List() {
+		if !stringSliceContains(supportedScopes, scope.(string)) {
+			return fmt.Errorf("scope %q is not supported for resource type %s, supported scopes are: %s", scope, resourceType, strings.Join(supportedScopes, ", "))
+		}
+	}
+
+	return nil
+}
+
+func getAdminPermissionFromData(data *schema.ResourceData) *keycloak.AdminPermission {
+	resources := make([]string, 0)
+	scopes := make([]string, 0)
+	policies := make([]string, 0)
+
+	for _, resource := range data.Get("resources").(*schema.Set).List() {
+		resources = append(resources, resource.(string))
+	}
+	for _, scope := range data.Get("scopes").(*schema.Set).List() {
+		scopes = append(scopes, scope.(string))
+	}
+	for _, policy := range data.Get("policies").(*schema.Set).List() {
+		policies = append(policies, policy.(string))
+	}
+
+	return &keycloak.AdminPermission{
+		Id:               data.Id(),
+		RealmId:          data.Get("realm_id").(string),
+		ResourceServerId: data.Get("resource_server_id").(string),
+		Name:             data.Get("name").(string),
+		Description:      data.Get("description").(string),
+		DecisionStrategy: data.Get("decision_strategy").(string),
+		ResourceType:     data.Get("resource_type").(string),
+		Resources:        resources,
+		Scopes:           scopes,
+		Policies:         policies,
+	}
+}
+
+func setAdminPermissionData(data *schema.ResourceData, permission *keycloak.AdminPermission) {
+	data.SetId(permission.Id)
+	data.Set("realm_id", permission.RealmId)
+	data.Set("resource_server_id", permission.ResourceServerId)
+	data.Set("name", permission.Name)
+	data.Set("description", permission.Description)
+	data.Set("decision_strategy", permission.DecisionStrategy)
+	data.Set("resource_type", permission.ResourceType)
+	data.Set("resources", permission.Resources)
+	data.Set("scopes", permission.Scopes)
+	data.Set("policies", permission.Policies)
+}
+
+func checkAdminPermissionsV2Supported(ctx context.Context, keycloakClient *keycloak.KeycloakClient) diag.Diagnostics {
+	if ok, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_26_2); !ok && err == nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "fine-grained admin permissions v2 require Keycloak v26.2 or higher",
+		}}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakAdminPermissionCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	if diags := checkAdminPermissionsV2Supported(ctx, keycloakClient); diags.HasError() {
+		return diags
+	}
+
+	permission := getAdminPermissionFromData(data)
+
+	adminPermissionsClient, err := keycloakClient.GetAdminPermissionsClient(ctx, permission.RealmId)
+	if err != nil {
+		return diag.Errorf("unable to find the %s client, make sure admin permissions are enabled for realm %s: %s", keycloak.AdminPermissionsClientId, permission.RealmId, err)
+	}
+	permission.ResourceServerId = adminPermissionsClient.Id
+
+	err = keycloakClient.NewAdminPermission(ctx, permission)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(permission.Id)
+	data.Set("resource_server_id", permission.ResourceServerId)
+
+	return resourceKeycloakAdminPermissionRead(ctx, data, meta)
+}
+
+func resourceKeycloakAdminPermissionRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+
+	permission, err := keycloakClient.GetAdminPermission(ctx, realmId, resourceServerId, data.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setAdminPermissionData(data, permission)
+
+	return nil
+}
+
+func resourceKeycloakAdminPermissionUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	permission := getAdminPermissionFromData(data)
+
+	err := keycloakClient.UpdateAdminPermission(ctx, permission)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakAdminPermissionRead(ctx, data, meta)
+}
+
+func resourceKeycloakAdminPermissionDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+
+	return diag.FromErr(keycloakClient.DeleteAdminPermission(ctx, realmId, resourceServerId, data.Id()))
+}
+
+func resourceKeycloakAdminPermissionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{permissionId}}")
+	}
+
+	adminPermissionsClient, err := keycloakClient.GetAdminPermissionsClient(ctx, parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("resource_server_id", adminPermissionsClient.Id)
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakAdminPermission_basic(t *testing.T) {
+	skipIfVersionIsLessThan(testCtx, t, keycloakClient, keycloak.Version_26_2)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakAdminPermissionDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakAdminPermission_basic(realmName, username, `"view"`, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("keycloak_admin_permission.permission", "resource_server_id", "data.keycloak_realm_admin_permissions.admin_permissions", "resource_server_id"),
+					testAccCheckKeycloakAdminPermissionExists("keycloak_admin_permission.permission", []string{"view"}, 0),
+				),
+			},
+			{
+				Config: testKeycloakAdminPermission_basic(realmName, username, `"view", "manage"`, "keycloak_user.user.id"),
+				Check:  testAccCheckKeycloakAdminPermissionExists("keycloak_admin_permission.permission", []string{"view", "manage"}, 1),
+			},
+			{
+				ResourceName:      "keycloak_admin_permission.permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					rs := state.RootModule().Resources["keycloak_admin_permission.permission"]
+
+					return fmt.Sprintf("%s/%s", realmName, rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakAdminPermission_invalidScope(t *testing.T) {
+	skipIfVersionIsLessThan(testCtx, t, keycloakClient, keycloak.Version_26_2)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakAdminPermission_basic(realmName, username, `"map-role-composite"`, ""),
+				ExpectError: regexp.MustCompile(`scope "map-role-composite" is not supported for resource type Users`),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakAdminPermissionExists(resourceName string, scopes []string, resourceCount int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+		resourceServerId := rs.Primary.Attributes["resource_server_id"]
+
+		permission, err := keycloakClient.GetAdminPermission(testCtx, realmId, resourceServerId, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if permission.ResourceType != "Users" {
+			return fmt.Errorf("expected permission to have resource type Users, got %s", permission.ResourceType)
+		}
+
+		if len(permission.Scopes) != len(scopes) {
+			return fmt.Errorf("expected permission to have scopes %v, got %v", scopes, permission.Scopes)
+		}
+		for _, scope := range scopes {
+			if !stringSliceContains(permission.Scopes, scope) {
+				return fmt.Errorf("expected permission to have scopes %v, got %v", scopes, permission.Scopes)
+			}
+		}
+
+		if len(permission.Resources) != resourceCount {
+			return fmt.Errorf("expected permission to have %d resources, got %v", resourceCount, permission.Resources)
+		}
+
+		if len(permission.Policies) != 1 {
+			return fmt.Errorf("expected permission to have a single policy, got %v", permission.Policies)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakAdminPermissionDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_admin_permission" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			resourceServerId := rs.Primary.Attributes["resource_server_id"]
+
+			permission, _ := keycloakClient.GetAdminPermission(testCtx, realmId, resourceServerId, rs.Primary.ID)
+			if permission != nil {
+				return fmt.Errorf("admin permission %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakAdminPermission_basic(realmName, username, scopes, resources string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm                     = "%s"
+	admin_permissions_enabled = true
+}
+
+data "keycloak_realm_admin_permissions" "admin_permissions" {
+	realm_id = keycloak_realm.realm.id
+}
+
+resource "keycloak_user" "user" {
+	realm_id = keycloak_realm.realm.id
+	username = "%s"
+}
+
+resource "keycloak_openid_client_user_policy" "policy" {
+	realm_id           = keycloak_realm.realm.id
+	resource_server_id = data.keycloak_realm_admin_permissions.admin_permissions.resource_server_id
+	name               = "user-policy"
+	users              = [keycloak_user.user.id]
+	logic              = "POSITIVE"
+	decision_strategy  = "UNANIMOUS"
+}
+
+resource "keycloak_admin_permission" "permission" {
+	realm_id      = keycloak_realm.realm.id
+	name          = "user-permission"
+	resource_type = "Users"
+	resources     = [%s]
+	scopes        = [%s]
+	policies      = [keycloak_openid_client_user_policy.policy.id]
+}
+	`, realmName, username, resources, scopes)
+}
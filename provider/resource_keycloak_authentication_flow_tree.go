@@ -0,0 +1,733 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// the schema can't be recursive, subflows can be nested this many times, which is enough for every built-in flow
+const authenticationFlowTreeMaxDepth = 6
+
+var (
+	keycloakAuthenticationExecutionRequirements = []string{"REQUIRED", "ALTERNATIVE", "CONDITIONAL", "DISABLED"}
+)
+
+func resourceKeycloakAuthenticationFlowTree() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakAuthenticationFlowTreeCreate,
+		ReadContext:   resourceKeycloakAuthenticationFlowTreeRead,
+		DeleteContext: resourceKeycloakAuthenticationFlowTreeDelete,
+		UpdateContext: resourceKeycloakAuthenticationFlowTreeUpdate,
+		// This resource can be imported using {{realmId}}/{{authenticationFlowId}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakAuthenticationFlowTreeImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"provider_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "basic-flow",
+				ValidateFunc: validation.StringInSlice([]string{"basic-flow", "client-flow"}, false),
+			},
+			"copy_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The alias of a flow to copy when creating this flow. The copied executions are then converged to the configured ones.",
+			},
+			"execution": authenticationFlowTreeExecutionSchema(0),
+		},
+	}
+}
+
+func authenticationFlowTreeExecutionSchema(depth int) *schema.Schema {
+	executionSchema := map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"authenticator": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The authenticator of the execution. Required unless this execution is a subflow.",
+		},
+		"requirement": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "DISABLED",
+			ValidateFunc: validation.StringInSlice(keycloakAuthenticationExecutionRequirements, false),
+		},
+		"config": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"alias": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"config": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	}
+
+	if depth < authenticationFlowTreeMaxDepth {
+		executionSchema["subflow"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"alias": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"provider_id": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "basic-flow",
+						ValidateFunc: validation.StringInSlice([]string{"basic-flow", "form-flow", "client-flow"}, false),
+					},
+					"execution": authenticationFlowTreeExecutionSchema(depth + 1),
+				},
+			},
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The executions of the flow, in order.",
+		Elem: &schema.Resource{
+			Schema: executionSchema,
+		},
+	}
+}
+
+// authenticationFlowTreeExecution is an execution of the flow described by the configuration
+type authenticationFlowTreeExecution struct {
+	Authenticator string
+	Requirement   string
+	Config        *keycloak.AuthenticationExecutionConfig
+	SubFlow       *authenticationFlowTreeSubFlow
+}
+
+type authenticationFlowTreeSubFlow struct {
+	Alias       string
+	Description string
+	ProviderId  string
+	Executions  []*authenticationFlowTreeExecution
+}
+
+// key identifies an execution within its parent flow. Executions of the same authenticator are matched in order,
+// subflows by alias. Changing the type of a subflow or its authenticator recreates it.
+func (execution *authenticationFlowTreeExecution) key() string {
+	if execution.SubFlow != nil {
+		return fmt.Sprintf("subflow/%s/%s/%s", execution.SubFlow.Alias, execution.SubFlow.ProviderId, execution.Authenticator)
+	}
+
+	return "execution/" + execution.Authenticator
+}
+
+func getAuthenticationFlowTreeExecutionsFromData(executions []interface{}) ([]*authenticationFlowTreeExecution, error) {
+	var result []*authenticationFlowTreeExecution
+
+	for _, e := range executions {
+		data := e.(map[string]interface{})
+		execution := &authenticationFlowTreeExecution{
+			Authenticator: data["authenticator"].(string),
+			Requirement:   data["requirement"].(string),
+		}
+
+		if configs := data["config"].([]interface{}); len(configs) == 1 {
+			configData := configs[0].(map[string]interface{})
+			config := map[string]string{}
+			for key, value := range configData["config"].(map[string]interface{}) {
+				config[key] = value.(string)
+			}
+			execution.Config = &keycloak.AuthenticationExecutionConfig{
+				Alias:  configData["alias"].(string),
+				Config: config,
+			}
+		}
+
+		if subFlows, ok := data["subflow"].([]interface{}); ok && len(subFlows) == 1 {
+			subFlowData := subFlows[0].(map[string]interface{})
+			subFlowExecutions, err := getAuthenticationFlowTreeExecutionsFromData(subFlowData["execution"].([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+
+			execution.SubFlow = &authenticationFlowTreeSubFlow{
+				Alias:       subFlowData["alias"].(string),
+				Description: subFlowData["description"].(string),
+				ProviderId:  subFlowData["provider_id"].(string),
+				Executions:  subFlowExecutions,
+			}
+
+			if execution.Authenticator != "" && execution.SubFlow.ProviderId != "form-flow" {
+				return nil, fmt.Errorf("subflow %s: authenticator can only be set on subflows of type form-flow", execution.SubFlow.Alias)
+			}
+		} else if execution.Authenticator == "" {
+			return nil, errors.New("every execution needs either an authenticator or a subflow")
+		}
+
+		result = append(result, execution)
+	}
+
+	return result, nil
+}
+
+// currentAuthenticationExecution is an execution of a flow as it exists in Keycloak
+type currentAuthenticationExecution struct {
+	info    *keycloak.AuthenticationExecutionInfo
+	subFlow *keycloak.AuthenticationFlow
+	// authenticator of a subflow, only set for form flows
+	authenticator string
+}
+
+func (execution *currentAuthenticationExecution) key() string {
+	if execution.subFlow != nil {
+		return fmt.Sprintf("subflow/%s/%s/%s", execution.subFlow.Alias, execution.subFlow.ProviderId, execution.authenticator)
+	}
+
+	return "execution/" + execution.info.ProviderId
+}
+
+// listDirectAuthenticationExecutions returns the executions directly under the flow, in order
+func listDirectAuthenticationExecutions(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, flowAlias string) ([]*currentAuthenticationExecution, error) {
+	infos, err := keycloakClient.ListAuthenticationExecutions(ctx, realmId, flowAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	var direct keycloak.AuthenticationExecutionList
+	for _, info := range infos {
+		if info.Level == 0 {
+			direct = append(direct, info)
+		}
+	}
+	sort.Sort(direct)
+
+	var executions []*currentAuthenticationExecution
+	for _, info := range direct {
+		execution := &currentAuthenticationExecution{info: info}
+
+		if info.AuthenticationFlow {
+			execution.subFlow, err = keycloakClient.GetAuthenticationFlow(ctx, realmId, info.FlowId)
+			if err != nil {
+				return nil, err
+			}
+
+			if execution.subFlow.ProviderId == "form-flow" {
+				authenticationExecution, err := keycloakClient.GetAuthenticationExecution(ctx, realmId, flowAlias, info.Id)
+				if err != nil {
+					return nil, err
+				}
+				execution.authenticator = authenticationExecution.Authenticator
+			}
+		}
+
+		executions = append(executions, execution)
+	}
+
+	return executions, nil
+}
+
+// matchAuthenticationExecutions pairs each desired execution with an existing one, the unmatched existing executions
+// are returned separately
+func matchAuthenticationExecutions(desired []*authenticationFlowTreeExecution, current []*currentAuthenticationExecution) ([]*currentAuthenticationExecution, []*currentAuthenticationExecution) {
+	matched := make([]*currentAuthenticationExecution, len(desired))
+	used := make(map[*currentAuthenticationExecution]bool)
+
+	for i, execution := range desired {
+		for _, candidate := range current {
+			if !used[candidate] && candidate.key() == execution.key() {
+				matched[i] = candidate
+				used[candidate] = true
+				break
+			}
+		}
+	}
+
+	var unmatched []*currentAuthenticationExecution
+	for _, candidate := range current {
+		if !used[candidate] {
+			unmatched = append(unmatched, candidate)
+		}
+	}
+
+	return matched, unmatched
+}
+
+// convergeAuthenticationFlow applies the minimal changes needed for the executions of the flow to match the desired
+// ones: unknown executions are deleted, missing ones created, the others updated in place and moved if needed
+func convergeAuthenticationFlow(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, flowAlias string, desired []*authenticationFlowTreeExecution) error {
+	current, err := listDirectAuthenticationExecutions(ctx, keycloakClient, realmId, flowAlias)
+	if err != nil {
+		return err
+	}
+
+	matched, unmatched := matchAuthenticationExecutions(desired, current)
+
+	for _, execution := range unmatched {
+		tflog.Debug(ctx, "Deleting authentication execution", map[string]interface{}{
+			"flow":      flowAlias,
+			"execution": execution.key(),
+		})
+
+		err = keycloakClient.DeleteAuthenticationExecution(ctx, realmId, execution.info.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	created := false
+	for i, execution := range desired {
+		if matched[i] != nil {
+			continue
+		}
+		created = true
+
+		if execution.SubFlow != nil {
+			err = keycloakClient.NewAuthenticationSubFlow(ctx, &keycloak.AuthenticationSubFlow{
+				RealmId:         realmId,
+				ParentFlowAlias: flowAlias,
+				Alias:           execution.SubFlow.Alias,
+				Description:     execution.SubFlow.Description,
+				ProviderId:      execution.SubFlow.ProviderId,
+				Authenticator:   execution.Authenticator,
+				Requirement:     execution.Requirement,
+			})
+		} else {
+			err = keycloakClient.NewAuthenticationExecution(ctx, &keycloak.AuthenticationExecution{
+				RealmId:         realmId,
+				ParentFlowAlias: flowAlias,
+				Authenticator:   execution.Authenticator,
+				Requirement:     execution.Requirement,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if created {
+		current, err = listDirectAuthenticationExecutions(ctx, keycloakClient, realmId, flowAlias)
+		if err != nil {
+			return err
+		}
+
+		matched, _ = matchAuthenticationExecutions(desired, current)
+	}
+
+	for i, execution := range desired {
+		if matched[i] == nil {
+			return fmt.Errorf("execution %s of flow %s not found after creating it", execution.key(), flowAlias)
+		}
+
+		err = updateAuthenticationFlowTreeExecution(ctx, keycloakClient, realmId, flowAlias, execution, matched[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	// the executions are only reordered once every subflow has converged, moving executions doesn't affect their children
+	var currentOrder, desiredOrder []string
+	for _, execution := range current {
+		for _, m := range matched {
+			if m == execution {
+				currentOrder = append(currentOrder, execution.info.Id)
+			}
+		}
+	}
+	for _, execution := range matched {
+		desiredOrder = append(desiredOrder, execution.info.Id)
+	}
+
+	for _, move := range authenticationExecutionMoves(currentOrder, desiredOrder) {
+		for i := 0; i < move.Steps; i++ {
+			err = keycloakClient.LowerAuthenticationExecutionPriority(ctx, realmId, move.Id)
+			if err != nil {
+				return err
+			}
+		}
+		for i := 0; i > move.Steps; i-- {
+			err = keycloakClient.RaiseAuthenticationExecutionPriority(ctx, realmId, move.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func updateAuthenticationFlowTreeExecution(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, flowAlias string, desired *authenticationFlowTreeExecution, current *currentAuthenticationExecution) error {
+	var err error
+
+	if desired.SubFlow != nil {
+		if desired.Requirement != current.info.Requirement || desired.SubFlow.Description != current.subFlow.Description {
+			err = keycloakClient.UpdateAuthenticationSubFlow(ctx, &keycloak.AuthenticationSubFlow{
+				Id:              current.subFlow.Id,
+				RealmId:         realmId,
+				ParentFlowAlias: flowAlias,
+				Alias:           desired.SubFlow.Alias,
+				Description:     desired.SubFlow.Description,
+				ProviderId:      desired.SubFlow.ProviderId,
+				Requirement:     desired.Requirement,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	} else if desired.Requirement != current.info.Requirement {
+		err = keycloakClient.UpdateAuthenticationExecution(ctx, &keycloak.AuthenticationExecution{
+			Id:              current.info.Id,
+			RealmId:         realmId,
+			ParentFlowAlias: flowAlias,
+			Requirement:     desired.Requirement,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = updateAuthenticationFlowTreeExecutionConfig(ctx, keycloakClient, realmId, desired, current)
+	if err != nil {
+		return err
+	}
+
+	if desired.SubFlow != nil {
+		return convergeAuthenticationFlow(ctx, keycloakClient, realmId, desired.SubFlow.Alias, desired.SubFlow.Executions)
+	}
+
+	return nil
+}
+
+func updateAuthenticationFlowTreeExecutionConfig(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId string, desired *authenticationFlowTreeExecution, current *currentAuthenticationExecution) error {
+	configId := current.info.AuthenticationConfig
+
+	if desired.Config == nil {
+		if configId == "" {
+			return nil
+		}
+
+		return keycloakClient.DeleteAuthenticationExecutionConfig(ctx, &keycloak.AuthenticationExecutionConfig{
+			RealmId: realmId,
+			Id:      configId,
+		})
+	}
+
+	config := &keycloak.AuthenticationExecutionConfig{
+		RealmId:     realmId,
+		ExecutionId: current.info.Id,
+		Alias:       desired.Config.Alias,
+		Config:      desired.Config.Config,
+	}
+
+	if configId == "" {
+		_, err := keycloakClient.NewAuthenticationExecutionConfig(ctx, config)
+		return err
+	}
+
+	existing := &keycloak.AuthenticationExecutionConfig{
+		RealmId: realmId,
+		Id:      configId,
+	}
+	err := keycloakClient.GetAuthenticationExecutionConfig(ctx, existing)
+	if err != nil {
+		return err
+	}
+
+	if existing.Alias == config.Alias && (len(existing.Config) == 0 && len(config.Config) == 0 || reflect.DeepEqual(existing.Config, config.Config)) {
+		return nil
+	}
+
+	config.Id = configId
+
+	return keycloakClient.UpdateAuthenticationExecutionConfig(ctx, config)
+}
+
+type authenticationExecutionMove struct {
+	Id string
+	// Steps is the number of positions the execution moves down, or up when negative
+	Steps int
+}
+
+// authenticationExecutionMoves returns the moves turning the current order into the desired one, in the order they
+// have to be applied. The executions on the longest subsequence that's already in the desired order don't move.
+func authenticationExecutionMoves(current, desired []string) []authenticationExecutionMove {
+	desiredIndex := make(map[string]int, len(desired))
+	for i, id := range desired {
+		desiredIndex[id] = i
+	}
+
+	// longest increasing subsequence of the desired indexes, in current order
+	length := make([]int, len(current))
+	previous := make([]int, len(current))
+	best := -1
+	for i, id := range current {
+		length[i], previous[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if desiredIndex[current[j]] < desiredIndex[id] && length[j]+1 > length[i] {
+				length[i], previous[i] = length[j]+1, j
+			}
+		}
+		if best == -1 || length[i] > length[best] {
+			best = i
+		}
+	}
+
+	stays := map[string]bool{}
+	for i := best; i != -1; i = previous[i] {
+		stays[current[i]] = true
+	}
+
+	order := append([]string(nil), current...)
+	indexOf := func(id string) int {
+		for i, candidate := range order {
+			if candidate == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var moves []authenticationExecutionMove
+	for i, id := range desired {
+		if stays[id] {
+			continue
+		}
+
+		from := indexOf(id)
+		to := 0
+		if i > 0 {
+			to = indexOf(desired[i-1]) + 1
+		}
+		if from < to {
+			to--
+		}
+
+		order = append(order[:from], order[from+1:]...)
+		order = append(order[:to], append([]string{id}, order[to:]...)...)
+
+		if to != from {
+			moves = append(moves, authenticationExecutionMove{Id: id, Steps: to - from})
+		}
+	}
+
+	return moves
+}
+
+func readAuthenticationFlowTreeExecutions(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, flowAlias string, depth int) ([]interface{}, error) {
+	current, err := listDirectAuthenticationExecutions(ctx, keycloakClient, realmId, flowAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]interface{}, 0, len(current))
+	for _, execution := range current {
+		data := map[string]interface{}{
+			"id":            execution.info.Id,
+			"authenticator": execution.info.ProviderId,
+			"requirement":   execution.info.Requirement,
+		}
+
+		if configId := execution.info.AuthenticationConfig; configId != "" {
+			config := &keycloak.AuthenticationExecutionConfig{
+				RealmId: realmId,
+				Id:      configId,
+			}
+			err = keycloakClient.GetAuthenticationExecutionConfig(ctx, config)
+			if err != nil {
+				return nil, err
+			}
+
+			data["config"] = []interface{}{
+				map[string]interface{}{
+					"alias":  config.Alias,
+					"config": config.Config,
+				},
+			}
+		}
+
+		if execution.subFlow != nil {
+			data["authenticator"] = execution.authenticator
+
+			if depth >= authenticationFlowTreeMaxDepth {
+				return nil, fmt.Errorf("subflow %s is nested more than %d times, which isn't supported", execution.subFlow.Alias, authenticationFlowTreeMaxDepth)
+			}
+
+			subFlowExecutions, err := readAuthenticationFlowTreeExecutions(ctx, keycloakClient, realmId, execution.subFlow.Alias, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			data["subflow"] = []interface{}{
+				map[string]interface{}{
+					"alias":       execution.subFlow.Alias,
+					"description": execution.subFlow.Description,
+					"provider_id": execution.subFlow.ProviderId,
+					"execution":   subFlowExecutions,
+				},
+			}
+		}
+
+		executions = append(executions, data)
+	}
+
+	return executions, nil
+}
+
+func resourceKeycloakAuthenticationFlowTreeCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	executions, err := getAuthenticationFlowTreeExecutionsFromData(data.Get("execution").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	authenticationFlow := mapFromDataToAuthenticationFlow(data)
+
+	if copyFrom := data.Get("copy_from").(string); copyFrom != "" {
+		copied, err := keycloakClient.CopyAuthenticationFlow(ctx, authenticationFlow.RealmId, copyFrom, authenticationFlow.Alias)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		authenticationFlow.Id = copied.Id
+		err = keycloakClient.UpdateAuthenticationFlow(ctx, authenticationFlow)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		err = keycloakClient.NewAuthenticationFlow(ctx, authenticationFlow)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(authenticationFlow.Id)
+
+	err = convergeAuthenticationFlow(ctx, keycloakClient, authenticationFlow.RealmId, authenticationFlow.Alias, executions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakAuthenticationFlowTreeRead(ctx, data, meta)
+}
+
+func resourceKeycloakAuthenticationFlowTreeRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	authenticationFlow, err := keycloakClient.GetAuthenticationFlow(ctx, realmId, data.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	executions, err := readAuthenticationFlowTreeExecutions(ctx, keycloakClient, realmId, authenticationFlow.Alias, 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.Set("alias", authenticationFlow.Alias)
+	data.Set("description", authenticationFlow.Description)
+	data.Set("provider_id", authenticationFlow.ProviderId)
+	data.Set("execution", executions)
+
+	return nil
+}
+
+func resourceKeycloakAuthenticationFlowTreeUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	executions, err := getAuthenticationFlowTreeExecutionsFromData(data.Get("execution").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	authenticationFlow := mapFromDataToAuthenticationFlow(data)
+
+	if data.HasChanges("alias", "description") {
+		err = keycloakClient.UpdateAuthenticationFlow(ctx, authenticationFlow)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = convergeAuthenticationFlow(ctx, keycloakClient, authenticationFlow.RealmId, authenticationFlow.Alias, executions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakAuthenticationFlowTreeRead(ctx, data, meta)
+}
+
+func resourceKeycloakAuthenticationFlowTreeDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	err := keycloakClient.DeleteAuthenticationFlow(ctx, realmId, data.Id())
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakAuthenticationFlowTreeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{authenticationFlowId}}")
+	}
+
+	_, err := keycloakClient.GetAuthenticationFlow(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakAuthenticationFlowTree_basic(t *testing.T) {
+	t.Parallel()
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakAuthenticationFlowDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakAuthenticationFlowTree(alias, "auth-cookie", "auth-username-password-form"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakAuthenticationFlowExists("keycloak_authentication_flow_tree.flow"),
+					resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.0.authenticator", "auth-cookie"),
+					resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.2.subflow.0.execution.1.config.0.config.defaultProvider", "idp"),
+				),
+			},
+			{
+				Config: testKeycloakAuthenticationFlowTree(alias, "auth-username-password-form", "auth-cookie"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.0.authenticator", "auth-username-password-form"),
+					resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.1.authenticator", "auth-cookie"),
+				),
+			},
+			{
+				PreConfig: func() {
+					flow, err := keycloakClient.GetAuthenticationFlowFromAlias(testCtx, testAccRealm.Realm, alias)
+					if err != nil {
+						t.Fatal(err)
+					}
+					executions, err := listDirectAuthenticationExecutions(testCtx, keycloakClient, testAccRealm.Realm, flow.Alias)
+					if err != nil {
+						t.Fatal(err)
+					}
+					err = keycloakClient.LowerAuthenticationExecutionPriority(testCtx, testAccRealm.Realm, executions[0].info.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testKeycloakAuthenticationFlowTree(alias, "auth-username-password-form", "auth-cookie"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testKeycloakAuthenticationFlowTree(alias, "auth-username-password-form", "auth-cookie"),
+				Check:  resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.0.authenticator", "auth-username-password-form"),
+			},
+			{
+				ResourceName:        "keycloak_authentication_flow_tree.flow",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func TestAccKeycloakAuthenticationFlowTree_copyFrom(t *testing.T) {
+	t.Parallel()
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakAuthenticationFlowDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakAuthenticationFlowTree_copyFrom(alias),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakAuthenticationFlowTreeExecutions("keycloak_authentication_flow_tree.flow", 2),
+					resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.#", "2"),
+					resource.TestCheckResourceAttr("keycloak_authentication_flow_tree.flow", "execution.1.requirement", "REQUIRED"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakAuthenticationFlowTree(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	flowTree := testAccProvider.ResourcesMap["keycloak_authentication_flow_tree"]
+	alias := acctest.RandomWithPrefix("tf-unit")
+
+	data := schema.TestResourceDataRaw(t, flowTree.Schema, map[string]interface{}{
+		"realm_id":    testAccRealm.Realm,
+		"alias":       alias,
+		"description": "managed as a whole",
+		"execution": []interface{}{
+			map[string]interface{}{"authenticator": "auth-cookie", "requirement": "ALTERNATIVE"},
+			map[string]interface{}{
+				"requirement": "ALTERNATIVE",
+				"subflow": []interface{}{
+					map[string]interface{}{
+						"alias": alias + " forms",
+						"execution": []interface{}{
+							map[string]interface{}{"authenticator": "auth-username-password-form", "requirement": "REQUIRED"},
+							map[string]interface{}{
+								"authenticator": "auth-otp-form",
+								"requirement":   "REQUIRED",
+								"config": []interface{}{
+									map[string]interface{}{"alias": alias + " otp", "config": map[string]interface{}{"foo": "bar"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]interface{}{"authenticator": "identity-provider-redirector", "requirement": "DISABLED"},
+		},
+	})
+	if diags := flowTree.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = flowTree.DeleteContext(testCtx, data, keycloakClient)
+	})
+
+	authenticators := func() []string {
+		var result []string
+		for _, execution := range data.Get("execution").([]interface{}) {
+			result = append(result, execution.(map[string]interface{})["authenticator"].(string))
+		}
+		return result
+	}
+
+	if got := authenticators(); !reflect.DeepEqual(got, []string{"auth-cookie", "", "identity-provider-redirector"}) {
+		t.Errorf("unexpected executions %v", got)
+	}
+	if got := data.Get("execution.1.subflow.0.execution.1.config.0.config.foo"); got != "bar" {
+		t.Errorf("expected the config of the nested execution to be read, got %v", got)
+	}
+
+	// an out-of-band reorder shows up on the next read
+	firstId := data.Get("execution.0.id").(string)
+	if err := keycloakClient.LowerAuthenticationExecutionPriority(testCtx, testAccRealm.Realm, firstId); err != nil {
+		t.Fatal(err)
+	}
+	if diags := flowTree.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := authenticators(); !reflect.DeepEqual(got, []string{"", "auth-cookie", "identity-provider-redirector"}) {
+		t.Errorf("expected the reorder to be detected, got %v", got)
+	}
+
+	// converging keeps the executions that still exist
+	executions, err := getAuthenticationFlowTreeExecutionsFromData([]interface{}{
+		map[string]interface{}{"authenticator": "identity-provider-redirector", "requirement": "ALTERNATIVE", "config": []interface{}{}, "subflow": []interface{}{}},
+		map[string]interface{}{"authenticator": "auth-cookie", "requirement": "ALTERNATIVE", "config": []interface{}{}, "subflow": []interface{}{}},
+		map[string]interface{}{"authenticator": "auth-spnego", "requirement": "DISABLED", "config": []interface{}{}, "subflow": []interface{}{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := convergeAuthenticationFlow(testCtx, keycloakClient, testAccRealm.Realm, alias, executions); err != nil {
+		t.Fatal(err)
+	}
+	if diags := flowTree.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := authenticators(); !reflect.DeepEqual(got, []string{"identity-provider-redirector", "auth-cookie", "auth-spnego"}) {
+		t.Errorf("unexpected executions after converging %v", got)
+	}
+	if data.Get("execution.1.id") != firstId {
+		t.Error("expected the existing execution to be moved rather than recreated")
+	}
+	if data.Get("execution.0.requirement") != "ALTERNATIVE" {
+		t.Error("expected the requirement to be updated")
+	}
+	if _, err := keycloakClient.GetAuthenticationFlowFromAlias(testCtx, testAccRealm.Realm, alias+" forms"); err == nil {
+		t.Error("expected the removed subflow to be deleted")
+	}
+
+	// copy_from starts from the executions of the copied flow
+	copied := schema.TestResourceDataRaw(t, flowTree.Schema, map[string]interface{}{
+		"realm_id":  testAccRealm.Realm,
+		"alias":     alias + " copy",
+		"copy_from": "direct grant",
+		"execution": []interface{}{
+			map[string]interface{}{"authenticator": "direct-grant-validate-username", "requirement": "REQUIRED"},
+			map[string]interface{}{"authenticator": "direct-grant-validate-password", "requirement": "DISABLED"},
+		},
+	})
+	if diags := flowTree.CreateContext(testCtx, copied, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = flowTree.DeleteContext(testCtx, copied, keycloakClient)
+	})
+	if copied.Get("execution.#") != 2 || copied.Get("execution.1.requirement") != "DISABLED" {
+		t.Errorf("unexpected executions of the copy %v", copied.Get("execution"))
+	}
+}
+
+func TestUnitAuthenticationExecutionMoves(t *testing.T) {
+	for name, test := range map[string]struct {
+		current, desired []string
+		moves            int
+	}{
+		"unchanged":      {current: []string{"a", "b", "c"}, desired: []string{"a", "b", "c"}, moves: 0},
+		"swap":           {current: []string{"a", "b", "c"}, desired: []string{"b", "a", "c"}, moves: 1},
+		"last to first":  {current: []string{"a", "b", "c", "d"}, desired: []string{"d", "a", "b", "c"}, moves: 1},
+		"first to last":  {current: []string{"a", "b", "c", "d"}, desired: []string{"b", "c", "d", "a"}, moves: 1},
+		"reversed":       {current: []string{"a", "b", "c", "d"}, desired: []string{"d", "c", "b", "a"}, moves: 3},
+		"interleaved":    {current: []string{"a", "b", "c", "d", "e"}, desired: []string{"b", "a", "d", "c", "e"}, moves: 2},
+		"single element": {current: []string{"a"}, desired: []string{"a"}, moves: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			moves := authenticationExecutionMoves(test.current, test.desired)
+			if len(moves) != test.moves {
+				t.Errorf("expected %d moves, got %v", test.moves, moves)
+			}
+
+			// apply the moves one step at a time, like Keycloak does
+			order := append([]string(nil), test.current...)
+			for _, move := range moves {
+				index := 0
+				for i, id := range order {
+					if id == move.Id {
+						index = i
+					}
+				}
+				for ; move.Steps > 0; move.Steps-- {
+					order[index], order[index+1] = order[index+1], order[index]
+					index++
+				}
+				for ; move.Steps < 0; move.Steps++ {
+					order[index], order[index-1] = order[index-1], order[index]
+					index--
+				}
+			}
+			if !reflect.DeepEqual(order, test.desired) {
+				t.Errorf("expected %v, got %v", test.desired, order)
+			}
+		})
+	}
+}
+
+func testAccCheckKeycloakAuthenticationFlowTreeExecutions(resourceName string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		executions, err := listDirectAuthenticationExecutions(testCtx, keycloakClient, rs.Primary.Attributes["realm_id"], rs.Primary.Attributes["alias"])
+		if err != nil {
+			return err
+		}
+		if len(executions) != count {
+			return fmt.Errorf("expected %d executions, got %d", count, len(executions))
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakAuthenticationFlowTree(alias, first, second string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_authentication_flow_tree" "flow" {
+	realm_id    = data.keycloak_realm.realm.id
+	alias       = "%s"
+	description = "browser flow managed as a whole"
+
+	execution {
+		authenticator = "%s"
+		requirement   = "ALTERNATIVE"
+	}
+
+	execution {
+		authenticator = "%s"
+		requirement   = "ALTERNATIVE"
+	}
+
+	execution {
+		requirement = "ALTERNATIVE"
+
+		subflow {
+			alias = "%s idp"
+
+			execution {
+				authenticator = "idp-review-profile"
+				requirement   = "DISABLED"
+			}
+
+			execution {
+				authenticator = "identity-provider-redirector"
+				requirement   = "REQUIRED"
+
+				config {
+					alias = "%s redirector"
+					config = {
+						defaultProvider = "idp"
+					}
+				}
+			}
+		}
+	}
+}
+	`, testAccRealm.Realm, alias, first, second, alias, alias)
+}
+
+func testKeycloakAuthenticationFlowTree_copyFrom(alias string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_authentication_flow_tree" "flow" {
+	realm_id  = data.keycloak_realm.realm.id
+	alias     = "%s"
+	copy_from = "direct grant"
+
+	execution {
+		authenticator = "direct-grant-validate-username"
+		requirement   = "REQUIRED"
+	}
+
+	execution {
+		authenticator = "direct-grant-validate-password"
+		requirement   = "REQUIRED"
+	}
+}
+	`, testAccRealm.Realm, alias)
+}
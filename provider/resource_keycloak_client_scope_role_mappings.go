@@ -0,0 +1,9 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakClientScopeRoleMappings() *schema.Resource {
+	return resourceKeycloakRoleScopeMappings("client_scope_id", "client scope")
+}
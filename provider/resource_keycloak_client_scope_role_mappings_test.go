@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakClientScopeRoleMappings_basic(t *testing.T) {
+	t.Parallel()
+
+	clientScopeName := acctest.RandomWithPrefix("tf-acc")
+	rolePrefix := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_client_scope_role_mappings.mappings"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientScopeRoleMappings(clientScopeName, rolePrefix, "[keycloak_role.realm_role.id, keycloak_role.client_role.id]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "role_ids.#", "2"),
+					testAccCheckKeycloakRoleScopeMappings(resourceName, 2),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testKeycloakClientScopeRoleMappings(clientScopeName, rolePrefix, "[keycloak_role.client_role.id]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "role_ids.#", "1"),
+					testAccCheckKeycloakRoleScopeMappings(resourceName, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakClientScopeRoleMappings_clientScopeDeleted(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	clientScope := &keycloak.OpenidClientScope{
+		RealmId: testAccRealm.Realm,
+		Name:    acctest.RandomWithPrefix("tf-unit"),
+	}
+	if err := keycloakClient.NewOpenidClientScope(testCtx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+
+	role := &keycloak.Role{
+		RealmId: testAccRealm.Realm,
+		Name:    acctest.RandomWithPrefix("tf-unit"),
+	}
+	if err := keycloakClient.CreateRole(testCtx, role); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteRole(testCtx, testAccRealm.Realm, role.Id)
+	})
+
+	mappingsResource := testAccProvider.ResourcesMap["keycloak_client_scope_role_mappings"]
+	data := schema.TestResourceDataRaw(t, mappingsResource.Schema, map[string]interface{}{
+		"realm_id":        testAccRealm.Realm,
+		"client_scope_id": clientScope.Id,
+		"role_ids":        []interface{}{role.Id},
+	})
+	if diags := mappingsResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != testAccRealm.Realm+"/"+clientScope.Id {
+		t.Errorf("unexpected id %s", data.Id())
+	}
+
+	mappedRole, err := keycloakClient.GetRoleScopeMapping(testCtx, testAccRealm.Realm, "", clientScope.Id, role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mappedRole == nil {
+		t.Fatal("expected the role to be in scope of the client scope")
+	}
+
+	// the mappings are gone along with the client scope
+	if err := keycloakClient.DeleteOpenidClientScope(testCtx, testAccRealm.Realm, clientScope.Id); err != nil {
+		t.Fatal(err)
+	}
+	if diags := mappingsResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != "" {
+		t.Error("expected the mappings to be removed from the state")
+	}
+}
+
+func testKeycloakClientScopeRoleMappings(clientScopeName, rolePrefix, roleIds string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client_scope" "client_scope" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id    = data.keycloak_realm.realm.id
+	client_id   = "%s"
+	access_type = "PUBLIC"
+}
+
+resource "keycloak_role" "realm_role" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s-realm"
+}
+
+resource "keycloak_role" "client_role" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client.id
+	name      = "%s-client"
+}
+
+resource "keycloak_client_scope_role_mappings" "mappings" {
+	realm_id        = data.keycloak_realm.realm.id
+	client_scope_id = keycloak_openid_client_scope.client_scope.id
+	role_ids        = %s
+}
+	`, testAccRealm.Realm, clientScopeName, clientScopeName, rolePrefix, rolePrefix, roleIds)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakIdentityProviderPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakIdentityProviderPermissionsCreate,
+		ReadContext:   resourceKeycloakIdentityProviderPermissionsRead,
+		DeleteContext: resourceKeycloakIdentityProviderPermissionsDelete,
+		UpdateContext: resourceKeycloakIdentityProviderPermissionsUpdate,
+		// This resource can be imported using {{realmId}}/{{providerAlias}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakIdentityProviderPermissionsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"provider_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"authorization_resource_server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource server id representing the realm management client on which this permission is managed",
+			},
+			"token_exchange_scope": scopePermissionsSchema(),
+		},
+	}
+}
+
+func identityProviderPermissionsId(realmId, providerAlias string) string {
+	return fmt.Sprintf("%s/%s", realmId, providerAlias)
+}
+
+func resourceKeycloakIdentityProviderPermissionsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceKeycloakIdentityProviderPermissionsUpdate(ctx, data, meta)
+}
+
+func resourceKeycloakIdentityProviderPermissionsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	providerAlias := data.Get("provider_alias").(string)
+
+	// the existence of this resource implies that it is enabled.
+	err := keycloakClient.EnableIdentityProviderPermissions(ctx, realmId, providerAlias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// setting scope permissions requires us to fetch the identity provider permissions details, as well as the realm management client
+	identityProviderPermissions, err := keycloakClient.GetIdentityProviderPermissions(ctx, realmId, providerAlias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, "realm-management")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tokenExchangeScope, ok := data.GetOk("token_exchange_scope"); ok {
+		err := setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, identityProviderPermissions.GetScopePermissionId("token-exchange"), tokenExchangeScope.(*schema.Set))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeycloakIdentityProviderPermissionsRead(ctx, data, meta)
+}
+
+func resourceKeycloakIdentityProviderPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+	realmId := data.Get("realm_id").(string)
+	providerAlias := data.Get("provider_alias").(string)
+
+	realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, "realm-management")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	identityProviderPermissions, err := keycloakClient.GetIdentityProviderPermissions(ctx, realmId, providerAlias)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	if !identityProviderPermissions.Enabled {
+		tflog.Warn(ctx, "Removing resource with id from state as it is no longer enabled", map[string]interface{}{
+			"id": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	}
+
+	data.SetId(identityProviderPermissionsId(identityProviderPermissions.RealmId, identityProviderPermissions.ProviderAlias))
+	data.Set("realm_id", identityProviderPermissions.RealmId)
+	data.Set("provider_alias", identityProviderPermissions.ProviderAlias)
+	data.Set("enabled", identityProviderPermissions.Enabled)
+	data.Set("authorization_resource_server_id", realmManagementClient.Id)
+
+	if scopeId := identityProviderPermissions.GetScopePermissionId("token-exchange"); scopeId != "" {
+		if tokenExchangeScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, scopeId); err == nil && tokenExchangeScope != nil {
+			data.Set("token_exchange_scope", []interface{}{tokenExchangeScope})
+		} else if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceKeycloakIdentityProviderPermissionsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	providerAlias := data.Get("provider_alias").(string)
+
+	return diag.FromErr(keycloakClient.DisableIdentityProviderPermissions(ctx, realmId, providerAlias))
+}
+
+func resourceKeycloakIdentityProviderPermissionsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{providerAlias}}")
+	}
+
+	_, err := keycloakClient.GetIdentityProviderPermissions(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("provider_alias", parts[1])
+
+	d.SetId(identityProviderPermissionsId(parts[0], parts[1]))
+
+	diagnostics := resourceKeycloakIdentityProviderPermissionsRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakIdentityProviderPermission_basic(t *testing.T) {
+	providerAlias := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakIdentityProviderPermissionDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakIdentityProviderPermission_basic(providerAlias, clientId),
+				Check:  testAccCheckKeycloakIdentityProviderPermissionExists("keycloak_identity_provider_permissions.test"),
+			},
+			{
+				ResourceName:      "keycloak_identity_provider_permissions.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakIdentityProviderPermissionExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+		providerAlias := rs.Primary.Attributes["provider_alias"]
+		authorizationResourceServerId := rs.Primary.Attributes["authorization_resource_server_id"]
+
+		permissions, err := keycloakClient.GetIdentityProviderPermissions(testCtx, realmId, providerAlias)
+		if err != nil {
+			return err
+		}
+
+		if !permissions.Enabled {
+			return fmt.Errorf("expected permissions of identity provider %s to be enabled", providerAlias)
+		}
+
+		tokenExchangeScope, err := keycloakClient.GetOpenidClientAuthorizationPermission(testCtx, realmId, authorizationResourceServerId, permissions.GetScopePermissionId("token-exchange"))
+		if err != nil {
+			return err
+		}
+
+		policyId := rs.Primary.Attributes["token_exchange_scope.0.policies.0"]
+		if len(tokenExchangeScope.Policies) != 1 || tokenExchangeScope.Policies[0] != policyId {
+			return fmt.Errorf("expected token-exchange permission to have policy %s, got %v", policyId, tokenExchangeScope.Policies)
+		}
+
+		if tokenExchangeScope.DecisionStrategy != rs.Primary.Attributes["token_exchange_scope.0.decision_strategy"] {
+			return fmt.Errorf("DecisionStrategy %s was not equal to %s", tokenExchangeScope.DecisionStrategy, rs.Primary.Attributes["token_exchange_scope.0.decision_strategy"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakIdentityProviderPermissionDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_identity_provider_permissions" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			providerAlias := rs.Primary.Attributes["provider_alias"]
+
+			permissions, err := keycloakClient.GetIdentityProviderPermissions(testCtx, realmId, providerAlias)
+			if err == nil && permissions.Enabled {
+				return fmt.Errorf("permissions of identity provider %s are still enabled", providerAlias)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakIdentityProviderPermission_basic(providerAlias, clientId string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+data "keycloak_openid_client" "realm_management" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "realm-management"
+}
+
+resource "keycloak_openid_client_permissions" "realm-management_permission" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = data.keycloak_openid_client.realm_management.id
+}
+
+resource "keycloak_oidc_identity_provider" "idp" {
+	realm             = data.keycloak_realm.realm.id
+	alias             = "%s"
+	authorization_url = "http://localhost:8080/auth/realms/something/protocol/openid-connect/auth"
+	token_url         = "http://localhost:8080/auth/realms/something/protocol/openid-connect/token"
+	client_id         = "%s"
+	client_secret     = "secret"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id      = data.keycloak_realm.realm.id
+	client_id     = "%s"
+	client_secret = "secret"
+	access_type   = "CONFIDENTIAL"
+}
+
+resource "keycloak_openid_client_client_policy" "test" {
+	realm_id           = data.keycloak_realm.realm.id
+	resource_server_id = data.keycloak_openid_client.realm_management.id
+	name               = "token_exchange_%s"
+	clients            = [
+		keycloak_openid_client.client.id
+	]
+	logic             = "POSITIVE"
+	decision_strategy = "UNANIMOUS"
+	depends_on = [
+		keycloak_openid_client_permissions.realm-management_permission,
+	]
+}
+
+resource "keycloak_identity_provider_permissions" "test" {
+	realm_id       = data.keycloak_realm.realm.id
+	provider_alias = keycloak_oidc_identity_provider.idp.alias
+
+	token_exchange_scope {
+		policies          = [
+			keycloak_openid_client_client_policy.test.id
+		]
+		description       = "token_exchange_scope"
+		decision_strategy = "UNANIMOUS"
+	}
+}
+	`, testAccRealm.Realm, providerAlias, clientId, clientId, providerAlias)
+}
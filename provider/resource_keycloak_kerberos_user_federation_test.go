@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakKerberosUserFederation_basic(t *testing.T) {
+	t.Parallel()
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakKerberosUserFederation_basic(name, "FOO.LOCAL"),
+				Check:  testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+			},
+			{
+				Config: testKeycloakKerberosUserFederation_basic(name, "BAR.LOCAL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+					resource.TestCheckResourceAttr("keycloak_kerberos_user_federation.kerberos", "kerberos_realm", "BAR.LOCAL"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_kerberos_user_federation.kerberos",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func TestAccKeycloakKerberosUserFederation_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+
+	var kerberos = &keycloak.KerberosUserFederation{}
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakKerberosUserFederation_basic(name, "FOO.LOCAL"),
+				Check:  testAccCheckKeycloakKerberosUserFederationFetch("keycloak_kerberos_user_federation.kerberos", kerberos),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteKerberosUserFederation(testCtx, testAccRealm.Realm, kerberos.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakKerberosUserFederation_basic(name, "FOO.LOCAL"),
+				Check:  testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakKerberosUserFederation_editModeValidation(t *testing.T) {
+	t.Parallel()
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakKerberosUserFederation_editMode(name, "WRITABLE"),
+				ExpectError: regexp.MustCompile(`expected edit_mode to be one of \["READ_ONLY" "UNSYNCED"\]`),
+			},
+			{
+				Config: testKeycloakKerberosUserFederation_editMode(name, "UNSYNCED"),
+				Check:  resource.TestCheckResourceAttr("keycloak_kerberos_user_federation.kerberos", "edit_mode", "UNSYNCED"),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakKerberosUserFederation(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	kerberosResource := testAccProvider.ResourcesMap["keycloak_kerberos_user_federation"]
+
+	data := schema.TestResourceDataRaw(t, kerberosResource.Schema, map[string]interface{}{
+		"realm_id":                      testAccRealm.Realm,
+		"name":                          acctest.RandomWithPrefix("tf-unit"),
+		"kerberos_realm":                "FOO.LOCAL",
+		"server_principal":              "HTTP/host.foo.local@FOO.LOCAL",
+		"key_tab":                       "/etc/host.keytab",
+		"debug":                         true,
+		"allow_password_authentication": true,
+		"edit_mode":                     "UNSYNCED",
+		"update_profile_first_login":    true,
+		"cache": []interface{}{
+			map[string]interface{}{"policy": "MAX_LIFESPAN", "max_lifespan": "1h"},
+		},
+	})
+	if diags := kerberosResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = kerberosResource.DeleteContext(testCtx, data, keycloakClient)
+	})
+
+	kerberos, err := keycloakClient.GetKerberosUserFederation(testCtx, testAccRealm.Realm, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kerberos.ServerPrincipal != "HTTP/host.foo.local@FOO.LOCAL" || !kerberos.Debug || !kerberos.UpdateProfileFirstLogin {
+		t.Errorf("unexpected kerberos user federation %+v", kerberos)
+	}
+	if kerberos.CachePolicy != "MAX_LIFESPAN" || kerberos.MaxLifespan != "1h0m0s" {
+		t.Errorf("unexpected cache settings %s %s", kerberos.CachePolicy, kerberos.MaxLifespan)
+	}
+	if data.Get("edit_mode") != "UNSYNCED" || data.Get("cache.0.policy") != "MAX_LIFESPAN" {
+		t.Errorf("unexpected state %v %v", data.Get("edit_mode"), data.Get("cache"))
+	}
+
+	// the edit mode is dropped along with password authentication
+	kerberos.AllowPasswordAuthentication = false
+	if err := keycloakClient.UpdateKerberosUserFederation(testCtx, testAccRealm.Realm, kerberos); err != nil {
+		t.Fatal(err)
+	}
+	kerberos, err = keycloakClient.GetKerberosUserFederation(testCtx, testAccRealm.Realm, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kerberos.EditMode != "" {
+		t.Errorf("expected the edit mode to be unset, got %s", kerberos.EditMode)
+	}
+
+	ldap := &keycloak.LdapUserFederation{
+		Name:                  acctest.RandomWithPrefix("tf-unit"),
+		RealmId:               testAccRealm.Realm,
+		UserObjectClasses:     []string{"person"},
+		UsernameLDAPAttribute: "cn",
+		EditMode:              "READ_ONLY",
+	}
+	if err := keycloakClient.NewLdapUserFederation(testCtx, testAccRealm.Realm, ldap); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteLdapUserFederation(testCtx, testAccRealm.Realm, ldap.Id)
+	})
+
+	imported := schema.TestResourceDataRaw(t, kerberosResource.Schema, map[string]interface{}{})
+	imported.SetId(testAccRealm.Realm + "/" + ldap.Id)
+	if _, err := kerberosResource.Importer.StateContext(testCtx, imported, keycloakClient); err == nil {
+		t.Error("expected importing an ldap user federation to fail")
+	}
+}
+
+func testAccCheckKeycloakKerberosUserFederationExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getKerberosUserFederationFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakKerberosUserFederationFetch(resourceName string, kerberos *keycloak.KerberosUserFederation) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedKerberos, err := getKerberosUserFederationFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		kerberos.Id = fetchedKerberos.Id
+		kerberos.RealmId = fetchedKerberos.RealmId
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakKerberosUserFederationDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_kerberos_user_federation" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			kerberos, _ := keycloakClient.GetKerberosUserFederation(testCtx, realm, id)
+			if kerberos != nil {
+				return fmt.Errorf("kerberos user federation with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getKerberosUserFederationFromState(s *terraform.State, resourceName string) (*keycloak.KerberosUserFederation, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	kerberos, err := keycloakClient.GetKerberosUserFederation(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting kerberos user federation with id %s: %s", id, err)
+	}
+
+	return kerberos, nil
+}
+
+func testKeycloakKerberosUserFederation_basic(name, kerberosRealm string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+
+	kerberos_realm   = "%s"
+	server_principal = "HTTP/host.foo.local@%s"
+	key_tab          = "/etc/host.keytab"
+
+	cache {
+		policy = "NO_CACHE"
+	}
+}
+	`, testAccRealm.Realm, name, kerberosRealm, kerberosRealm)
+}
+
+func testKeycloakKerberosUserFederation_editMode(name, editMode string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+
+	kerberos_realm   = "FOO.LOCAL"
+	server_principal = "HTTP/host.foo.local@FOO.LOCAL"
+	key_tab          = "/etc/host.keytab"
+
+	allow_password_authentication = true
+	edit_mode                     = "%s"
+}
+	`, testAccRealm.Realm, name, editMode)
+}
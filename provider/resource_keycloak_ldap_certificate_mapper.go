@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakLdapCertificateMapper() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakLdapCertificateMapperCreate,
+		ReadContext:   resourceKeycloakLdapCertificateMapperRead,
+		UpdateContext: resourceKeycloakLdapCertificateMapperUpdate,
+		DeleteContext: resourceKeycloakLdapCertificateMapperDelete,
+		// This resource can be imported using {{realm}}/{{provider_id}}/{{mapper_id}}. The Provider and Mapper IDs are displayed in the GUI
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakLdapGenericMapperImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Display name of the mapper when displayed in the console.",
+			},
+			"realm_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The realm in which the ldap user federation provider exists.",
+			},
+			"ldap_user_federation_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ldap user federation provider to attach this mapper to.",
+			},
+			"user_model_attribute": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the UserModel property or attribute you want to map the LDAP attribute into.",
+			},
+			"ldap_attribute": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the mapped attribute on LDAP object.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, this attribute is not saved back to LDAP when the user attribute is updated in Keycloak.",
+			},
+			"always_read_value_from_ldap": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the value fetched from LDAP will override the value stored in Keycloak.",
+			},
+			"is_mandatory_in_ldap": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, this attribute must exist in LDAP.",
+			},
+			"attribute_force_default": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When true, an empty default value is forced for mandatory attributes even when a default value is not specified.",
+			},
+			"attribute_default_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				Description:  "Default certificate to set in LDAP if is_mandatory_in_ldap and the value is empty, either PEM or base64 encoded DER.",
+				ValidateFunc: validateLdapCertificateMapperCertificate,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return old == formatCertificate(new)
+				},
+			},
+			"is_binary_attribute": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Should be true for binary LDAP attributes, such as userCertificate;binary. Requires always_read_value_from_ldap to be true.",
+			},
+			"is_der_formatted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the certificate stored in LDAP is DER formatted instead of PEM formatted.",
+			},
+		},
+	}
+}
+
+// validateLdapCertificateMapperCertificate accepts a PEM encoded certificate, with or without its armor, as well as a
+// base64 encoded DER certificate. Both are stored by keycloak as the base64 encoded DER certificate.
+func validateLdapCertificateMapperCertificate(value interface{}, key string) ([]string, []error) {
+	if value.(string) == "" {
+		return nil, nil
+	}
+
+	der, err := base64.StdEncoding.DecodeString(formatCertificate(value.(string)))
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a PEM or base64 encoded DER certificate: %s", key, err)}
+	}
+
+	if _, err := x509.ParseCertificate(der); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a PEM or base64 encoded DER certificate: %s", key, err)}
+	}
+
+	return nil, nil
+}
+
+func getLdapCertificateMapperFromData(data *schema.ResourceData) *keycloak.LdapCertificateMapper {
+	return &keycloak.LdapCertificateMapper{
+		Id:                   data.Id(),
+		Name:                 data.Get("name").(string),
+		RealmId:              data.Get("realm_id").(string),
+		LdapUserFederationId: data.Get("ldap_user_federation_id").(string),
+
+		LdapAttribute:      data.Get("ldap_attribute").(string),
+		UserModelAttribute: data.Get("user_model_attribute").(string),
+
+		ReadOnly:                data.Get("read_only").(bool),
+		AlwaysReadValueFromLdap: data.Get("always_read_value_from_ldap").(bool),
+		IsMandatoryInLdap:       data.Get("is_mandatory_in_ldap").(bool),
+		ForceDefaultValue:       data.Get("attribute_force_default").(bool),
+		AttributeDefaultValue:   formatCertificate(data.Get("attribute_default_value").(string)),
+		IsBinaryAttribute:       data.Get("is_binary_attribute").(bool),
+		IsDerFormatted:          data.Get("is_der_formatted").(bool),
+	}
+}
+
+func setLdapCertificateMapperData(data *schema.ResourceData, ldapCertificateMapper *keycloak.LdapCertificateMapper) {
+	data.SetId(ldapCertificateMapper.Id)
+
+	data.Set("name", ldapCertificateMapper.Name)
+	data.Set("realm_id", ldapCertificateMapper.RealmId)
+	data.Set("ldap_user_federation_id", ldapCertificateMapper.LdapUserFederationId)
+
+	data.Set("ldap_attribute", ldapCertificateMapper.LdapAttribute)
+	data.Set("user_model_attribute", ldapCertificateMapper.UserModelAttribute)
+
+	data.Set("read_only", ldapCertificateMapper.ReadOnly)
+	data.Set("always_read_value_from_ldap", ldapCertificateMapper.AlwaysReadValueFromLdap)
+	data.Set("is_mandatory_in_ldap", ldapCertificateMapper.IsMandatoryInLdap)
+	data.Set("attribute_force_default", ldapCertificateMapper.ForceDefaultValue)
+	data.Set("attribute_default_value", ldapCertificateMapper.AttributeDefaultValue)
+	data.Set("is_binary_attribute", ldapCertificateMapper.IsBinaryAttribute)
+	data.Set("is_der_formatted", ldapCertificateMapper.IsDerFormatted)
+}
+
+func resourceKeycloakLdapCertificateMapperCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	ldapCertificateMapper := getLdapCertificateMapperFromData(data)
+
+	err := keycloakClient.ValidateLdapCertificateMapper(ctx, ldapCertificateMapper)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.NewLdapCertificateMapper(ctx, ldapCertificateMapper)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setLdapCertificateMapperData(data, ldapCertificateMapper)
+
+	return resourceKeycloakLdapCertificateMapperRead(ctx, data, meta)
+}
+
+func resourceKeycloakLdapCertificateMapperRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	ldapCertificateMapper, err := keycloakClient.GetLdapCertificateMapper(ctx, realmId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setLdapCertificateMapperData(data, ldapCertificateMapper)
+
+	return nil
+}
+
+func resourceKeycloakLdapCertificateMapperUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	ldapCertificateMapper := getLdapCertificateMapperFromData(data)
+
+	err := keycloakClient.ValidateLdapCertificateMapper(ctx, ldapCertificateMapper)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateLdapCertificateMapper(ctx, ldapCertificateMapper)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setLdapCertificateMapperData(data, ldapCertificateMapper)
+
+	return nil
+}
+
+func resourceKeycloakLdapCertificateMapperDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteLdapCertificateMapper(ctx, realmId, id))
+}
@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakLdapCertificateMapper_basic(t *testing.T) {
+	t.Parallel()
+
+	certificateMapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakLdapCertificateMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakLdapCertificateMapper_basic(certificateMapperName, false),
+				Check:  testAccCheckKeycloakLdapCertificateMapperExists("keycloak_ldap_certificate_mapper.certificate"),
+			},
+			{
+				Config: testKeycloakLdapCertificateMapper_basic(certificateMapperName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakLdapCertificateMapperExists("keycloak_ldap_certificate_mapper.certificate"),
+					resource.TestCheckResourceAttr("keycloak_ldap_certificate_mapper.certificate", "is_der_formatted", "true"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_ldap_certificate_mapper.certificate",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getLdapGenericMapperImportId("keycloak_ldap_certificate_mapper.certificate"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakLdapCertificateMapper_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+
+	var mapper = &keycloak.LdapCertificateMapper{}
+
+	certificateMapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakLdapCertificateMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakLdapCertificateMapper_basic(certificateMapperName, false),
+				Check:  testAccCheckKeycloakLdapCertificateMapperFetch("keycloak_ldap_certificate_mapper.certificate", mapper),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteLdapCertificateMapper(testCtx, mapper.RealmId, mapper.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakLdapCertificateMapper_basic(certificateMapperName, false),
+				Check:  testAccCheckKeycloakLdapCertificateMapperExists("keycloak_ldap_certificate_mapper.certificate"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakLdapCertificateMapper_binaryAttributeValidation(t *testing.T) {
+	t.Parallel()
+
+	certificateMapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakLdapCertificateMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakLdapCertificateMapper_alwaysReadValueFromLdap(certificateMapperName, false),
+				ExpectError: regexp.MustCompile("validation error: always_read_value_from_ldap must be true when is_binary_attribute is true"),
+			},
+			{
+				Config: testKeycloakLdapCertificateMapper_alwaysReadValueFromLdap(certificateMapperName, true),
+				Check:  testAccCheckKeycloakLdapCertificateMapperExists("keycloak_ldap_certificate_mapper.certificate"),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakLdapCertificateMapper(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	ldap := &keycloak.LdapUserFederation{
+		Name:                  acctest.RandomWithPrefix("tf-unit"),
+		RealmId:               testAccRealm.Realm,
+		UserObjectClasses:     []string{"person"},
+		UsernameLDAPAttribute: "cn",
+		EditMode:              "READ_ONLY",
+	}
+	if err := keycloakClient.NewLdapUserFederation(testCtx, testAccRealm.Realm, ldap); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteLdapUserFederation(testCtx, testAccRealm.Realm, ldap.Id)
+	})
+
+	mapperResource := testAccProvider.ResourcesMap["keycloak_ldap_certificate_mapper"]
+
+	data := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"realm_id":                testAccRealm.Realm,
+		"ldap_user_federation_id": ldap.Id,
+		"name":                    acctest.RandomWithPrefix("tf-unit"),
+		"user_model_attribute":    "usercertificate",
+		"ldap_attribute":          "userCertificate;binary",
+		"is_binary_attribute":     true,
+	})
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); !diags.HasError() {
+		t.Fatal("expected a binary attribute that isn't always read from ldap to be rejected")
+	}
+
+	data.Set("always_read_value_from_ldap", true)
+	data.Set("is_binary_attribute", false)
+	data.Set("is_der_formatted", true)
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); !diags.HasError() {
+		t.Fatal("expected a DER certificate in an attribute that isn't binary to be rejected")
+	}
+
+	data.Set("is_binary_attribute", true)
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = mapperResource.DeleteContext(testCtx, data, keycloakClient)
+	})
+
+	mapper, err := keycloakClient.GetLdapCertificateMapper(testCtx, testAccRealm.Realm, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mapper.IsBinaryAttribute || !mapper.AlwaysReadValueFromLdap || !mapper.IsDerFormatted {
+		t.Errorf("unexpected ldap certificate mapper %+v", mapper)
+	}
+	if mapper.LdapAttribute != "userCertificate;binary" || mapper.LdapUserFederationId != ldap.Id {
+		t.Errorf("unexpected ldap certificate mapper %+v", mapper)
+	}
+
+	data.Set("is_der_formatted", false)
+	if diags := mapperResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := mapperResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Get("is_der_formatted") != false {
+		t.Errorf("expected the certificate to be PEM formatted")
+	}
+
+	// the default certificate is stored without its PEM armor, whatever the format it's given in
+	_, certificate := generateKeyAndCert(2048)
+	for _, value := range []string{testCertificatePemLines(certificate), certificate} {
+		if _, errs := validateLdapCertificateMapperCertificate(value, "attribute_default_value"); len(errs) != 0 {
+			t.Fatalf("expected %s to be a valid certificate, got %v", value, errs)
+		}
+	}
+	if _, errs := validateLdapCertificateMapperCertificate("bm90IGEgY2VydGlmaWNhdGU=", "attribute_default_value"); len(errs) == 0 {
+		t.Error("expected a value that isn't a certificate to be rejected")
+	}
+
+	data.Set("attribute_default_value", testCertificatePemLines(certificate))
+	if diags := mapperResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	mapper, err = keycloakClient.GetLdapCertificateMapper(testCtx, testAccRealm.Realm, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapper.AttributeDefaultValue != certificate {
+		t.Errorf("expected the default certificate to be stored without its PEM armor, got %s", mapper.AttributeDefaultValue)
+	}
+}
+
+func testAccCheckKeycloakLdapCertificateMapperExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getLdapCertificateMapperFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakLdapCertificateMapperFetch(resourceName string, mapper *keycloak.LdapCertificateMapper) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedMapper, err := getLdapCertificateMapperFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		mapper.Id = fetchedMapper.Id
+		mapper.RealmId = fetchedMapper.RealmId
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakLdapCertificateMapperDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_ldap_certificate_mapper" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			ldapCertificateMapper, _ := keycloakClient.GetLdapCertificateMapper(testCtx, realm, id)
+			if ldapCertificateMapper != nil {
+				return fmt.Errorf("ldap certificate mapper with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getLdapCertificateMapperFromState(s *terraform.State, resourceName string) (*keycloak.LdapCertificateMapper, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	ldapCertificateMapper, err := keycloakClient.GetLdapCertificateMapper(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ldap certificate mapper with id %s: %s", id, err)
+	}
+
+	return ldapCertificateMapper, nil
+}
+
+func testKeycloakLdapCertificateMapper_basic(certificateMapperName string, isDerFormatted bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_ldap_user_federation" "openldap" {
+	name                    = "openldap"
+	realm_id                = data.keycloak_realm.realm.id
+
+	enabled                 = true
+
+	username_ldap_attribute = "cn"
+	rdn_ldap_attribute      = "cn"
+	uuid_ldap_attribute     = "entryDN"
+	user_object_classes     = [
+		"simpleSecurityObject",
+		"organizationalRole"
+	]
+	connection_url          = "ldap://openldap"
+	users_dn                = "dc=example,dc=org"
+	bind_dn                 = "cn=admin,dc=example,dc=org"
+	bind_credential         = "admin"
+}
+
+resource "keycloak_ldap_certificate_mapper" "certificate" {
+	name                        = "%s"
+	realm_id                    = data.keycloak_realm.realm.id
+	ldap_user_federation_id     = keycloak_ldap_user_federation.openldap.id
+
+	user_model_attribute        = "usercertificate"
+	ldap_attribute              = "userCertificate"
+
+	is_der_formatted            = %t
+}
+	`, testAccRealmUserFederation.Realm, certificateMapperName, isDerFormatted)
+}
+
+func testKeycloakLdapCertificateMapper_alwaysReadValueFromLdap(certificateMapperName string, alwaysReadValueFromLdap bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_ldap_user_federation" "openldap" {
+	name                    = "openldap"
+	realm_id                = data.keycloak_realm.realm.id
+
+	enabled                 = true
+
+	username_ldap_attribute = "cn"
+	rdn_ldap_attribute      = "cn"
+	uuid_ldap_attribute     = "entryDN"
+	user_object_classes     = [
+		"simpleSecurityObject",
+		"organizationalRole"
+	]
+	connection_url          = "ldap://openldap"
+	users_dn                = "dc=example,dc=org"
+	bind_dn                 = "cn=admin,dc=example,dc=org"
+	bind_credential         = "admin"
+}
+
+resource "keycloak_ldap_certificate_mapper" "certificate" {
+	name                        = "%s"
+	realm_id                    = data.keycloak_realm.realm.id
+	ldap_user_federation_id     = keycloak_ldap_user_federation.openldap.id
+
+	user_model_attribute        = "usercertificate"
+	ldap_attribute              = "userCertificate;binary"
+
+	is_binary_attribute         = true
+	always_read_value_from_ldap = %t
+	is_der_formatted            = true
+}
+	`, testAccRealmUserFederation.Realm, certificateMapperName, alwaysReadValueFromLdap)
+}
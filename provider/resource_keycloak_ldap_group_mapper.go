@@ -21,6 +21,7 @@ func resourceKeycloakLdapGroupMapper() *schema.Resource {
 		ReadContext:   resourceKeycloakLdapGroupMapperRead,
 		UpdateContext: resourceKeycloakLdapGroupMapperUpdate,
 		DeleteContext: resourceKeycloakLdapGroupMapperDelete,
+		CustomizeDiff: ldapSyncOnApplyDiff,
 		// This resource can be imported using {{realm}}/{{provider_id}}/{{mapper_id}}. The Provider and Mapper IDs are displayed in the GUI
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceKeycloakLdapGenericMapperImport,
@@ -118,6 +119,13 @@ func resourceKeycloakLdapGroupMapper() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"sync_on_apply": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"FED_TO_KEYCLOAK", "KEYCLOAK_TO_FED"}, false),
+				Description:  "When set, the groups are synchronized in this direction whenever this mapper is created or changed.",
+			},
+			"sync_result": ldapSyncResultSchema(),
 		},
 	}
 }
@@ -225,7 +233,9 @@ func resourceKeycloakLdapGroupMapperCreate(ctx context.Context, data *schema.Res
 		return diag.FromErr(err)
 	}
 
-	return resourceKeycloakLdapGroupMapperRead(ctx, data, meta)
+	diags := ldapSyncOnCreateDiagnostics(syncLdapMapperOnApply(ctx, keycloakClient, data, ldapGroupMapper.RealmId, ldapGroupMapper.LdapUserFederationId, ldapGroupMapper.Id))
+
+	return append(diags, resourceKeycloakLdapGroupMapperRead(ctx, data, meta)...)
 }
 
 func resourceKeycloakLdapGroupMapperRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -270,7 +280,7 @@ func resourceKeycloakLdapGroupMapperUpdate(ctx context.Context, data *schema.Res
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return diag.FromErr(syncLdapMapperOnApply(ctx, keycloakClient, data, ldapGroupMapper.RealmId, ldapGroupMapper.LdapUserFederationId, ldapGroupMapper.Id))
 }
 
 func resourceKeycloakLdapGroupMapperDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
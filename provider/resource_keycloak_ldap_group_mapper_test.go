@@ -282,6 +282,28 @@ func TestAccKeycloakLdapGroupMapper_groupsPath(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakLdapGroupMapper_syncOnApply(t *testing.T) {
+	t.Parallel()
+
+	groupMapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakLdapGroupMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakLdapGroupMapper_syncOnApply(groupMapperName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakLdapGroupMapperExists("keycloak_ldap_group_mapper.group_mapper"),
+					resource.TestCheckResourceAttr("keycloak_ldap_group_mapper.group_mapper", "sync_result.#", "1"),
+					resource.TestCheckResourceAttr("keycloak_ldap_group_mapper.group_mapper", "sync_result.0.failed", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKeycloakLdapGroupMapperExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		_, err := getLdapGroupMapperFromState(s, resourceName)
@@ -708,3 +730,48 @@ resource "keycloak_ldap_group_mapper" "group_mapper" {
 }
 	`, testAccRealmUserFederation.Realm, groupName, groupMapperName)
 }
+
+func testKeycloakLdapGroupMapper_syncOnApply(groupMapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_ldap_user_federation" "openldap" {
+	name                    = "openldap"
+	realm_id                = data.keycloak_realm.realm.id
+
+	enabled                 = true
+
+	username_ldap_attribute = "cn"
+	rdn_ldap_attribute      = "cn"
+	uuid_ldap_attribute     = "entryDN"
+	user_object_classes     = [
+		"simpleSecurityObject",
+		"organizationalRole"
+	]
+	connection_url          = "ldap://openldap"
+	users_dn                = "dc=example,dc=org"
+	bind_dn                 = "cn=admin,dc=example,dc=org"
+	bind_credential         = "admin"
+}
+
+resource "keycloak_ldap_group_mapper" "group_mapper" {
+	name                        = "%s"
+	realm_id                    = data.keycloak_realm.realm.id
+	ldap_user_federation_id     = keycloak_ldap_user_federation.openldap.id
+
+	ldap_groups_dn                 = "dc=example,dc=org"
+	group_name_ldap_attribute      = "cn"
+	group_object_classes           = [
+		"groupOfNames"
+	]
+	membership_attribute_type      = "DN"
+	membership_ldap_attribute      = "member"
+	membership_user_ldap_attribute = "cn"
+	memberof_ldap_attribute        = "memberOf"
+
+	sync_on_apply                  = "FED_TO_KEYCLOAK"
+}
+	`, testAccRealmUserFederation.Realm, groupMapperName)
+}
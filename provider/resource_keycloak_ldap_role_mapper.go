@@ -21,6 +21,7 @@ func resourceKeycloakLdapRoleMapper() *schema.Resource {
 		ReadContext:   resourceKeycloakLdapRoleMapperRead,
 		UpdateContext: resourceKeycloakLdapRoleMapperUpdate,
 		DeleteContext: resourceKeycloakLdapRoleMapperDelete,
+		CustomizeDiff: ldapSyncOnApplyDiff,
 		// This resource can be imported using {{realm}}/{{provider_id}}/{{mapper_id}}. The Provider and Mapper IDs are displayed in the GUI
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceKeycloakLdapGenericMapperImport,
@@ -102,6 +103,13 @@ func resourceKeycloakLdapRoleMapper() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"sync_on_apply": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"FED_TO_KEYCLOAK", "KEYCLOAK_TO_FED"}, false),
+				Description:  "When set, the roles are synchronized in this direction whenever this mapper is created or changed.",
+			},
+			"sync_result": ldapSyncResultSchema(),
 		},
 	}
 }
@@ -167,7 +175,9 @@ func resourceKeycloakLdapRoleMapperCreate(ctx context.Context, data *schema.Reso
 
 	setLdapRoleMapperData(data, ldapRoleMapper)
 
-	return resourceKeycloakLdapRoleMapperRead(ctx, data, meta)
+	diags := ldapSyncOnCreateDiagnostics(syncLdapMapperOnApply(ctx, keycloakClient, data, ldapRoleMapper.RealmId, ldapRoleMapper.LdapUserFederationId, ldapRoleMapper.Id))
+
+	return append(diags, resourceKeycloakLdapRoleMapperRead(ctx, data, meta)...)
 }
 
 func resourceKeycloakLdapRoleMapperRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -198,7 +208,7 @@ func resourceKeycloakLdapRoleMapperUpdate(ctx context.Context, data *schema.Reso
 
 	setLdapRoleMapperData(data, ldapRoleMapper)
 
-	return nil
+	return diag.FromErr(syncLdapMapperOnApply(ctx, keycloakClient, data, ldapRoleMapper.RealmId, ldapRoleMapper.LdapUserFederationId, ldapRoleMapper.Id))
 }
 
 func resourceKeycloakLdapRoleMapperDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
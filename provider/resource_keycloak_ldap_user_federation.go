@@ -27,6 +27,7 @@ func resourceKeycloakLdapUserFederation() *schema.Resource {
 		ReadContext:   resourceKeycloakLdapUserFederationRead,
 		UpdateContext: resourceKeycloakLdapUserFederationUpdate,
 		DeleteContext: resourceKeycloakLdapUserFederationDelete,
+		CustomizeDiff: ldapSyncOnApplyDiff,
 		// If this resource uses authentication, then this resource must be imported using the syntax {{realm_id}}/{{provider_id}}/{{bind_credential}}
 		// Otherwise, this resource can be imported using {{realm}}/{{provider_id}}.
 		// The Provider ID is displayed in the GUI when editing this provider
@@ -312,6 +313,19 @@ func resourceKeycloakLdapUserFederation() *schema.Resource {
 				ForceNew:    true,
 				Description: "When true, the provider will delete the default mappers which are normally created by Keycloak when creating an LDAP user federation provider.",
 			},
+			"sync_on_apply": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"FULL", "CHANGED_USERS"}, false),
+				Description:  "When set, the users are synchronized whenever this provider is created or changed. Can be FULL or CHANGED_USERS.",
+			},
+			"sync_result": ldapSyncResultSchema(),
+			"test_connection_on_apply": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, Keycloak tests the connection to the LDAP server, and the authentication when bind_dn is set, before this provider is created or updated.",
+			},
 		},
 	}
 }
@@ -486,6 +500,40 @@ func setLdapUserFederationData(data *schema.ResourceData, ldap *keycloak.LdapUse
 	}
 }
 
+// testLdapUserFederationConnectionOnApply lets keycloak check that the ldap server can be reached and that the bind
+// credentials are valid, so a misconfiguration fails the apply instead of the logins of the users
+func testLdapUserFederationConnectionOnApply(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, ldap *keycloak.LdapUserFederation) diag.Diagnostics {
+	if !data.Get("test_connection_on_apply").(bool) {
+		return nil
+	}
+
+	realmId := data.Get("realm_id").(string)
+
+	err := keycloakClient.TestLdapUserFederationConnection(ctx, realmId, ldap, keycloak.LdapTestActionConnection)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to connect to ldap server %s", ldap.ConnectionUrl),
+			Detail:   err.Error(),
+		}}
+	}
+
+	if ldap.BindDn == "" {
+		return nil
+	}
+
+	err = keycloakClient.TestLdapUserFederationConnection(ctx, realmId, ldap, keycloak.LdapTestActionAuthentication)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to authenticate to ldap server %s as %s", ldap.ConnectionUrl, ldap.BindDn),
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
+
 func resourceKeycloakLdapUserFederationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
@@ -503,6 +551,10 @@ func resourceKeycloakLdapUserFederationCreate(ctx context.Context, data *schema.
 		return diag.FromErr(err)
 	}
 
+	if diags := testLdapUserFederationConnectionOnApply(ctx, keycloakClient, data, ldap); diags.HasError() {
+		return diags
+	}
+
 	err = keycloakClient.NewLdapUserFederation(ctx, realmId, ldap)
 	if err != nil {
 		return diag.FromErr(err)
@@ -517,7 +569,9 @@ func resourceKeycloakLdapUserFederationCreate(ctx context.Context, data *schema.
 
 	setLdapUserFederationData(data, ldap, realmId)
 
-	return resourceKeycloakLdapUserFederationRead(ctx, data, meta)
+	diags := ldapSyncOnCreateDiagnostics(syncLdapUserFederationOnApply(ctx, keycloakClient, data, realmId, ldap.Id))
+
+	return append(diags, resourceKeycloakLdapUserFederationRead(ctx, data, meta)...)
 }
 
 func resourceKeycloakLdapUserFederationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -554,6 +608,12 @@ func resourceKeycloakLdapUserFederationUpdate(ctx context.Context, data *schema.
 		return diag.FromErr(err)
 	}
 
+	if diags := testLdapUserFederationConnectionOnApply(ctx, keycloakClient, data, ldap); diags.HasError() {
+		// nothing was sent to keycloak, the state keeps the previous values so that the change is planned again
+		data.Partial(true)
+		return diags
+	}
+
 	err = keycloakClient.UpdateLdapUserFederation(ctx, realmId, ldap)
 	if err != nil {
 		return diag.FromErr(err)
@@ -561,7 +621,7 @@ func resourceKeycloakLdapUserFederationUpdate(ctx context.Context, data *schema.
 
 	setLdapUserFederationData(data, ldap, realmId)
 
-	return nil
+	return diag.FromErr(syncLdapUserFederationOnApply(ctx, keycloakClient, data, realmId, ldap.Id))
 }
 
 func resourceKeycloakLdapUserFederationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -598,6 +658,7 @@ func resourceKeycloakLdapUserFederationImport(ctx context.Context, d *schema.Res
 
 	d.Set("realm_id", realmId)
 	d.Set("delete_default_mappers", false) // this is only valid on create, so we assume this is false
+	d.Set("test_connection_on_apply", false)
 	d.SetId(id)
 
 	diagnostics := resourceKeycloakLdapUserFederationRead(ctx, d, meta)
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
@@ -140,6 +143,269 @@ func TestAccKeycloakLdapUserFederation_deleteDefaultMappers(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakLdapUserFederation_syncOnApply(t *testing.T) {
+	t.Parallel()
+	ldapName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakLdapUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakLdapUserFederation_syncOnApply(ldapName, "FULL", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_ldap_user_federation.openldap", "sync_result.#", "1"),
+					resource.TestCheckResourceAttr("keycloak_ldap_user_federation.openldap", "sync_result.0.failed", "0"),
+				),
+			},
+			{
+				Config: testKeycloakLdapUserFederation_syncOnApply(ldapName, "CHANGED_USERS", 200),
+				Check:  resource.TestCheckResourceAttr("keycloak_ldap_user_federation.openldap", "sync_result.0.ignored", "false"),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakLdapUserFederation_syncOnApply(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	ldapResource := testAccProvider.ResourcesMap["keycloak_ldap_user_federation"]
+	data := schema.TestResourceDataRaw(t, ldapResource.Schema, map[string]interface{}{
+		"realm_id":                testAccRealm.Realm,
+		"name":                    acctest.RandomWithPrefix("tf-unit"),
+		"username_ldap_attribute": "cn",
+		"rdn_ldap_attribute":      "cn",
+		"uuid_ldap_attribute":     "entryDN",
+		"user_object_classes":     []interface{}{"person"},
+		"connection_url":          "ldap://openldap",
+		"users_dn":                "dc=example,dc=org",
+		"sync_on_apply":           "FULL",
+	})
+	if diags := ldapResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = ldapResource.DeleteContext(testCtx, data, keycloakClient)
+	})
+
+	if data.Get("sync_result.0.ignored") != false || data.Get("sync_result.0.status") == "" {
+		t.Errorf("unexpected result of the sync on create %v", data.Get("sync_result"))
+	}
+
+	// a disabled provider isn't synchronized
+	data.Set("enabled", false)
+	if diags := ldapResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Get("sync_result.0.ignored") != true {
+		t.Errorf("expected the sync of a disabled provider to be ignored, got %v", data.Get("sync_result"))
+	}
+
+	mapper := &keycloak.LdapGroupMapper{
+		Name:                      acctest.RandomWithPrefix("tf-unit"),
+		RealmId:                   testAccRealm.Realm,
+		LdapUserFederationId:      data.Id(),
+		LdapGroupsDn:              "dc=example,dc=org",
+		GroupNameLdapAttribute:    "cn",
+		GroupObjectClasses:        []string{"groupOfNames"},
+		MembershipLdapAttribute:   "member",
+		MembershipAttributeType:   "DN",
+		Mode:                      "READ_ONLY",
+		UserRolesRetrieveStrategy: "LOAD_GROUPS_BY_MEMBER_ATTRIBUTE",
+	}
+	if err := keycloakClient.NewLdapGroupMapper(testCtx, mapper); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := keycloakClient.SyncUserStorageMapper(testCtx, testAccRealm.Realm, data.Id(), mapper.Id, keycloak.UserStorageMapperSyncFedToKeycloak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Ignored || result.Failed != 0 {
+		t.Errorf("unexpected result of the mapper sync %+v", result)
+	}
+
+	if _, err := keycloakClient.SyncUserStorageMapper(testCtx, testAccRealm.Realm, data.Id(), data.Id(), keycloak.UserStorageMapperSyncFedToKeycloak); !keycloak.ErrorIs404(err) {
+		t.Errorf("expected syncing an unknown mapper to fail with a 404, got %v", err)
+	}
+
+	if err := setLdapSyncResultData(data, &keycloak.UserStorageSyncResult{Added: 3, Failed: 2, Status: "3 imported users, 2 users failed sync!"}); err == nil {
+		t.Error("expected a sync with failures to fail")
+	}
+}
+
+func TestUnitKeycloakLdapUserFederation_syncOnApplyFailures(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	testAccServer.FailLdapSync("ldap://sync-failures.unit", 2)
+
+	ldapResource := testAccProvider.ResourcesMap["keycloak_ldap_user_federation"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"realm_id":                testAccRealm.Realm,
+		"name":                    acctest.RandomWithPrefix("tf-unit"),
+		"username_ldap_attribute": "cn",
+		"rdn_ldap_attribute":      "cn",
+		"uuid_ldap_attribute":     "entryDN",
+		"user_object_classes":     []interface{}{"person"},
+		"connection_url":          "ldap://sync-failures.unit",
+		"users_dn":                "dc=example,dc=org",
+		"sync_on_apply":           "FULL",
+	})
+
+	diff, err := ldapResource.Diff(testCtx, nil, config, keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the provider exists once the sync fails, failing the create would taint it and replacing it deletes its users
+	state, diags := ldapResource.Apply(testCtx, nil, diff, keycloakClient)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected the failed sync to be a warning on create, got %v", diags)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteLdapUserFederation(testCtx, testAccRealm.Realm, state.ID)
+	})
+	if state.Tainted || state.Attributes["sync_result.0.failed"] != "2" {
+		t.Fatalf("expected the created provider to record the failed sync, got %v", state)
+	}
+
+	// the sync is planned again until it succeeds, an update failing to sync keeps failing the apply
+	for _, failed := range []int{2, 0} {
+		testAccServer.FailLdapSync("ldap://sync-failures.unit", failed)
+
+		diff, err = ldapResource.Diff(testCtx, state, config, keycloakClient)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff == nil || !diff.Attributes["sync_result.#"].NewComputed {
+			t.Fatalf("expected the sync to be planned again after %s, got %v", state.Attributes["sync_result.0.status"], diff)
+		}
+
+		state, diags = ldapResource.Apply(testCtx, state, diff, keycloakClient)
+		if diags.HasError() != (failed > 0) {
+			t.Errorf("unexpected result of the update with %d failed entries: %v", failed, diags)
+		}
+		if state == nil || state.Attributes["sync_result.0.failed"] != strconv.Itoa(failed) {
+			t.Fatalf("expected the update to record the result of the sync, got %v", state)
+		}
+	}
+
+	diff, err = ldapResource.Diff(testCtx, state, config, keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("expected no sync to be planned once it succeeded, got %v", diff)
+	}
+}
+
+func TestAccKeycloakLdapUserFederation_testConnectionOnApply(t *testing.T) {
+	t.Parallel()
+	ldapName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakLdapUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakLdapUserFederation_testConnectionOnApply(ldapName, "ldap://openldap", "wrong"),
+				ExpectError: regexp.MustCompile("unable to authenticate to ldap server ldap://openldap as cn=admin,dc=example,dc=org"),
+			},
+			{
+				Config:      testKeycloakLdapUserFederation_testConnectionOnApply(ldapName, "ldap://unknown", "admin"),
+				ExpectError: regexp.MustCompile("unable to connect to ldap server ldap://unknown"),
+			},
+			{
+				Config: testKeycloakLdapUserFederation_testConnectionOnApply(ldapName, "ldap://openldap", "admin"),
+				Check:  testAccCheckKeycloakLdapUserFederationExists("keycloak_ldap_user_federation.openldap"),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakLdapUserFederation_testConnectionOnApply(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	testAccServer.AddLdapServer("ldap://openldap.unit", map[string]string{"cn=admin,dc=example,dc=org": "admin"})
+
+	ldapResource := testAccProvider.ResourcesMap["keycloak_ldap_user_federation"]
+	attributes := map[string]interface{}{
+		"realm_id":                 testAccRealm.Realm,
+		"name":                     acctest.RandomWithPrefix("tf-unit"),
+		"username_ldap_attribute":  "cn",
+		"rdn_ldap_attribute":       "cn",
+		"uuid_ldap_attribute":      "entryDN",
+		"user_object_classes":      []interface{}{"person"},
+		"connection_url":           "ldap://unknown.unit",
+		"users_dn":                 "dc=example,dc=org",
+		"bind_dn":                  "cn=admin,dc=example,dc=org",
+		"bind_credential":          "wrong",
+		"test_connection_on_apply": true,
+	}
+	data := schema.TestResourceDataRaw(t, ldapResource.Schema, attributes)
+
+	diags := ldapResource.CreateContext(testCtx, data, keycloakClient)
+	if !diags.HasError() || diags[0].Summary != "unable to connect to ldap server ldap://unknown.unit" {
+		t.Fatalf("expected the connection test to fail, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "CommunicationError") {
+		t.Errorf("expected the error of the server in the diagnostic, got %s", diags[0].Detail)
+	}
+
+	data.Set("connection_url", "ldap://openldap.unit")
+	diags = ldapResource.CreateContext(testCtx, data, keycloakClient)
+	if !diags.HasError() || diags[0].Summary != "unable to authenticate to ldap server ldap://openldap.unit as cn=admin,dc=example,dc=org" {
+		t.Fatalf("expected the authentication test to fail, got %v", diags)
+	}
+	if data.Id() != "" {
+		t.Fatal("expected the provider not to be created when the connection test fails")
+	}
+
+	data.Set("bind_credential", "admin")
+	if diags := ldapResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = ldapResource.DeleteContext(testCtx, data, keycloakClient)
+	})
+
+	// the state keeps the previous credential when the authentication test fails, so that the change is planned again
+	state := data.State()
+	attributes["connection_url"] = "ldap://openldap.unit"
+	attributes["bind_credential"] = "wrong"
+	diff, err := ldapResource.Diff(testCtx, state, terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newState, diags := ldapResource.Apply(testCtx, state, diff, keycloakClient)
+	if !diags.HasError() {
+		t.Error("expected the update to fail the authentication test")
+	}
+	if newState == nil || newState.Attributes["bind_credential"] != "admin" {
+		t.Errorf("expected the state to keep the previous credential, got %v", newState)
+	}
+
+	ldap, err := keycloakClient.GetLdapUserFederation(testCtx, testAccRealm.Realm, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keycloak falls back to the stored credential when the masked one is sent
+	ldap.BindCredential = "**********"
+	if err := keycloakClient.TestLdapUserFederationConnection(testCtx, testAccRealm.Realm, ldap, keycloak.LdapTestActionAuthentication); err != nil {
+		t.Errorf("expected the stored credential to be used, got %v", err)
+	}
+
+	// without a bind dn, only the connection is tested
+	data.Set("bind_dn", "")
+	data.Set("bind_credential", "")
+	if diags := ldapResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
 func generateRandomLdapKerberos(enabled bool) *keycloak.LdapUserFederation {
 	connectionTimeout, _ := keycloak.GetDurationStringFromMilliseconds(strconv.Itoa(acctest.RandIntRange(1, 3600) * 1000))
 	readTimeout, _ := keycloak.GetDurationStringFromMilliseconds(strconv.Itoa(acctest.RandIntRange(1, 3600) * 1000))
@@ -954,3 +1220,62 @@ resource "keycloak_ldap_user_federation" "openldap" {
 }
 	`, testAccRealmUserFederation.Realm, ldap)
 }
+
+func testKeycloakLdapUserFederation_syncOnApply(ldap, syncOnApply string, batchSizeForSync int) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_ldap_user_federation" "openldap" {
+	name                    = "%s"
+	realm_id                = data.keycloak_realm.realm.id
+
+	enabled                 = true
+
+	username_ldap_attribute = "cn"
+	rdn_ldap_attribute      = "cn"
+	uuid_ldap_attribute     = "entryDN"
+	user_object_classes     = [
+		"simpleSecurityObject",
+		"organizationalRole"
+	]
+	connection_url          = "ldap://openldap"
+	users_dn                = "dc=example,dc=org"
+	bind_dn                 = "cn=admin,dc=example,dc=org"
+	bind_credential         = "admin"
+
+	batch_size_for_sync     = %d
+	sync_on_apply           = "%s"
+}
+	`, testAccRealmUserFederation.Realm, ldap, batchSizeForSync, syncOnApply)
+}
+
+func testKeycloakLdapUserFederation_testConnectionOnApply(ldap, connectionUrl, bindCredential string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_ldap_user_federation" "openldap" {
+	name                     = "%s"
+	realm_id                 = data.keycloak_realm.realm.id
+
+	enabled                  = true
+
+	username_ldap_attribute  = "cn"
+	rdn_ldap_attribute       = "cn"
+	uuid_ldap_attribute      = "entryDN"
+	user_object_classes      = [
+		"simpleSecurityObject",
+		"organizationalRole"
+	]
+	connection_url           = "%s"
+	users_dn                 = "dc=example,dc=org"
+	bind_dn                  = "cn=admin,dc=example,dc=org"
+	bind_credential          = "%s"
+
+	test_connection_on_apply = true
+}
+	`, testAccRealmUserFederation.Realm, ldap, connectionUrl, bindCredential)
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcAppleIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:    "apple",
+		displayName:   "Apple",
+		defaultScopes: "openid name email",
+		schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Apple developer team the Services ID belongs to.",
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the private key used to sign the client secret. The private key itself is the client_secret.",
+			},
+		},
+		config: map[string]string{
+			"team_id": "teamId",
+			"key_id":  "keyId",
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcAppleIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_apple_identity_provider.apple"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_apple_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcAppleIdentityProvider_basic("team-one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"teamId": "team-one"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "apple"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcAppleIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_apple_identity_provider.apple"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_apple_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcAppleIdentityProvider_basic("team-one"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"teamId": "team-one"}),
+			},
+			{
+				Config: testKeycloakOidcAppleIdentityProvider_basic("team-two"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"teamId": "team-two"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/apple",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcAppleIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_apple_identity_provider" "apple" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "apple"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	team_id = "%s"
+	key_id        = "my-key"
+}
+	`, testAccRealm.Realm, value)
+}
@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcBitbucketIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:    "bitbucket",
+		displayName:   "Bitbucket",
+		defaultScopes: "account email",
+	})
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcBitbucketIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_bitbucket_identity_provider.bitbucket"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_bitbucket_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcBitbucketIdentityProvider_basic("account email"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "account email"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "bitbucket"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcBitbucketIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_bitbucket_identity_provider.bitbucket"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_bitbucket_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcBitbucketIdentityProvider_basic("account email"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "account email"}),
+			},
+			{
+				Config: testKeycloakOidcBitbucketIdentityProvider_basic("account"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "account"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/bitbucket",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcBitbucketIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_bitbucket_identity_provider" "bitbucket" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "bitbucket"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	default_scopes = "%s"
+}
+	`, testAccRealm.Realm, value)
+}
@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcGitlabIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:    "gitlab",
+		displayName:   "GitLab",
+		defaultScopes: "openid read_user",
+	})
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcGitlabIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_gitlab_identity_provider.gitlab"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_gitlab_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcGitlabIdentityProvider_basic("openid read_user"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "openid read_user"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "gitlab"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcGitlabIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_gitlab_identity_provider.gitlab"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_gitlab_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcGitlabIdentityProvider_basic("openid read_user"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "openid read_user"}),
+			},
+			{
+				Config: testKeycloakOidcGitlabIdentityProvider_basic("openid read_api"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "openid read_api"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/gitlab",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcGitlabIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_gitlab_identity_provider" "gitlab" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "gitlab"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	default_scopes = "%s"
+}
+	`, testAccRealm.Realm, value)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// the endpoints of the upstream keycloak, which are imported from its discovery document when discovery_url is set,
+// mapped to their key in the identity provider config
+var keycloakOidcIdentityProviderDiscoveredAttributes = map[string]string{
+	"authorization_url": "authorizationUrl",
+	"token_url":         "tokenUrl",
+	"user_info_url":     "userInfoUrl",
+	"logout_url":        "logoutUrl",
+	"jwks_url":          "jwksUrl",
+	"issuer":            "issuer",
+}
+
+func resourceKeycloakOidcKeycloakIdentityProvider() *schema.Resource {
+	keycloakOidcResource := resourceKeycloakOidcIdentityProvider()
+
+	keycloakOidcResource.Schema["provider_id"].Default = "keycloak-oidc"
+	keycloakOidcResource.Schema["provider_id"].Description = "provider id, is always keycloak-oidc, unless you have a custom implementation"
+	keycloakOidcResource.Schema["discovery_url"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		Description:  "The URL of the OpenID Connect discovery document of the upstream Keycloak realm. When set, the endpoints which aren't configured explicitly are imported from it, and kept in sync with it.",
+	}
+
+	// the endpoints become computed, so they can be planned from the discovery document
+	discovery := &identityProviderImport{
+		sources: []string{"realm", "provider_id", "discovery_url"},
+		values:  getKeycloakOidcIdentityProviderDiscoveredValues,
+	}
+	for attribute := range keycloakOidcIdentityProviderDiscoveredAttributes {
+		discovery.attributes = append(discovery.attributes, attribute)
+	}
+	discovery.apply(keycloakOidcResource)
+
+	keycloakOidcResource.ValidateRawResourceConfigFuncs = append(keycloakOidcResource.ValidateRawResourceConfigFuncs,
+		requiredWithoutAll(cty.GetAttrPath("authorization_url"), []cty.Path{cty.GetAttrPath("discovery_url")}),
+		requiredWithoutAll(cty.GetAttrPath("token_url"), []cty.Path{cty.GetAttrPath("discovery_url")}),
+	)
+
+	return keycloakOidcResource
+}
+
+// getKeycloakOidcIdentityProviderDiscoveredValues returns the endpoints found in the discovery document by attribute,
+// they are all empty without a discovery document
+func getKeycloakOidcIdentityProviderDiscoveredValues(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data identityProviderImportData) (map[string]string, error) {
+	values := map[string]string{}
+
+	discoveryUrl := data.Get("discovery_url").(string)
+	if discoveryUrl == "" {
+		return values, nil
+	}
+
+	config, err := keycloakClient.ImportIdentityProviderConfig(ctx, data.Get("realm").(string), data.Get("provider_id").(string), discoveryUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error importing the discovery document %s: %w", discoveryUrl, err)
+	}
+
+	for attribute, configKey := range keycloakOidcIdentityProviderDiscoveredAttributes {
+		values[attribute] = config[configKey]
+	}
+
+	return values, nil
+}
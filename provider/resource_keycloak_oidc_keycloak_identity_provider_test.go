@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakOidcKeycloakIdentityProvider_basic(t *testing.T) {
+	t.Parallel()
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_oidc_keycloak_identity_provider.keycloak"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_keycloak_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcKeycloakIdentityProvider_basic(alias),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"tokenUrl": "https://example.com/token"}),
+					resource.TestCheckResourceAttr(resourceName, "provider_id", "keycloak-oidc"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/" + alias,
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcKeycloakIdentityProvider_discovery(t *testing.T) {
+	t.Parallel()
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_oidc_keycloak_identity_provider.keycloak"
+	issuer := fmt.Sprintf("%s/realms/%s", os.Getenv("KEYCLOAK_URL"), testAccRealm.Realm)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_keycloak_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcKeycloakIdentityProvider_discovery(alias, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "issuer", issuer),
+					resource.TestCheckResourceAttr(resourceName, "token_url", issuer+"/protocol/openid-connect/token"),
+					resource.TestCheckResourceAttr(resourceName, "jwks_url", issuer+"/protocol/openid-connect/certs"),
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"authorizationUrl": issuer + "/protocol/openid-connect/auth"}),
+				),
+			},
+			{
+				// endpoints configured explicitly take precedence over the discovery document
+				Config: testKeycloakOidcKeycloakIdentityProvider_discovery(alias, "https://example.com/logout"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "logout_url", "https://example.com/logout"),
+					resource.TestCheckResourceAttr(resourceName, "token_url", issuer+"/protocol/openid-connect/token"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/" + alias,
+				ImportStateVerifyIgnore: []string{"client_secret", "discovery_url"},
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakOidcKeycloakIdentityProvider_discovery(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	upstream := testAccServer.URL + "/realms/upstream"
+	document := map[string]string{
+		"issuer":                 upstream,
+		"authorization_endpoint": upstream + "/auth",
+		"token_endpoint":         upstream + "/token",
+		"jwks_uri":               upstream + "/certs",
+	}
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(document)
+	}))
+	t.Cleanup(discovery.Close)
+
+	alias := acctest.RandomWithPrefix("tf-unit")
+	attributes := map[string]interface{}{
+		"realm":         testAccRealm.Realm,
+		"alias":         alias,
+		"client_id":     "id",
+		"client_secret": "secret",
+		"discovery_url": discovery.URL,
+	}
+
+	keycloakOidcResource := testAccProvider.ResourcesMap["keycloak_oidc_keycloak_identity_provider"]
+	data := schema.TestResourceDataRaw(t, keycloakOidcResource.Schema, attributes)
+	if diags := keycloakOidcResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteIdentityProvider(testCtx, testAccRealm.Realm, alias)
+	})
+
+	identityProvider, err := keycloakClient.GetIdentityProvider(testCtx, testAccRealm.Realm, alias)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identityProvider.ProviderId != "keycloak-oidc" {
+		t.Errorf("expected provider id keycloak-oidc, got %s", identityProvider.ProviderId)
+	}
+	if err := checkIdentityProviderConfigValues(identityProvider, map[string]string{
+		"issuer":           upstream,
+		"authorizationUrl": upstream + "/auth",
+		"tokenUrl":         upstream + "/token",
+		"jwksUrl":          upstream + "/certs",
+		"useJwksUrl":       "true",
+	}); err != nil {
+		t.Error(err)
+	}
+
+	// the plan follows the discovery document when the upstream keycloak changes its endpoints
+	document["token_endpoint"] = upstream + "/protocol/openid-connect/token"
+
+	diff, err := keycloakOidcResource.Diff(testCtx, data.State(), terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["token_url"] == nil || diff.Attributes["token_url"].New != document["token_endpoint"] {
+		t.Fatalf("expected a diff of token_url, got %v", diff)
+	}
+	for attribute := range diff.Attributes {
+		if attribute != "token_url" {
+			t.Errorf("expected only token_url to change, %s changed as well", attribute)
+		}
+	}
+
+	// an endpoint removed from the discovery document is removed from the identity provider as well
+	delete(document, "jwks_uri")
+	if diags := keycloakOidcResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if jwksUrl := data.Get("jwks_url").(string); jwksUrl != "" {
+		t.Errorf("expected jwks_url to be removed, got %s", jwksUrl)
+	}
+
+	// a realm created in the same apply doesn't exist yet when planning, the endpoints are imported on create
+	attributes["realm"] = acctest.RandomWithPrefix("tf-unit")
+	diff, err = keycloakOidcResource.Diff(testCtx, &terraform.InstanceState{}, terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["token_url"] == nil || !diff.Attributes["token_url"].NewComputed {
+		t.Errorf("expected token_url to be unknown until the realm exists, got %v", diff)
+	}
+}
+
+func testKeycloakOidcKeycloakIdentityProvider_basic(alias string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_keycloak_identity_provider" "keycloak" {
+	realm             = data.keycloak_realm.realm.id
+	alias             = "%s"
+	authorization_url = "https://example.com/auth"
+	token_url         = "https://example.com/token"
+	client_id         = "example_id"
+	client_secret     = "example_token"
+}
+	`, testAccRealm.Realm, alias)
+}
+
+func testKeycloakOidcKeycloakIdentityProvider_discovery(alias, logoutUrl string) string {
+	logoutUrlAttribute := ""
+	if logoutUrl != "" {
+		logoutUrlAttribute = fmt.Sprintf("logout_url = \"%s\"", logoutUrl)
+	}
+
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_keycloak_identity_provider" "keycloak" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "%s"
+	discovery_url = "%s/realms/%s/.well-known/openid-configuration"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	%s
+}
+	`, testAccRealm.Realm, alias, os.Getenv("KEYCLOAK_URL"), testAccRealm.Realm, logoutUrlAttribute)
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcKubernetesIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:               "kubernetes",
+		displayName:              "Kubernetes",
+		withoutClientCredentials: true,
+		schema: map[string]*schema.Schema{
+			"issuer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The issuer of the Kubernetes service account tokens, which is used to discover the keys validating them.",
+			},
+		},
+		config: map[string]string{
+			"issuer": "issuer",
+		},
+	})
+}
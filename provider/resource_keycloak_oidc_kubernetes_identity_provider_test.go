@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcKubernetesIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_kubernetes_identity_provider.kubernetes"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_kubernetes_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcKubernetesIdentityProvider_basic("https://kubernetes.default.svc"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"issuer": "https://kubernetes.default.svc"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "kubernetes"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcKubernetesIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_kubernetes_identity_provider.kubernetes"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_kubernetes_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcKubernetesIdentityProvider_basic("https://kubernetes.default.svc"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"issuer": "https://kubernetes.default.svc"}),
+			},
+			{
+				Config: testKeycloakOidcKubernetesIdentityProvider_basic("https://kubernetes.example.com"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"issuer": "https://kubernetes.example.com"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/kubernetes",
+				ImportStateVerifyIgnore: []string{},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcKubernetesIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_kubernetes_identity_provider" "kubernetes" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "kubernetes"
+
+	issuer = "%s"
+}
+	`, testAccRealm.Realm, value)
+}
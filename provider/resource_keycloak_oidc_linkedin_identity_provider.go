@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcLinkedinIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:    "linkedin-openid-connect",
+		displayName:   "LinkedIn",
+		defaultScopes: "openid profile email",
+	})
+}
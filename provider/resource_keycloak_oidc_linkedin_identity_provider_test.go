@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcLinkedinIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_linkedin_identity_provider.linkedin"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_linkedin_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcLinkedinIdentityProvider_basic("openid profile"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "openid profile"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "linkedin"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcLinkedinIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_linkedin_identity_provider.linkedin"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_linkedin_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcLinkedinIdentityProvider_basic("openid profile"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "openid profile"}),
+			},
+			{
+				Config: testKeycloakOidcLinkedinIdentityProvider_basic("openid profile email"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"defaultScope": "openid profile email"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/linkedin",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcLinkedinIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_linkedin_identity_provider" "linkedin" {
+	realm          = data.keycloak_realm.realm.id
+	alias          = "linkedin"
+	client_id      = "example_id"
+	client_secret  = "example_token"
+	default_scopes = "%s"
+}
+	`, testAccRealm.Realm, value)
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcMicrosoftIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:    "microsoft",
+		displayName:   "Microsoft",
+		defaultScopes: "openid profile email",
+		schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Microsoft Entra ID tenant users are allowed to log in from. Users of all tenants and personal Microsoft accounts can log in when empty.",
+			},
+		},
+		config: map[string]string{
+			"tenant_id": "tenantId",
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcMicrosoftIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_microsoft_identity_provider.microsoft"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_microsoft_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcMicrosoftIdentityProvider_basic("tenant-one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"tenantId": "tenant-one"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "microsoft"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcMicrosoftIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_microsoft_identity_provider.microsoft"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_microsoft_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcMicrosoftIdentityProvider_basic("tenant-one"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"tenantId": "tenant-one"}),
+			},
+			{
+				Config: testKeycloakOidcMicrosoftIdentityProvider_basic("tenant-two"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"tenantId": "tenant-two"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/microsoft",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcMicrosoftIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_microsoft_identity_provider" "microsoft" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "microsoft"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	tenant_id = "%s"
+}
+	`, testAccRealm.Realm, value)
+}
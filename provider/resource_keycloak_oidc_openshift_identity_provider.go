@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKeycloakOidcOpenshiftIdentityProvider() *schema.Resource {
+	return resourceKeycloakSocialIdentityProvider(&socialIdentityProvider{
+		providerId:    "openshift-v4",
+		displayName:   "OpenShift",
+		defaultScopes: "user:full",
+		schema: map[string]*schema.Schema{
+			"base_url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The base URL of the OpenShift API server, e.g. https://api.cluster.example.com:6443",
+			},
+		},
+		config: map[string]string{
+			"base_url": "baseUrl",
+		},
+	})
+}
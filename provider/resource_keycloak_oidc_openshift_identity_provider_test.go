@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakOidcOpenshiftIdentityProvider_basic(t *testing.T) {
+	resourceName := "keycloak_oidc_openshift_identity_provider.openshift"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_openshift_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcOpenshiftIdentityProvider_basic("https://api.one.example.com:6443"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"baseUrl": "https://api.one.example.com:6443"}),
+					resource.TestCheckResourceAttr(resourceName, "alias", "openshift"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOidcOpenshiftIdentityProvider_update(t *testing.T) {
+	resourceName := "keycloak_oidc_openshift_identity_provider.openshift"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy("keycloak_oidc_openshift_identity_provider"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOidcOpenshiftIdentityProvider_basic("https://api.one.example.com:6443"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"baseUrl": "https://api.one.example.com:6443"}),
+			},
+			{
+				Config: testKeycloakOidcOpenshiftIdentityProvider_basic("https://api.two.example.com:6443"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderHasConfig(resourceName, map[string]string{"baseUrl": "https://api.two.example.com:6443"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/openshift",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func testKeycloakOidcOpenshiftIdentityProvider_basic(value string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_openshift_identity_provider" "openshift" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "openshift"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	base_url = "%s"
+}
+	`, testAccRealm.Realm, value)
+}
@@ -2,6 +2,9 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"reflect"
@@ -102,6 +105,45 @@ func resourceKeycloakOpenidClient() *schema.Resource {
 				// No validation is performed since Keycloak plugins can register custom client authenticators
 				Default: "client-secret",
 			},
+			"use_jwks_url": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the keys verifying the JWTs signed by the client are fetched from jwks_url.",
+			},
+			"jwks_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL of the JSON Web Key Set of the client, used when use_jwks_url is true.",
+			},
+			"jwks": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateJwks,
+				Description:  "The JSON Web Key Set of the client, verifying the JWTs signed by the client when use_jwks_url is false.",
+			},
+			"client_certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateCertificatePem,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return old == formatCertificate(new)
+				},
+				Description: "The PEM encoded certificate verifying the JWTs signed by the client when it has no JSON Web Key Set. It is uploaded to the jwt.credential certificate of the client.",
+			},
+			"x509_subject_dn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The subject DN of the certificate of the client, or a regular expression matching it, when using the client-x509 authenticator.",
+			},
+			"x509_allow_regex_pattern_comparison": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, x509_subject_dn is a regular expression matched against the subject DN of the certificate of the client.",
+			},
 			"standard_flow_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -428,6 +470,12 @@ func getOpenidClientFromData(data *schema.ResourceData) (*keycloak.OpenidClient,
 			UseRefreshTokensClientCredentials:        types.KeycloakBoolQuoted(data.Get("use_refresh_tokens_client_credentials").(bool)),
 			StandardTokenExchangeEnabled:             types.KeycloakBoolQuoted(data.Get("standard_token_exchange_enabled").(bool)),
 			AllowRefreshTokenInStandardTokenExchange: data.Get("allow_refresh_token_in_standard_token_exchange").(string),
+			UseJwksUrl:                               types.KeycloakBoolQuoted(data.Get("use_jwks_url").(bool)),
+			JwksUrl:                                  data.Get("jwks_url").(string),
+			UseJwksString:                            types.KeycloakBoolQuoted(data.Get("jwks").(string) != ""),
+			JwksString:                               data.Get("jwks").(string),
+			X509SubjectDn:                            data.Get("x509_subject_dn").(string),
+			X509AllowRegexPatternComparison:          types.KeycloakBoolQuoted(data.Get("x509_allow_regex_pattern_comparison").(bool)),
 			FrontchannelLogoutUrl:                    data.Get("frontchannel_logout_url").(string),
 			BackchannelLogoutUrl:                     data.Get("backchannel_logout_url").(string),
 			BackchannelLogoutRevokeOfflineTokens:     types.KeycloakBoolQuoted(data.Get("backchannel_logout_revoke_offline_sessions").(bool)),
@@ -473,6 +521,15 @@ func getOpenidClientFromData(data *schema.ResourceData) (*keycloak.OpenidClient,
 		}
 	}
 
+	if openidClient.Attributes.UseJwksUrl {
+		if openidClient.Attributes.JwksUrl == "" {
+			return nil, errors.New("jwks_url is required when use_jwks_url is true")
+		}
+		if openidClient.Attributes.JwksString != "" {
+			return nil, errors.New("jwks cannot be set when use_jwks_url is true")
+		}
+	}
+
 	// access type
 	if accessType := data.Get("access_type").(string); accessType == "PUBLIC" {
 		openidClient.PublicClient = true
@@ -550,6 +607,15 @@ func setOpenidClientData(ctx context.Context, keycloakClient *keycloak.KeycloakC
 	data.Set("use_refresh_tokens_client_credentials", client.Attributes.UseRefreshTokensClientCredentials)
 	data.Set("standard_token_exchange_enabled", client.Attributes.StandardTokenExchangeEnabled)
 	data.Set("allow_refresh_token_in_standard_token_exchange", client.Attributes.AllowRefreshTokenInStandardTokenExchange)
+	data.Set("use_jwks_url", client.Attributes.UseJwksUrl)
+	data.Set("jwks_url", client.Attributes.JwksUrl)
+	if client.Attributes.UseJwksString {
+		data.Set("jwks", client.Attributes.JwksString)
+	} else {
+		data.Set("jwks", "")
+	}
+	data.Set("x509_subject_dn", client.Attributes.X509SubjectDn)
+	data.Set("x509_allow_regex_pattern_comparison", client.Attributes.X509AllowRegexPatternComparison)
 	data.Set("oauth2_device_authorization_grant_enabled", client.Attributes.Oauth2DeviceAuthorizationGrantEnabled)
 	data.Set("oauth2_device_code_lifespan", client.Attributes.Oauth2DeviceCodeLifespan)
 	data.Set("oauth2_device_polling_interval", client.Attributes.Oauth2DevicePollingInterval)
@@ -565,6 +631,11 @@ func setOpenidClientData(ctx context.Context, keycloakClient *keycloak.KeycloakC
 	data.Set("backchannel_logout_session_required", client.Attributes.BackchannelLogoutSessionRequired)
 	setExtraConfigData(data, client.Attributes.ExtraConfig)
 
+	// the uploaded certificate is read from the attributes of the client, left alone when they aren't known yet
+	if certificate, ok := client.Attributes.ExtraConfig[keycloak.OpenidClientJwtCredentialAttribute+".certificate"].(string); ok {
+		data.Set("client_certificate", certificate)
+	}
+
 	if client.AuthorizationServicesEnabled {
 		data.Set("resource_server_id", client.Id)
 
@@ -653,6 +724,11 @@ func resourceKeycloakOpenidClientCreate(ctx context.Context, data *schema.Resour
 		}
 	}
 
+	err = uploadOpenidClientCertificate(ctx, keycloakClient, data, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	err = setOpenidClientData(ctx, keycloakClient, data, client)
 	if err != nil {
 		return diag.FromErr(err)
@@ -707,6 +783,11 @@ func resourceKeycloakOpenidClientUpdate(ctx context.Context, data *schema.Resour
 		return diag.FromErr(err)
 	}
 
+	err = uploadOpenidClientCertificate(ctx, keycloakClient, data, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	err = setOpenidClientData(ctx, keycloakClient, data, client)
 	if err != nil {
 		return diag.FromErr(err)
@@ -766,3 +847,55 @@ func evaluateSecretRegeneration(ctx context.Context, keycloakClient *keycloak.Ke
 
 	return nil
 }
+
+// uploadOpenidClientCertificate uploads client_certificate when it changes. It goes through the upload endpoint rather
+// than the attributes of the client, so keycloak parses the PEM like it does for the admin console.
+func uploadOpenidClientCertificate(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, client *keycloak.OpenidClient) error {
+	certificate := data.Get("client_certificate").(string)
+	if certificate == "" || !data.HasChange("client_certificate") {
+		return nil
+	}
+
+	_, err := keycloakClient.UploadOpenidClientCertificate(ctx, client.RealmId, client.Id, keycloak.OpenidClientJwtCredentialAttribute, certificate)
+	if err != nil {
+		return fmt.Errorf("error uploading the certificate of client %s: %s", client.ClientId, err)
+	}
+
+	return nil
+}
+
+// validateCertificatePem accepts a single PEM encoded X.509 certificate
+func validateCertificatePem(value interface{}, key string) ([]string, []error) {
+	block, rest := pem.Decode([]byte(value.(string)))
+	if block == nil || block.Type != "CERTIFICATE" || strings.TrimSpace(string(rest)) != "" {
+		return nil, []error{fmt.Errorf("expected %s to be a single PEM encoded certificate", key)}
+	}
+
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a PEM encoded certificate: %s", key, err)}
+	}
+
+	return nil, nil
+}
+
+// validateJwks accepts a JSON Web Key Set of at least one key, every key having a key type
+func validateJwks(value interface{}, key string) ([]string, []error) {
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(value.(string)), &jwks); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a JSON Web Key Set: %s", key, err)}
+	}
+
+	if len(jwks.Keys) == 0 {
+		return nil, []error{fmt.Errorf("expected %s to contain at least one key", key)}
+	}
+
+	for i, jwk := range jwks.Keys {
+		if kty, _ := jwk["kty"].(string); kty == "" {
+			return nil, []error{fmt.Errorf("expected key %d of %s to have a kty", i, key)}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOpenidClientRoleScopeMappings_basic(t *testing.T) {
+	t.Parallel()
+
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	rolePrefix := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_openid_client_role_scope_mappings.mappings"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOpenidClientRoleScopeMappings(clientId, rolePrefix, "[keycloak_role.realm_role[0].id, keycloak_role.client_role[0].id, keycloak_role.client_role[1].id]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "role_ids.#", "3"),
+					testAccCheckKeycloakRoleScopeMappings(resourceName, 3),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// roles put in scope out of band are planned to be removed
+				PreConfig: func() {
+					client, err := keycloakClient.GetOpenidClientByClientId(testCtx, testAccRealm.Realm, clientId)
+					if err != nil {
+						t.Fatal(err)
+					}
+					role, err := keycloakClient.GetRoleByName(testCtx, testAccRealm.Realm, "", rolePrefix+"-realm-1")
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := keycloakClient.CreateRoleScopeMapping(testCtx, testAccRealm.Realm, client.Id, "", role); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testKeycloakOpenidClientRoleScopeMappings(clientId, rolePrefix, "[keycloak_role.realm_role[0].id, keycloak_role.client_role[0].id, keycloak_role.client_role[1].id]"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testKeycloakOpenidClientRoleScopeMappings(clientId, rolePrefix, "[keycloak_role.realm_role[1].id, keycloak_role.client_role[1].id]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "role_ids.#", "2"),
+					testAccCheckKeycloakRoleScopeMappings(resourceName, 2),
+				),
+			},
+			{
+				Config: testKeycloakOpenidClientRoleScopeMappings(clientId, rolePrefix, "[]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "role_ids.#", "0"),
+					testAccCheckKeycloakRoleScopeMappings(resourceName, 0),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitKeycloakOpenidClientRoleScopeMappings_batch checks that the roles are added and removed with a request per
+// role container, rather than per role
+func TestUnitKeycloakOpenidClientRoleScopeMappings_batch(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	prefix := acctest.RandomWithPrefix("tf-unit")
+	client := &keycloak.OpenidClient{
+		RealmId:      testAccRealm.Realm,
+		ClientId:     prefix,
+		PublicClient: true,
+		Enabled:      true,
+	}
+	if err := keycloakClient.NewOpenidClient(testCtx, client); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteOpenidClient(testCtx, testAccRealm.Realm, client.Id)
+	})
+
+	var roleIds []interface{}
+	var roles []*keycloak.Role
+	for i := 0; i < 6; i++ {
+		role := &keycloak.Role{
+			RealmId: testAccRealm.Realm,
+			Name:    fmt.Sprintf("%s-%d", prefix, i),
+		}
+		if i%2 == 1 {
+			role.ClientId = client.Id
+		}
+		if err := keycloakClient.CreateRole(testCtx, role); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = keycloakClient.DeleteRole(testCtx, testAccRealm.Realm, role.Id)
+		})
+		roles = append(roles, role)
+		if i < 5 {
+			roleIds = append(roleIds, role.Id)
+		}
+	}
+
+	mappingsResource := testAccProvider.ResourcesMap["keycloak_openid_client_role_scope_mappings"]
+	attributes := map[string]interface{}{
+		"realm_id":  testAccRealm.Realm,
+		"client_id": client.Id,
+		"role_ids":  roleIds,
+	}
+	data := schema.TestResourceDataRaw(t, mappingsResource.Schema, attributes)
+
+	requests := len(testAccServer.Requests())
+	if diags := mappingsResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if posts := countScopeMappingRequests(testAccServer.Requests()[requests:], "POST"); posts != 2 {
+		t.Errorf("expected the roles to be added with 2 requests, got %d", posts)
+	}
+	if count := data.Get("role_ids").(*schema.Set).Len(); count != 5 {
+		t.Errorf("expected 5 roles in scope, got %d", count)
+	}
+
+	// a role put in scope out of band shows up as drift
+	if err := keycloakClient.CreateRoleScopeMapping(testCtx, testAccRealm.Realm, client.Id, "", roles[5]); err != nil {
+		t.Fatal(err)
+	}
+	if diags := mappingsResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !data.Get("role_ids").(*schema.Set).Contains(roles[5].Id) {
+		t.Errorf("expected the role put in scope out of band to be read, got %v", data.Get("role_ids"))
+	}
+
+	diff, err := mappingsResource.Diff(testCtx, data.State(), terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["role_ids.#"] == nil || diff.Attributes["role_ids.#"].New != "5" {
+		t.Fatalf("expected a diff of role_ids, got %v", diff)
+	}
+
+	// applying the configuration again removes it
+	data = schema.TestResourceDataRaw(t, mappingsResource.Schema, attributes)
+	requests = len(testAccServer.Requests())
+	if diags := mappingsResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if deletes := countScopeMappingRequests(testAccServer.Requests()[requests:], "DELETE"); deletes != 1 {
+		t.Errorf("expected the role to be removed with 1 request, got %d", deletes)
+	}
+	if count := data.Get("role_ids").(*schema.Set).Len(); count != 5 {
+		t.Errorf("expected 5 roles in scope, got %d", count)
+	}
+
+	requests = len(testAccServer.Requests())
+	if diags := mappingsResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if deletes := countScopeMappingRequests(testAccServer.Requests()[requests:], "DELETE"); deletes != 2 {
+		t.Errorf("expected the roles to be removed with 2 requests, got %d", deletes)
+	}
+
+	roleMappings, err := keycloakClient.GetRoleScopeMappings(testCtx, testAccRealm.Realm, client.Id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roleMappings.RealmMappings) != 0 || len(roleMappings.ClientMappings) != 0 {
+		t.Errorf("expected no role in scope once deleted, got %v", roleMappings)
+	}
+}
+
+func countScopeMappingRequests(requests []string, method string) int {
+	count := 0
+	for _, request := range requests {
+		if strings.HasPrefix(request, method+" ") && strings.Contains(request, "/scope-mappings/") {
+			count++
+		}
+	}
+
+	return count
+}
+
+// testAccCheckKeycloakRoleScopeMappings checks the number of roles in scope of the client or client scope of resourceName
+func testAccCheckKeycloakRoleScopeMappings(resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+		clientId := rs.Primary.Attributes["client_id"]
+		clientScopeId := rs.Primary.Attributes["client_scope_id"]
+
+		roleMappings, err := keycloakClient.GetRoleScopeMappings(testCtx, realmId, clientId, clientScopeId)
+		if err != nil {
+			return err
+		}
+
+		roles, err := flattenRoleMapping(roleMappings)
+		if err != nil {
+			return err
+		}
+
+		if len(roles) != expected {
+			return fmt.Errorf("expected %d roles in scope, got %v", expected, roles)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOpenidClientRoleScopeMappings(clientId, rolePrefix, roleIds string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id           = data.keycloak_realm.realm.id
+	client_id          = "%s"
+	access_type        = "PUBLIC"
+	full_scope_allowed = false
+}
+
+resource "keycloak_role" "realm_role" {
+	count    = 2
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s-realm-${count.index}"
+}
+
+resource "keycloak_role" "client_role" {
+	count     = 2
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client.id
+	name      = "%s-client-${count.index}"
+}
+
+resource "keycloak_openid_client_role_scope_mappings" "mappings" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client.id
+	role_ids  = %s
+}
+	`, testAccRealm.Realm, clientId, rolePrefix, rolePrefix, roleIds)
+}
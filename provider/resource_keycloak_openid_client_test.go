@@ -9,8 +9,10 @@ import (
 
 	"github.com/keycloak/terraform-provider-keycloak/keycloak/types"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
@@ -176,6 +178,233 @@ func TestAccKeycloakOpenidClient_clientAuthenticatorType(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakOpenidClient_jwks(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOpenidClientDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOpenidClient_jwksUrl(clientId, "https://client.example.com/jwks"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOpenidClientAuthenticatorType("keycloak_openid_client.client", "client-jwt"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "use_jwks_url", "true"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "jwks_url", "https://client.example.com/jwks"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "jwks", ""),
+				),
+			},
+			{
+				Config: testKeycloakOpenidClient_jwks(clientId, "tf-acc-key"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "use_jwks_url", "false"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "jwks_url", ""),
+					resource.TestMatchResourceAttr("keycloak_openid_client.client", "jwks", regexp.MustCompile(`"kid":"tf-acc-key"`)),
+				),
+			},
+			{
+				ResourceName:            "keycloak_openid_client.client",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     testAccRealm.Realm + "/",
+				ImportStateVerifyIgnore: []string{"exclude_session_state_from_auth_response", "exclude_issuer_from_auth_response"},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOpenidClient_jwksValidation(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOpenidClientDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakOpenidClient_jwksString(clientId, `{\"keys\": []}`),
+				ExpectError: regexp.MustCompile("expected jwks to contain at least one key"),
+			},
+			{
+				Config:      testKeycloakOpenidClient_jwksString(clientId, `{\"keys\": [{\"kid\": \"key\"}]}`),
+				ExpectError: regexp.MustCompile("expected key 0 of jwks to have a kty"),
+			},
+			{
+				Config:      testKeycloakOpenidClient_jwksString(clientId, "not json"),
+				ExpectError: regexp.MustCompile("expected jwks to be a JSON Web Key Set"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOpenidClient_clientCertificate(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	_, certificate := generateKeyAndCert(2048)
+	_, rotatedCertificate := generateKeyAndCert(2048)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOpenidClientDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOpenidClient_clientCertificate(clientId, certificate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "client_certificate", certificate),
+					testAccCheckKeycloakOpenidClientCertificate("keycloak_openid_client.client", certificate),
+				),
+			},
+			{
+				Config: testKeycloakOpenidClient_clientCertificate(clientId, rotatedCertificate),
+				Check:  testAccCheckKeycloakOpenidClientCertificate("keycloak_openid_client.client", rotatedCertificate),
+			},
+			{
+				ResourceName:            "keycloak_openid_client.client",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     testAccRealm.Realm + "/",
+				ImportStateVerifyIgnore: []string{"exclude_session_state_from_auth_response", "exclude_issuer_from_auth_response"},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOpenidClient_clientCertificateValidation(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOpenidClientDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakOpenidClient_clientCertificate(clientId, "bm90IGEgY2VydGlmaWNhdGU="),
+				ExpectError: regexp.MustCompile("expected client_certificate to be a PEM encoded certificate"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOpenidClient_x509(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOpenidClientDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOpenidClient_x509(clientId, "CN=client.example.com", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOpenidClientAuthenticatorType("keycloak_openid_client.client", "client-x509"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "x509_subject_dn", "CN=client.example.com"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "x509_allow_regex_pattern_comparison", "false"),
+				),
+			},
+			{
+				Config: testKeycloakOpenidClient_x509(clientId, "CN=(.*).example.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "x509_subject_dn", "CN=(.*).example.com"),
+					resource.TestCheckResourceAttr("keycloak_openid_client.client", "x509_allow_regex_pattern_comparison", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakOpenidClient_clientCertificate(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	_, certificate := generateKeyAndCert(2048)
+	_, rotatedCertificate := generateKeyAndCert(2048)
+	attributes := map[string]interface{}{
+		"realm_id":                  testAccRealm.Realm,
+		"client_id":                 acctest.RandomWithPrefix("tf-unit"),
+		"access_type":               "CONFIDENTIAL",
+		"client_authenticator_type": "client-jwt",
+		"client_certificate":        testCertificatePem(certificate),
+	}
+
+	clientResource := testAccProvider.ResourcesMap["keycloak_openid_client"]
+	data := schema.TestResourceDataRaw(t, clientResource.Schema, attributes)
+	if diags := clientResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteOpenidClient(testCtx, testAccRealm.Realm, data.Id())
+	})
+
+	uploaded, err := keycloakClient.GetOpenidClientCertificate(testCtx, testAccRealm.Realm, data.Id(), keycloak.OpenidClientJwtCredentialAttribute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uploaded.Certificate != certificate {
+		t.Errorf("expected the certificate to be uploaded, got %s", uploaded.Certificate)
+	}
+	if data.Get("client_certificate").(string) != certificate {
+		t.Errorf("expected client_certificate to be read back without its PEM armor, got %s", data.Get("client_certificate"))
+	}
+
+	// the certificate keycloak returns is the one in the configuration
+	diff, err := clientResource.Diff(testCtx, data.State(), terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && diff.Attributes["client_certificate"] != nil {
+		t.Errorf("expected no diff of client_certificate, got %v", diff.Attributes["client_certificate"])
+	}
+
+	// changing another attribute doesn't upload the certificate again, rotating it does
+	state := data.State()
+	for _, step := range []struct {
+		attribute, value string
+		uploads          int
+	}{
+		{"description", "updated", 0},
+		{"client_certificate", testCertificatePem(rotatedCertificate), 1},
+	} {
+		attributes[step.attribute] = step.value
+		diff, err := clientResource.Diff(testCtx, state, terraform.NewResourceConfigRaw(attributes), keycloakClient)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		requests := len(testAccServer.Requests())
+		var diags diag.Diagnostics
+		if state, diags = clientResource.Apply(testCtx, state, diff, keycloakClient); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if uploads := countCertificateUploads(testAccServer.Requests()[requests:]); uploads != step.uploads {
+			t.Errorf("expected %d upload of the certificate when changing %s, got %d", step.uploads, step.attribute, uploads)
+		}
+	}
+
+	uploaded, err = keycloakClient.GetOpenidClientCertificate(testCtx, testAccRealm.Realm, data.Id(), keycloak.OpenidClientJwtCredentialAttribute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uploaded.Certificate != rotatedCertificate {
+		t.Errorf("expected the rotated certificate to be uploaded, got %s", uploaded.Certificate)
+	}
+}
+
+func countCertificateUploads(requests []string) int {
+	count := 0
+	for _, request := range requests {
+		if strings.HasPrefix(request, "POST ") && strings.HasSuffix(request, "/upload-certificate") {
+			count++
+		}
+	}
+
+	return count
+}
+
 func TestAccKeycloakOpenidClient_updateInPlace(t *testing.T) {
 	t.Parallel()
 	clientId := acctest.RandomWithPrefix("tf-acc")
@@ -1233,6 +1462,26 @@ func testAccCheckKeycloakOpenidClientAuthenticatorType(resourceName string, auth
 	}
 }
 
+func testAccCheckKeycloakOpenidClientCertificate(resourceName, certificate string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := getOpenidClientFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		uploaded, err := keycloakClient.GetOpenidClientCertificate(testCtx, client.RealmId, client.Id, keycloak.OpenidClientJwtCredentialAttribute)
+		if err != nil {
+			return err
+		}
+
+		if uploaded.Certificate != certificate {
+			return fmt.Errorf("expected openid client to have certificate %s, but got %s", certificate, uploaded.Certificate)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckKeycloakOpenidClientBelongsToRealm(resourceName, realm string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client, err := getOpenidClientFromState(s, resourceName)
@@ -1709,6 +1958,103 @@ resource "keycloak_openid_client" "client" {
 	`, testAccRealm.Realm, clientId, authType)
 }
 
+func testKeycloakOpenidClient_jwksUrl(clientId, jwksUrl string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                  = data.keycloak_realm.realm.id
+	client_id                 = "%s"
+	access_type               = "CONFIDENTIAL"
+	client_authenticator_type = "client-jwt"
+	use_jwks_url              = true
+	jwks_url                  = "%s"
+}
+	`, testAccRealm.Realm, clientId, jwksUrl)
+}
+
+func testKeycloakOpenidClient_jwks(clientId, kid string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                  = data.keycloak_realm.realm.id
+	client_id                 = "%s"
+	access_type               = "CONFIDENTIAL"
+	client_authenticator_type = "client-jwt"
+	jwks = jsonencode({
+		keys = [{
+			kty = "RSA"
+			kid = "%s"
+			use = "sig"
+			alg = "RS256"
+			e   = "AQAB"
+			n   = "sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri23bOdgWp4Dy1WlUzewbgBHod5pcM9H95GQRV3JDXboIRROSBigeC5yjU1hGzHHyXss8UDprecbAYxknTcQkhslANGRUZmdTOQ5qTRsLAt6BTYuyvVRdhS8exSZEy_c4gs_7svlJJQ4H9_NxsiIoLwAEk7-Q3UXERGYw_75IDrGA84-lA_-Ct4eTlXHBIY2EaV7t7LjJaynVJCpkv4LKjTTAumiGUIuQhrNhZLuF_RJLqHpM2kgWFLU7-VTdL1VbC2tejvcI2BlMkEpk1BzBZI0KQB0GaDWFLN-aEAw3vRw"
+		}]
+	})
+}
+	`, testAccRealm.Realm, clientId, kid)
+}
+
+func testKeycloakOpenidClient_jwksString(clientId, jwks string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                  = data.keycloak_realm.realm.id
+	client_id                 = "%s"
+	access_type               = "CONFIDENTIAL"
+	client_authenticator_type = "client-jwt"
+	jwks                      = "%s"
+}
+	`, testAccRealm.Realm, clientId, jwks)
+}
+
+func testKeycloakOpenidClient_clientCertificate(clientId, certificate string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                  = data.keycloak_realm.realm.id
+	client_id                 = "%s"
+	access_type               = "CONFIDENTIAL"
+	client_authenticator_type = "client-jwt"
+	client_certificate        = <<EOT
+%sEOT
+}
+	`, testAccRealm.Realm, clientId, testCertificatePem(certificate))
+}
+
+func testKeycloakOpenidClient_x509(clientId, subjectDn string, allowRegexPatternComparison bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id                            = data.keycloak_realm.realm.id
+	client_id                           = "%s"
+	access_type                         = "CONFIDENTIAL"
+	client_authenticator_type           = "client-x509"
+	x509_subject_dn                     = "%s"
+	x509_allow_regex_pattern_comparison = %t
+}
+	`, testAccRealm.Realm, clientId, subjectDn, allowRegexPatternComparison)
+}
+
+// testCertificatePem wraps a base64 encoded certificate, as returned by generateKeyAndCert, in its PEM armor
+func testCertificatePem(certificate string) string {
+	return fmt.Sprintf("-----BEGIN CERTIFICATE-----\n%s\n-----END CERTIFICATE-----\n", certificate)
+}
+
 func testKeycloakOpenidClient_pkceChallengeMethod(clientId, pkceChallengeMethod string) string {
 
 	return fmt.Sprintf(`
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationMemberCreate,
+		ReadContext:   resourceKeycloakOrganizationMemberRead,
+		DeleteContext: resourceKeycloakOrganizationMemberDelete,
+		// This resource can be imported using {{realm}}/{{organization_id}}/{{user_id}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Realm ID.",
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"invite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "When true, an invitation email is sent to the user instead of adding them to the organization directly.",
+			},
+			"membership_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "MANAGED or UNMANAGED, empty while an invitation is pending.",
+			},
+		},
+	}
+}
+
+func organizationMemberId(organizationId, userId string) string {
+	return fmt.Sprintf("%s/%s", organizationId, userId)
+}
+
+func resourceKeycloakOrganizationMemberCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	organizationId := data.Get("organization_id").(string)
+	userId := data.Get("user_id").(string)
+
+	var err error
+	if data.Get("invite").(bool) {
+		err = keycloakClient.InviteExistingUserToOrganization(ctx, realm, organizationId, userId)
+	} else {
+		err = keycloakClient.AddOrganizationMember(ctx, realm, organizationId, userId)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(organizationMemberId(organizationId, userId))
+
+	return resourceKeycloakOrganizationMemberRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMemberRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	organizationId := data.Get("organization_id").(string)
+	userId := data.Get("user_id").(string)
+
+	member, err := keycloakClient.GetOrganizationMember(ctx, realm, organizationId, userId)
+	if err != nil {
+		// invited users only become members once they accept the invitation
+		if keycloak.ErrorIs404(err) && data.Get("invite").(bool) {
+			if _, err := keycloakClient.GetOrganization(ctx, realm, organizationId); err != nil {
+				return handleNotFoundError(ctx, err, data)
+			}
+
+			data.Set("membership_type", "")
+			return nil
+		}
+
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	data.Set("membership_type", member.MembershipType)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMemberDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	organizationId := data.Get("organization_id").(string)
+	userId := data.Get("user_id").(string)
+
+	// removing a managed member would delete the user, like keycloak_organization_members it's left in the organization
+	if data.Get("membership_type").(string) == keycloak.OrganizationMembershipManaged {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("user %s is a managed member of organization %s and was only removed from the state", userId, organizationId),
+			Detail:   "removing a managed member from an organization deletes the user, remove it with the Keycloak admin console if that's intended",
+		}}
+	}
+
+	err := keycloakClient.RemoveOrganizationMember(ctx, realm, organizationId, userId)
+	// there's nothing to remove if the invitation was never accepted
+	if err != nil && keycloak.ErrorIs404(err) && data.Get("invite").(bool) {
+		return nil
+	}
+
+	return diag.FromErr(err)
+}
+
+func resourceKeycloakOrganizationMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realm}}/{{organizationId}}/{{userId}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationMember(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("user_id", parts[2])
+	d.Set("invite", false)
+	d.SetId(organizationMemberId(parts[1], parts[2]))
+
+	diagnostics := resourceKeycloakOrganizationMemberRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, fmt.Errorf("Error reading organization member: %s", diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOrganizationMember_basic(t *testing.T) {
+	skipIfVersionIsLessThan(testCtx, t, keycloakClient, keycloak.Version_26)
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationMemberDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationMember_basic(organizationName, username),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationMemberExists("keycloak_organization_member.member"),
+					resource.TestCheckResourceAttr("keycloak_organization_member.member", "membership_type", keycloak.OrganizationMembershipUnmanaged),
+				),
+			},
+			{
+				ResourceName:      "keycloak_organization_member.member",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					rs := state.RootModule().Resources["keycloak_organization_member.member"]
+
+					return fmt.Sprintf("%s/%s/%s", testAccRealm.Realm, rs.Primary.Attributes["organization_id"], rs.Primary.Attributes["user_id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOrganizationMember_createAfterManualDestroy(t *testing.T) {
+	skipIfVersionIsLessThan(testCtx, t, keycloakClient, keycloak.Version_26)
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	var organizationId, userId string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationMemberDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationMember_basic(organizationName, username),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationMemberExists("keycloak_organization_member.member"),
+					func(state *terraform.State) error {
+						rs := state.RootModule().Resources["keycloak_organization_member.member"]
+						organizationId = rs.Primary.Attributes["organization_id"]
+						userId = rs.Primary.Attributes["user_id"]
+
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.RemoveOrganizationMember(testCtx, testAccRealm.Realm, organizationId, userId)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakOrganizationMember_basic(organizationName, username),
+				Check:  testAccCheckKeycloakOrganizationMemberExists("keycloak_organization_member.member"),
+			},
+		},
+	})
+}
+
+// TestUnitKeycloakOrganizationMember_deleteManaged checks that destroying a managed member doesn't delete the user
+func TestUnitKeycloakOrganizationMember_deleteManaged(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	memberResource := testAccProvider.ResourcesMap["keycloak_organization_member"]
+	data := schema.TestResourceDataRaw(t, memberResource.Schema, map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": "organization",
+		"user_id":         "managed-user",
+	})
+	data.SetId(organizationMemberId("organization", "managed-user"))
+	data.Set("membership_type", keycloak.OrganizationMembershipManaged)
+
+	requests := len(testAccServer.Requests())
+	diags := memberResource.DeleteContext(testCtx, data, keycloakClient)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning when destroying a managed member, got %v", diags)
+	}
+	for _, request := range testAccServer.Requests()[requests:] {
+		if strings.Contains(request, "/organizations/") {
+			t.Errorf("expected the managed member to be left in the organization, got %s", request)
+		}
+	}
+}
+
+func testAccCheckKeycloakOrganizationMemberExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm"]
+		organizationId := rs.Primary.Attributes["organization_id"]
+		userId := rs.Primary.Attributes["user_id"]
+
+		_, err := keycloakClient.GetOrganizationMember(testCtx, realm, organizationId, userId)
+		if err != nil {
+			return fmt.Errorf("user %s is not a member of organization %s: %s", userId, organizationId, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakOrganizationMemberDestroy() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "keycloak_organization_member" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm"]
+			organizationId := rs.Primary.Attributes["organization_id"]
+			userId := rs.Primary.Attributes["user_id"]
+
+			member, _ := keycloakClient.GetOrganizationMember(testCtx, realm, organizationId, userId)
+			if member != nil {
+				return fmt.Errorf("user %s is still a member of organization %s", userId, organizationId)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOrganizationMember_basic(organizationName, username string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_organization" "organization" {
+	name  = "%s"
+	realm = data.keycloak_realm.realm.id
+
+	domain {
+		name = "example.com"
+	}
+}
+
+resource "keycloak_user" "user" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+	email    = "%s@example.com"
+}
+
+resource "keycloak_organization_member" "member" {
+	realm           = data.keycloak_realm.realm.id
+	organization_id = keycloak_organization.organization.id
+	user_id         = keycloak_user.user.id
+}
+	`, testAccRealm.Realm, organizationName, username, username)
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationMembersReconcile,
+		ReadContext:   resourceKeycloakOrganizationMembersRead,
+		DeleteContext: resourceKeycloakOrganizationMembersDelete,
+		UpdateContext: resourceKeycloakOrganizationMembersReconcile,
+		// This resource can be imported using {{realm}}/{{organization_id}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Realm ID.",
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Required: true,
+			},
+			"managed_user_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Computed:    true,
+				Description: "Members brokered from the organization's identity providers. They are never removed by this resource.",
+			},
+		},
+	}
+}
+
+func organizationMembersId(realm, organizationId string) string {
+	return fmt.Sprintf("%s/organization-members/%s", realm, organizationId)
+}
+
+func resourceKeycloakOrganizationMembersReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	organizationId := data.Get("organization_id").(string)
+	tfUserIds := data.Get("user_ids").(*schema.Set)
+
+	keycloakMembers, err := keycloakClient.GetOrganizationMembers(ctx, realm, organizationId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, keycloakMember := range keycloakMembers {
+		if tfUserIds.Contains(keycloakMember.Id) {
+			// the user is already a member, whether managed or not
+			tfUserIds.Remove(keycloakMember.Id)
+		} else if keycloakMember.MembershipType != keycloak.OrganizationMembershipManaged {
+			// removing a managed member would delete the user, so only unmanaged members are removed
+			err = keycloakClient.RemoveOrganizationMember(ctx, realm, organizationId, keycloakMember.Id)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	// at this point, `tfUserIds` only contains users that aren't members yet
+	for _, userId := range tfUserIds.List() {
+		err = keycloakClient.AddOrganizationMember(ctx, realm, organizationId, userId.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(organizationMembersId(realm, organizationId))
+
+	return resourceKeycloakOrganizationMembersRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMembersRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	organizationId := data.Get("organization_id").(string)
+	tfUserIds := data.Get("user_ids").(*schema.Set)
+
+	keycloakMembers, err := keycloakClient.GetOrganizationMembers(ctx, realm, organizationId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var userIds, managedUserIds []string
+	for _, keycloakMember := range keycloakMembers {
+		if keycloakMember.MembershipType == keycloak.OrganizationMembershipManaged {
+			managedUserIds = append(managedUserIds, keycloakMember.Id)
+
+			// managed members are only part of `user_ids` if they're explicitly listed
+			if !tfUserIds.Contains(keycloakMember.Id) {
+				continue
+			}
+		}
+
+		userIds = append(userIds, keycloakMember.Id)
+	}
+
+	data.Set("user_ids", userIds)
+	data.Set("managed_user_ids", managedUserIds)
+	data.SetId(organizationMembersId(realm, organizationId))
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMembersDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	organizationId := data.Get("organization_id").(string)
+	managedUserIds := data.Get("managed_user_ids").(*schema.Set)
+
+	for _, userId := range data.Get("user_ids").(*schema.Set).List() {
+		if managedUserIds.Contains(userId) {
+			continue
+		}
+
+		err := keycloakClient.RemoveOrganizationMember(ctx, realm, organizationId, userId.(string))
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realm}}/{{organizationId}}")
+	}
+
+	_, err := keycloakClient.GetOrganization(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.SetId(organizationMembersId(parts[0], parts[1]))
+
+	diagnostics := resourceKeycloakOrganizationMembersRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, fmt.Errorf("Error reading organization members: %s", diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOrganizationMembers_basic(t *testing.T) {
+	skipIfVersionIsLessThan(testCtx, t, keycloakClient, keycloak.Version_26)
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+	usernames := []string{acctest.RandomWithPrefix("tf-acc"), acctest.RandomWithPrefix("tf-acc"), acctest.RandomWithPrefix("tf-acc")}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationMembers_basic(organizationName, usernames, []int{0, 1}),
+				Check:  testAccCheckKeycloakOrganizationHasMembers("keycloak_organization_members.members", usernames[0], usernames[1]),
+			},
+			{
+				ResourceName:      "keycloak_organization_members.members",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					rs := state.RootModule().Resources["keycloak_organization_members.members"]
+
+					return fmt.Sprintf("%s/%s", testAccRealm.Realm, rs.Primary.Attributes["organization_id"]), nil
+				},
+			},
+			{
+				Config: testKeycloakOrganizationMembers_basic(organizationName, usernames, []int{1, 2}),
+				Check:  testAccCheckKeycloakOrganizationHasMembers("keycloak_organization_members.members", usernames[1], usernames[2]),
+			},
+			{
+				Config: testKeycloakOrganizationMembers_basic(organizationName, usernames, []int{}),
+				Check:  testAccCheckKeycloakOrganizationHasMembers("keycloak_organization_members.members"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOrganizationMembers_authoritative(t *testing.T) {
+	skipIfVersionIsLessThan(testCtx, t, keycloakClient, keycloak.Version_26)
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+	usernames := []string{acctest.RandomWithPrefix("tf-acc"), acctest.RandomWithPrefix("tf-acc")}
+
+	var organizationId string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationMembers_basic(organizationName, usernames, []int{0}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationHasMembers("keycloak_organization_members.members", usernames[0]),
+					func(state *terraform.State) error {
+						organizationId = state.RootModule().Resources["keycloak_organization.organization"].Primary.ID
+
+						return nil
+					},
+				),
+			},
+			{
+				// a member added outside of terraform is removed on the next apply
+				PreConfig: func() {
+					user, err := keycloakClient.GetUserByUsername(testCtx, testAccRealm.Realm, usernames[1])
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					err = keycloakClient.AddOrganizationMember(testCtx, testAccRealm.Realm, organizationId, user.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakOrganizationMembers_basic(organizationName, usernames, []int{0}),
+				Check:  testAccCheckKeycloakOrganizationHasMembers("keycloak_organization_members.members", usernames[0]),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationHasMembers(resourceName string, usernames ...string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm"]
+		organizationId := rs.Primary.Attributes["organization_id"]
+
+		members, err := keycloakClient.GetOrganizationMembers(testCtx, realm, organizationId)
+		if err != nil {
+			return err
+		}
+
+		var memberUsernames []string
+		for _, member := range members {
+			memberUsernames = append(memberUsernames, member.Username)
+		}
+
+		sort.Strings(memberUsernames)
+		sort.Strings(usernames)
+
+		if strings.Join(memberUsernames, ",") != strings.Join(usernames, ",") {
+			return fmt.Errorf("expected organization %s to have members %v, got %v", organizationId, usernames, memberUsernames)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOrganizationMembers_basic(organizationName string, usernames []string, memberIndexes []int) string {
+	var users, userIds strings.Builder
+	for i, username := range usernames {
+		users.WriteString(fmt.Sprintf(`
+resource "keycloak_user" "user_%d" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+}
+`, i, username))
+	}
+	for _, i := range memberIndexes {
+		userIds.WriteString(fmt.Sprintf("\n\t\tkeycloak_user.user_%d.id,", i))
+	}
+
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_organization" "organization" {
+	name  = "%s"
+	realm = data.keycloak_realm.realm.id
+
+	domain {
+		name = "example.com"
+	}
+}
+%s
+resource "keycloak_organization_members" "members" {
+	realm           = data.keycloak_realm.realm.id
+	organization_id = keycloak_organization.organization.id
+
+	user_ids = [%s
+	]
+}
+	`, testAccRealm.Realm, organizationName, users.String(), userIds.String())
+}
@@ -0,0 +1,524 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+const (
+	clientPolicyConditionProviderType = "org.keycloak.services.clientpolicy.condition.ClientPolicyConditionProvider"
+
+	clientPolicyConditionClientRoles             = "client-roles"
+	clientPolicyConditionClientScopes            = "client-scopes"
+	clientPolicyConditionClientAccessType        = "client-access-type"
+	clientPolicyConditionClientUpdaterSourceHost = "client-updater-source-host"
+	clientPolicyConditionAnyClient               = "any-client"
+
+	clientPolicyConditionNegativeLogic = "is-negative-logic"
+)
+
+var (
+	keycloakRealmClientPolicyClientScopeTypes = []string{"Default", "Optional"}
+	keycloakRealmClientPolicyAccessTypes      = []string{"confidential", "public", "bearer-only"}
+)
+
+func realmClientPolicyConditionSchema(attributes map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: mergeSchemas(attributes, map[string]*schema.Schema{
+				"negative_logic": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "When true, the condition matches the clients that do not fulfill it.",
+				},
+			}),
+		},
+	}
+}
+
+func resourceKeycloakRealmClientPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientPolicyCreate,
+		ReadContext:   resourceKeycloakRealmClientPolicyRead,
+		DeleteContext: resourceKeycloakRealmClientPolicyDelete,
+		UpdateContext: resourceKeycloakRealmClientPolicyUpdate,
+		// This resource can be imported using {{realmId}}/{{policyName}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"profiles": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"client_roles_condition": realmClientPolicyConditionSchema(map[string]*schema.Schema{
+				"roles": {
+					Type:     schema.TypeSet,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Required: true,
+				},
+			}),
+			"client_scopes_condition": realmClientPolicyConditionSchema(map[string]*schema.Schema{
+				"scopes": {
+					Type:     schema.TypeSet,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Required: true,
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "Default",
+					ValidateFunc: validation.StringInSlice(keycloakRealmClientPolicyClientScopeTypes, false),
+				},
+			}),
+			"client_access_type_condition": realmClientPolicyConditionSchema(map[string]*schema.Schema{
+				"access_types": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(keycloakRealmClientPolicyAccessTypes, false),
+					},
+					Required: true,
+				},
+			}),
+			"client_updater_source_host_condition": realmClientPolicyConditionSchema(map[string]*schema.Schema{
+				"trusted_hosts": {
+					Type:     schema.TypeSet,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Required: true,
+				},
+			}),
+			"any_client_condition": realmClientPolicyConditionSchema(map[string]*schema.Schema{}),
+			"condition": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Conditions of other types than the ones supported by a dedicated block.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"configuration": {
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func realmClientPolicyId(realmId, name string) string {
+	return fmt.Sprintf("%s/%s", realmId, name)
+}
+
+func newRealmClientPolicyCondition(name string, conditionMap map[string]interface{}, configuration map[string]interface{}) keycloak.RealmClientPolicyProfilePolicyCondition {
+	configuration[clientPolicyConditionNegativeLogic] = conditionMap["negative_logic"].(bool)
+
+	return keycloak.RealmClientPolicyProfilePolicyCondition{
+		Name:          name,
+		Configuration: configuration,
+	}
+}
+
+func getRealmClientPolicyFromData(data *schema.ResourceData) *keycloak.RealmClientPolicyProfilePolicy {
+	conditions := []keycloak.RealmClientPolicyProfilePolicyCondition{}
+	profiles := make([]string, 0)
+
+	for _, condition := range data.Get("client_roles_condition").([]interface{}) {
+		conditionMap := condition.(map[string]interface{})
+		conditions = append(conditions, newRealmClientPolicyCondition(clientPolicyConditionClientRoles, conditionMap, map[string]interface{}{
+			"roles": interfaceSliceToStringSlice(conditionMap["roles"].(*schema.Set).List()),
+		}))
+	}
+
+	for _, condition := range data.Get("client_scopes_condition").([]interface{}) {
+		conditionMap := condition.(map[string]interface{})
+		conditions = append(conditions, newRealmClientPolicyCondition(clientPolicyConditionClientScopes, conditionMap, map[string]interface{}{
+			"scopes": interfaceSliceToStringSlice(conditionMap["scopes"].(*schema.Set).List()),
+			"type":   conditionMap["type"].(string),
+		}))
+	}
+
+	for _, condition := range data.Get("client_access_type_condition").([]interface{}) {
+		conditionMap := condition.(map[string]interface{})
+		conditions = append(conditions, newRealmClientPolicyCondition(clientPolicyConditionClientAccessType, conditionMap, map[string]interface{}{
+			"type": interfaceSliceToStringSlice(conditionMap["access_types"].(*schema.Set).List()),
+		}))
+	}
+
+	for _, condition := range data.Get("client_updater_source_host_condition").([]interface{}) {
+		conditionMap := condition.(map[string]interface{})
+		conditions = append(conditions, newRealmClientPolicyCondition(clientPolicyConditionClientUpdaterSourceHost, conditionMap, map[string]interface{}{
+			"trusted-hosts": interfaceSliceToStringSlice(conditionMap["trusted_hosts"].(*schema.Set).List()),
+		}))
+	}
+
+	for _, condition := range data.Get("any_client_condition").([]interface{}) {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			// an empty block is represented by a nil element
+			conditionMap = map[string]interface{}{"negative_logic": false}
+		}
+		conditions = append(conditions, newRealmClientPolicyCondition(clientPolicyConditionAnyClient, conditionMap, map[string]interface{}{}))
+	}
+
+	for _, condition := range data.Get("condition").([]interface{}) {
+		conditionMap := condition.(map[string]interface{})
+
+		configurations := make(map[string]interface{})
+		for key, value := range conditionMap["configuration"].(map[string]interface{}) {
+			// handle json objects and arrays
+			if strings.HasPrefix(value.(string), "{") || strings.HasPrefix(value.(string), "[") {
+				var t interface{}
+				json.Unmarshal([]byte(value.(string)), &t)
+				configurations[key] = t
+				continue
+			}
+			configurations[key] = value
+		}
+
+		conditions = append(conditions, keycloak.RealmClientPolicyProfilePolicyCondition{
+			Name:          conditionMap["name"].(string),
+			Configuration: configurations,
+		})
+	}
+
+	for _, profile := range data.Get("profiles").(*schema.Set).List() {
+		profiles = append(profiles, profile.(string))
+	}
+
+	return &keycloak.RealmClientPolicyProfilePolicy{
+		Name:        data.Get("name").(string),
+		RealmId:     data.Get("realm_id").(string),
+		Description: data.Get("description").(string),
+		Enabled:     data.Get("enabled").(bool),
+		Profiles:    profiles,
+		Conditions:  conditions,
+	}
+}
+
+func getRealmClientPolicyConditionStrings(configuration map[string]interface{}, key string) []string {
+	switch value := configuration[key].(type) {
+	case []interface{}:
+		return interfaceSliceToStringSlice(value)
+	case string:
+		return []string{value}
+	}
+
+	return []string{}
+}
+
+func getRealmClientPolicyConditionNegativeLogic(configuration map[string]interface{}) bool {
+	switch value := configuration[clientPolicyConditionNegativeLogic].(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	}
+
+	return false
+}
+
+func setRealmClientPolicyData(data *schema.ResourceData, policy *keycloak.RealmClientPolicyProfilePolicy) {
+	clientRolesConditions := make([]interface{}, 0)
+	clientScopesConditions := make([]interface{}, 0)
+	clientAccessTypeConditions := make([]interface{}, 0)
+	clientUpdaterSourceHostConditions := make([]interface{}, 0)
+	anyClientConditions := make([]interface{}, 0)
+	conditions := make([]interface{}, 0)
+
+	for _, condition := range policy.Conditions {
+		configuration := condition.Configuration
+		if configuration == nil {
+			configuration = map[string]interface{}{}
+		}
+		negativeLogic := getRealmClientPolicyConditionNegativeLogic(configuration)
+
+		switch condition.Name {
+		case clientPolicyConditionClientRoles:
+			clientRolesConditions = append(clientRolesConditions, map[string]interface{}{
+				"roles":          getRealmClientPolicyConditionStrings(configuration, "roles"),
+				"negative_logic": negativeLogic,
+			})
+		case clientPolicyConditionClientScopes:
+			scopeType := "Default"
+			if v, ok := configuration["type"].(string); ok && v != "" {
+				scopeType = v
+			}
+			clientScopesConditions = append(clientScopesConditions, map[string]interface{}{
+				"scopes":         getRealmClientPolicyConditionStrings(configuration, "scopes"),
+				"type":           scopeType,
+				"negative_logic": negativeLogic,
+			})
+		case clientPolicyConditionClientAccessType:
+			clientAccessTypeConditions = append(clientAccessTypeConditions, map[string]interface{}{
+				"access_types":   getRealmClientPolicyConditionStrings(configuration, "type"),
+				"negative_logic": negativeLogic,
+			})
+		case clientPolicyConditionClientUpdaterSourceHost:
+			clientUpdaterSourceHostConditions = append(clientUpdaterSourceHostConditions, map[string]interface{}{
+				"trusted_hosts":  getRealmClientPolicyConditionStrings(configuration, "trusted-hosts"),
+				"negative_logic": negativeLogic,
+			})
+		case clientPolicyConditionAnyClient:
+			anyClientConditions = append(anyClientConditions, map[string]interface{}{
+				"negative_logic": negativeLogic,
+			})
+		default:
+			configurations := make(map[string]interface{})
+			for k, v := range configuration {
+				switch v.(type) {
+				// handle json objects and arrays
+				case map[string]interface{}, []interface{}:
+					s, _ := json.Marshal(v)
+					configurations[k] = string(s)
+				default:
+					configurations[k] = fmt.Sprintf("%v", v)
+				}
+			}
+			conditions = append(conditions, map[string]interface{}{
+				"name":          condition.Name,
+				"configuration": configurations,
+			})
+		}
+	}
+
+	data.SetId(realmClientPolicyId(policy.RealmId, policy.Name))
+	data.Set("realm_id", policy.RealmId)
+	data.Set("name", policy.Name)
+	data.Set("description", policy.Description)
+	data.Set("enabled", policy.Enabled)
+	data.Set("profiles", policy.Profiles)
+	data.Set("client_roles_condition", clientRolesConditions)
+	data.Set("client_scopes_condition", clientScopesConditions)
+	data.Set("client_access_type_condition", clientAccessTypeConditions)
+	data.Set("client_updater_source_host_condition", clientUpdaterSourceHostConditions)
+	data.Set("any_client_condition", anyClientConditions)
+	data.Set("condition", conditions)
+}
+
+// validateRealmClientPolicy checks the conditions of the policy against the condition providers registered on the server,
+// so that typos in condition names or configuration keys are reported instead of being silently ignored by Keycloak.
+func validateRealmClientPolicy(ctx context.Context, keycloakClient *keycloak.KeycloakClient, policy *keycloak.RealmClientPolicyProfilePolicy) error {
+	serverInfo, err := keycloakClient.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range policy.Conditions {
+		conditionProvider, ok := serverInfo.GetComponentType(clientPolicyConditionProviderType, condition.Name)
+		if !ok {
+			return fmt.Errorf("validation error: client policy condition %s is not installed on the server", condition.Name)
+		}
+
+		for key, value := range condition.Configuration {
+			if key == clientPolicyConditionNegativeLogic {
+				continue
+			}
+
+			var property *keycloak.ComponentTypeProperty
+			for i := range conditionProvider.Properties {
+				if conditionProvider.Properties[i].Name == key {
+					property = &conditionProvider.Properties[i]
+					break
+				}
+			}
+
+			if property == nil {
+				var supportedKeys []string
+				for _, p := range conditionProvider.Properties {
+					supportedKeys = append(supportedKeys, p.Name)
+				}
+				return fmt.Errorf("validation error: configuration key %s is not supported by client policy condition %s, supported keys are: %s", key, condition.Name, strings.Join(supportedKeys, ", "))
+			}
+
+			if len(property.Options) == 0 {
+				continue
+			}
+
+			var values []string
+			switch v := value.(type) {
+			case string:
+				values = []string{v}
+			case []string:
+				values = v
+			case []interface{}:
+				values = interfaceSliceToStringSlice(v)
+			}
+
+			for _, v := range values {
+				if !stringSliceContains(property.Options, v) {
+					return fmt.Errorf("validation error: value %s of configuration key %s is not supported by client policy condition %s, supported values are: %s", v, key, condition.Name, strings.Join(property.Options, ", "))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmClientPolicyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	policy := getRealmClientPolicyFromData(data)
+
+	err := validateRealmClientPolicy(ctx, keycloakClient, policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.ModifyRealmClientPolicyProfilePolicies(ctx, policy.RealmId, func(policies *keycloak.RealmClientPolicyProfilePolicies) error {
+		for _, p := range policies.Policies {
+			if p.Name == policy.Name {
+				return fmt.Errorf("client policy with name %s already exists in realm %s", policy.Name, policy.RealmId)
+			}
+		}
+
+		policies.Policies = append(policies.Policies, *policy)
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(realmClientPolicyId(policy.RealmId, policy.Name))
+
+	return resourceKeycloakRealmClientPolicyRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPolicyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	policies, err := keycloakClient.GetAllRealmClientPolicyProfilePolices(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	for _, policy := range policies.Policies {
+		if policy.Name == name {
+			policy.RealmId = realmId
+			setRealmClientPolicyData(data, &policy)
+
+			return nil
+		}
+	}
+
+	tflog.Warn(ctx, "Removing resource with id from state as it no longer exists", map[string]interface{}{
+		"id": data.Id(),
+	})
+	data.SetId("")
+
+	return nil
+}
+
+func resourceKeycloakRealmClientPolicyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	policy := getRealmClientPolicyFromData(data)
+
+	err := validateRealmClientPolicy(ctx, keycloakClient, policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.ModifyRealmClientPolicyProfilePolicies(ctx, policy.RealmId, func(policies *keycloak.RealmClientPolicyProfilePolicies) error {
+		for i, p := range policies.Policies {
+			if p.Name == policy.Name {
+				policies.Policies[i] = *policy
+
+				return nil
+			}
+		}
+
+		// the policy was removed outside of terraform in the meantime
+		policies.Policies = append(policies.Policies, *policy)
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientPolicyRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPolicyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	err := keycloakClient.ModifyRealmClientPolicyProfilePolicies(ctx, realmId, func(policies *keycloak.RealmClientPolicyProfilePolicies) error {
+		remainingPolicies := []keycloak.RealmClientPolicyProfilePolicy{}
+		for _, p := range policies.Policies {
+			if p.Name != name {
+				remainingPolicies = append(remainingPolicies, p)
+			}
+		}
+
+		policies.Policies = remainingPolicies
+
+		return nil
+	})
+
+	return diag.FromErr(err)
+}
+
+func resourceKeycloakRealmClientPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{policyName}}")
+	}
+
+	_, err := keycloakClient.GetRealmClientPolicyProfilePolicyByName(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("name", parts[1])
+	d.SetId(realmClientPolicyId(parts[0], parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -67,18 +67,16 @@ func resourceKeycloakRealmClientPolicyProfilePolicyUpdate(ctx context.Context, d
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 	policy := mapFromDataToRealmClientPolicyProfilePolicy(data)
 	realmId := policy.RealmId
-	realmClientPolicyProfilePolicies, err := keycloakClient.GetAllRealmClientPolicyProfilePolices(ctx, realmId)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 
-	for i, p := range realmClientPolicyProfilePolicies.Policies {
-		if p.Name == policy.Name {
-			realmClientPolicyProfilePolicies.Policies[i] = *policy
+	err := keycloakClient.ModifyRealmClientPolicyProfilePolicies(ctx, realmId, func(realmClientPolicyProfilePolicies *keycloak.RealmClientPolicyProfilePolicies) error {
+		for i, p := range realmClientPolicyProfilePolicies.Policies {
+			if p.Name == policy.Name {
+				realmClientPolicyProfilePolicies.Policies[i] = *policy
+			}
 		}
-	}
 
-	err = keycloakClient.UpdateRealmClientPolicyProfilePolicies(ctx, realmId, realmClientPolicyProfilePolicies)
+		return nil
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -88,23 +86,21 @@ func resourceKeycloakRealmClientPolicyProfilePolicyUpdate(ctx context.Context, d
 
 func resourceKeycloakRealmClientPolicyProfilePolicyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
-	slicedPolicies := []keycloak.RealmClientPolicyProfilePolicy{}
 	policy := mapFromDataToRealmClientPolicyProfilePolicy(data)
 	realmId := policy.RealmId
-	realmClientPolicyProfilePolicies, err := keycloakClient.GetAllRealmClientPolicyProfilePolices(ctx, realmId)
-	if err != nil {
-		return diag.FromErr(err)
-	}
 
-	for _, p := range realmClientPolicyProfilePolicies.Policies {
-		if p.Name != policy.Name {
-			slicedPolicies = append(slicedPolicies, p)
+	err := keycloakClient.ModifyRealmClientPolicyProfilePolicies(ctx, realmId, func(realmClientPolicyProfilePolicies *keycloak.RealmClientPolicyProfilePolicies) error {
+		slicedPolicies := []keycloak.RealmClientPolicyProfilePolicy{}
+		for _, p := range realmClientPolicyProfilePolicies.Policies {
+			if p.Name != policy.Name {
+				slicedPolicies = append(slicedPolicies, p)
+			}
 		}
-	}
 
-	realmClientPolicyProfilePolicies.Policies = slicedPolicies
+		realmClientPolicyProfilePolicies.Policies = slicedPolicies
 
-	err = keycloakClient.UpdateRealmClientPolicyProfilePolicies(ctx, realmId, realmClientPolicyProfilePolicies)
+		return nil
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -120,14 +116,11 @@ func resourceKeycloakRealmClientPolicyProfilePolicyCreate(ctx context.Context, d
 	name := policy.Name
 	data.SetId(fmt.Sprintf("%s/realm-client-policy-profile-policies/%s", realmId, name))
 
-	realmClientPolicyProfilyProfilyPolicies, err := keycloakClient.GetAllRealmClientPolicyProfilePolices(ctx, realmId)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	realmClientPolicyProfilyProfilyPolicies.Policies = append(realmClientPolicyProfilyProfilyPolicies.Policies, *policy)
+	err := keycloakClient.ModifyRealmClientPolicyProfilePolicies(ctx, realmId, func(realmClientPolicyProfilePolicies *keycloak.RealmClientPolicyProfilePolicies) error {
+		realmClientPolicyProfilePolicies.Policies = append(realmClientPolicyProfilePolicies.Policies, *policy)
 
-	err = keycloakClient.UpdateRealmClientPolicyProfilePolicies(ctx, realmId, realmClientPolicyProfilyProfilyPolicies)
+		return nil
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmClientPolicy_basic(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientPolicyDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicy_basic(realmName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientPolicyProfilePolicyExists(realmName, "confidential-clients"),
+					testAccCheckKeycloakRealmClientPolicyProfilePolicyExists(realmName, "all-clients"),
+					testAccCheckKeycloakRealmClientPolicyProfilePolicyMatches(realmName, "confidential-clients", "client-access-type", map[string]interface{}{
+						"type":              []string{"confidential"},
+						"is-negative-logic": false,
+					}),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy.confidential_clients", "client_roles_condition.0.roles.#", "2"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy.confidential_clients", "client_scopes_condition.0.type", "Optional"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy.confidential_clients", "client_updater_source_host_condition.0.negative_logic", "true"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_client_policy.confidential_clients",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientPolicy_invalidConfiguration(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmClientPolicy_condition(realmName, "client-roles", `{ "role" = "[\"admin\"]" }`),
+				ExpectError: regexp.MustCompile("configuration key role is not supported by client policy condition client-roles"),
+			},
+			{
+				Config:      testKeycloakRealmClientPolicy_condition(realmName, "not-a-condition", `{}`),
+				ExpectError: regexp.MustCompile("client policy condition not-a-condition is not installed on the server"),
+			},
+		},
+	})
+}
+
+// TestUnitKeycloakRealmClientPolicy_concurrentCreate creates several policies of the same realm in parallel,
+// none of them may be lost while the policies document is rewritten
+func TestUnitKeycloakRealmClientPolicy_concurrentCreate(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	policyResource := testAccProvider.ResourcesMap["keycloak_realm_client_policy"]
+	prefix := acctest.RandomWithPrefix("tf-unit")
+
+	var policies []*schema.ResourceData
+	for i := 0; i < 10; i++ {
+		policies = append(policies, schema.TestResourceDataRaw(t, policyResource.Schema, map[string]interface{}{
+			"realm_id": testAccRealm.Realm,
+			"name":     fmt.Sprintf("%s-%d", prefix, i),
+			"client_access_type_condition": []interface{}{
+				map[string]interface{}{
+					"access_types": []interface{}{"public"},
+				},
+			},
+		}))
+	}
+
+	var wg sync.WaitGroup
+	for _, data := range policies {
+		wg.Add(1)
+		go func(data *schema.ResourceData) {
+			defer wg.Done()
+
+			if diags := policyResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+				t.Errorf("unable to create client policy: %v", diags)
+			}
+		}(data)
+	}
+	wg.Wait()
+
+	for _, data := range policies {
+		policy, err := keycloakClient.GetRealmClientPolicyProfilePolicyByName(testCtx, testAccRealm.Realm, data.Get("name").(string))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(policy.Conditions) != 1 || policy.Conditions[0].Name != "client-access-type" {
+			t.Errorf("unexpected conditions %+v", policy.Conditions)
+		}
+		if data.Get("client_access_type_condition.0.access_types").(*schema.Set).Len() != 1 {
+			t.Errorf("expected access types to be read back, got %v", data.Get("client_access_type_condition"))
+		}
+	}
+
+	for _, data := range policies {
+		if diags := policyResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+			t.Fatalf("unable to delete client policy: %v", diags)
+		}
+	}
+
+	remaining, err := keycloakClient.GetAllRealmClientPolicyProfilePolices(testCtx, testAccRealm.Realm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, policy := range remaining.Policies {
+		for _, data := range policies {
+			if policy.Name == data.Get("name").(string) {
+				t.Errorf("expected client policy %s to be deleted", policy.Name)
+			}
+		}
+	}
+}
+
+func TestUnitKeycloakRealmClientPolicy_validation(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	policyResource := testAccProvider.ResourcesMap["keycloak_realm_client_policy"]
+	data := schema.TestResourceDataRaw(t, policyResource.Schema, map[string]interface{}{
+		"realm_id": testAccRealm.Realm,
+		"name":     acctest.RandomWithPrefix("tf-unit"),
+		"condition": []interface{}{
+			map[string]interface{}{
+				"name": "client-scopes",
+				"configuration": map[string]interface{}{
+					"scopes": `["email"]`,
+					"type":   "Mandatory",
+				},
+			},
+		},
+	})
+
+	diags := policyResource.CreateContext(testCtx, data, keycloakClient)
+	if !diags.HasError() || !regexp.MustCompile("value Mandatory of configuration key type is not supported").MatchString(diags[0].Summary) {
+		t.Fatalf("expected validation error, got %v", diags)
+	}
+}
+
+func testAccCheckKeycloakRealmClientPolicyDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_client_policy" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			name := rs.Primary.Attributes["name"]
+
+			policy, _ := keycloakClient.GetRealmClientPolicyProfilePolicyByName(testCtx, realmId, name)
+			if policy != nil {
+				return fmt.Errorf("client policy %s still exists", name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmClientPolicy_basic(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_policy_profile" "profile" {
+	realm_id = keycloak_realm.realm.realm
+	name     = "pkce"
+
+	executor {
+		name = "pkce-enforcer"
+		configuration = {
+			auto-configure = "true"
+		}
+	}
+}
+
+resource "keycloak_realm_client_policy" "confidential_clients" {
+	realm_id    = keycloak_realm.realm.realm
+	name        = "confidential-clients"
+	description = "Enforce PKCE for confidential clients"
+	profiles    = [keycloak_realm_client_policy_profile.profile.name]
+
+	client_access_type_condition {
+		access_types = ["confidential"]
+	}
+
+	client_roles_condition {
+		roles = ["admin", "user"]
+	}
+
+	client_scopes_condition {
+		scopes = ["email"]
+		type   = "Optional"
+	}
+
+	client_updater_source_host_condition {
+		trusted_hosts  = ["example.com"]
+		negative_logic = true
+	}
+}
+
+resource "keycloak_realm_client_policy" "all_clients" {
+	realm_id = keycloak_realm.realm.realm
+	name     = "all-clients"
+	enabled  = false
+	profiles = [keycloak_realm_client_policy_profile.profile.name]
+
+	any_client_condition {}
+}
+	`, realm)
+}
+
+func testKeycloakRealmClientPolicy_condition(realm, conditionName, configuration string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_policy" "policy" {
+	realm_id = keycloak_realm.realm.realm
+	name     = "policy"
+
+	condition {
+		name          = "%s"
+		configuration = %s
+	}
+}
+	`, realm, conditionName, configuration)
+}
@@ -2,8 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
 
@@ -29,6 +33,13 @@ func resourceKeycloakRealmDefaultClientScopes() *schema.Resource {
 				Required: true,
 				Set:      schema.HashString,
 			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"openid-connect", "saml"}, false),
+				Description:  "When set, only the default client scopes of this protocol are managed by this resource.",
+			},
 		},
 	}
 }
@@ -37,19 +48,25 @@ func resourceKeycloakRealmDefaultClientScopesRead(ctx context.Context, data *sch
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
 	realmId := data.Get("realm_id").(string)
+	protocol := data.Get("protocol").(string)
 
 	defaultClientScopes, err := keycloakClient.GetRealmDefaultClientScopes(ctx, realmId)
 	if err != nil {
 		return handleNotFoundError(ctx, err, data)
 	}
 
+	defaultClientScopes, err = filterRealmClientScopesByProtocol(ctx, keycloakClient, realmId, protocol, defaultClientScopes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var scopeNames []string
 	for _, clientScope := range defaultClientScopes {
 		scopeNames = append(scopeNames, clientScope.Name)
 	}
 
 	data.Set("default_scopes", scopeNames)
-	data.SetId(realmId)
+	data.SetId(realmClientScopesId(realmId, protocol))
 
 	return nil
 }
@@ -58,13 +75,24 @@ func resourceKeycloakRealmDefaultClientScopesReconcile(ctx context.Context, data
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
 	realmId := data.Get("realm_id").(string)
+	protocol := data.Get("protocol").(string)
 	tfDefaultClientScopes := data.Get("default_scopes").(*schema.Set)
 
+	err := validateRealmClientScopesProtocol(ctx, keycloakClient, realmId, protocol, interfaceSliceToStringSlice(tfDefaultClientScopes.List()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	keycloakDefaultClientScopes, err := keycloakClient.GetRealmDefaultClientScopes(ctx, realmId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	keycloakDefaultClientScopes, err = filterRealmClientScopesByProtocol(ctx, keycloakClient, realmId, protocol, keycloakDefaultClientScopes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var scopesToUnmark []string
 	for _, keycloakDefaultClientScope := range keycloakDefaultClientScopes {
 		// if this scope is a default client scope in keycloak and tf state, no update is required
@@ -88,7 +116,7 @@ func resourceKeycloakRealmDefaultClientScopesReconcile(ctx context.Context, data
 		return diag.FromErr(err)
 	}
 
-	data.SetId(realmId)
+	data.SetId(realmClientScopesId(realmId, protocol))
 
 	return resourceKeycloakRealmDefaultClientScopesRead(ctx, data, meta)
 }
@@ -103,11 +131,73 @@ func resourceKeycloakRealmDefaultClientScopesDelete(ctx context.Context, data *s
 }
 
 // resourceKeycloakRealmDefaultClientScopesImport adopts the realm-level default
-// client scopes into state. The import id is the realm id; realm_id is required
-// by the Read, so it is populated from the id before Read runs (which then
-// resolves the current default scopes and sets the resource id to the realm id).
+// client scopes into state. The import id is the realm id, optionally followed by
+// the protocol; realm_id is required by the Read, so it is populated from the id
+// before Read runs (which then resolves the current default scopes and sets the
+// resource id).
 func resourceKeycloakRealmDefaultClientScopesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	d.Set("realm_id", d.Id())
+	parts := strings.Split(d.Id(), "/")
+
+	switch len(parts) {
+	case 1:
+		d.Set("realm_id", parts[0])
+	case 2:
+		d.Set("realm_id", parts[0])
+		d.Set("protocol", parts[1])
+	default:
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}, {{realmId}}/{{protocol}}")
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// realmClientScopesId is the realm id, followed by the protocol when the resource only manages the client scopes of one
+// protocol, so that a resource can exist for each protocol
+func realmClientScopesId(realmId, protocol string) string {
+	if protocol == "" {
+		return realmId
+	}
+
+	return fmt.Sprintf("%s/%s", realmId, protocol)
+}
+
+// filterRealmClientScopesByProtocol drops the client scopes of other protocols when the resource only manages the
+// client scopes of one protocol
+func filterRealmClientScopesByProtocol(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, protocol string, clientScopes []*keycloak.OpenidClientScope) ([]*keycloak.OpenidClientScope, error) {
+	if protocol == "" {
+		return clientScopes, nil
+	}
+
+	protocols, err := keycloakClient.GetRealmClientScopeProtocols(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*keycloak.OpenidClientScope
+	for _, clientScope := range clientScopes {
+		if protocols[clientScope.Name] == protocol {
+			filtered = append(filtered, clientScope)
+		}
+	}
+
+	return filtered, nil
+}
+
+func validateRealmClientScopesProtocol(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, protocol string, scopeNames []string) error {
+	if protocol == "" {
+		return nil
+	}
+
+	protocols, err := keycloakClient.GetRealmClientScopeProtocols(ctx, realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, scopeName := range scopeNames {
+		if scopeProtocol, ok := protocols[scopeName]; ok && scopeProtocol != protocol {
+			return fmt.Errorf("validation error: client scope %s uses the %s protocol instead of %s", scopeName, scopeProtocol, protocol)
+		}
+	}
+
+	return nil
+}
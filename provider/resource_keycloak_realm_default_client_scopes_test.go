@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 	"slices"
@@ -60,6 +61,125 @@ func TestAccKeycloakRealmDefaultClientScopes_empty(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakRealmDefaultClientScopes_protocol(t *testing.T) {
+	t.Parallel()
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmDefaultScopes_protocol(realmName, clientScope),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmHasDefaultScopes(
+						"keycloak_realm_default_client_scopes.default_scopes",
+						[]string{"profile", "email", "roles", "role_list", clientScope}),
+					resource.TestCheckResourceAttr("keycloak_realm_default_client_scopes.saml_default_scopes", "id", realmName+"/saml"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_default_client_scopes.saml_default_scopes",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakRealmClientScopes_protocol(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	clientScope := &keycloak.SamlClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClientScope(testCtx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClientScope(testCtx, testAccRealm.Realm, clientScope.Id)
+		_ = keycloakClient.MarkClientScopesAsRealmDefault(testCtx, testAccRealm.Realm, []string{"role_list", "saml_organization"})
+	})
+
+	defaultScopesResource := testAccProvider.ResourcesMap["keycloak_realm_default_client_scopes"]
+	data := schema.TestResourceDataRaw(t, defaultScopesResource.Schema, map[string]interface{}{
+		"realm_id":       testAccRealm.Realm,
+		"protocol":       "saml",
+		"default_scopes": []interface{}{"profile"},
+	})
+	if diags := defaultScopesResource.CreateContext(testCtx, data, keycloakClient); !diags.HasError() {
+		t.Fatal("expected an openid-connect client scope to be rejected")
+	}
+
+	data.Set("default_scopes", []interface{}{"role_list", clientScope.Name})
+	if diags := defaultScopesResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != testAccRealm.Realm+"/saml" {
+		t.Errorf("unexpected id %s", data.Id())
+	}
+
+	defaultScopes, err := keycloakClient.GetRealmDefaultClientScopes(testCtx, testAccRealm.Realm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, defaultScope := range defaultScopes {
+		names = append(names, defaultScope.Name)
+	}
+
+	// the openid-connect scopes are left alone, the saml scopes that aren't listed are unmarked
+	if !slices.Contains(names, "profile") || !slices.Contains(names, clientScope.Name) || slices.Contains(names, "saml_organization") {
+		t.Errorf("unexpected realm default client scopes %v", names)
+	}
+
+	samlClient := &keycloak.SamlClient{RealmId: testAccRealm.Realm, ClientId: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClient(testCtx, samlClient); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClient(testCtx, testAccRealm.Realm, samlClient.Id)
+	})
+
+	clientDefaultScopes, err := keycloakClient.GetSamlClientDefaultScopes(testCtx, testAccRealm.Realm, samlClient.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clientDefaultScopes) != 2 {
+		t.Errorf("expected new saml clients to get the realm default saml client scopes, got %v", clientDefaultScopes)
+	}
+
+	imported := schema.TestResourceDataRaw(t, defaultScopesResource.Schema, map[string]interface{}{})
+	imported.SetId(testAccRealm.Realm + "/saml")
+	if _, err := defaultScopesResource.Importer.StateContext(testCtx, imported, keycloakClient); err != nil {
+		t.Fatal(err)
+	}
+	if diags := defaultScopesResource.ReadContext(testCtx, imported, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if imported.Get("default_scopes").(*schema.Set).Len() != 2 {
+		t.Errorf("expected only the saml default client scopes to be imported, got %v", imported.Get("default_scopes"))
+	}
+
+	optionalScopesResource := testAccProvider.ResourcesMap["keycloak_realm_optional_client_scopes"]
+	optionalData := schema.TestResourceDataRaw(t, optionalScopesResource.Schema, map[string]interface{}{
+		"realm_id":        testAccRealm.Realm,
+		"protocol":        "saml",
+		"optional_scopes": []interface{}{},
+	})
+	if diags := optionalScopesResource.CreateContext(testCtx, optionalData, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	optionalScopes, err := keycloakClient.GetRealmOptionalClientScopes(testCtx, testAccRealm.Realm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(optionalScopes) == 0 {
+		t.Error("expected the openid-connect optional client scopes to be left alone")
+	}
+}
+
 func getRealmDefaultClientScopesFromState(resourceName string, s *terraform.State) ([]*keycloak.OpenidClientScope, error) {
 	rs, ok := s.RootModule().Resources[resourceName]
 	if !ok {
@@ -142,3 +262,37 @@ resource "keycloak_realm_default_client_scopes" "default_scopes" {
 }
 	`, realmName)
 }
+
+func testKeycloakRealmDefaultScopes_protocol(realmName string, clientScope string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name        = "%s"
+	realm_id    = keycloak_realm.realm.id
+
+	description = "test description"
+}
+
+resource "keycloak_realm_default_client_scopes" "default_scopes" {
+	realm_id       = keycloak_realm.realm.id
+	protocol       = "openid-connect"
+	default_scopes = [
+		"profile",
+		"email",
+		"roles",
+	]
+}
+
+resource "keycloak_realm_default_client_scopes" "saml_default_scopes" {
+	realm_id       = keycloak_realm.realm.id
+	protocol       = "saml"
+	default_scopes = [
+		"role_list",
+		keycloak_saml_client_scope.client_scope.name
+	]
+}
+	`, realmName, clientScope)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
 
@@ -25,6 +26,13 @@ func resourceKeycloakRealmOptionalClientScopes() *schema.Resource {
 				Required: true,
 				Set:      schema.HashString,
 			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"openid-connect", "saml"}, false),
+				Description:  "When set, only the optional client scopes of this protocol are managed by this resource.",
+			},
 		},
 	}
 }
@@ -33,19 +41,25 @@ func resourceKeycloakRealmOptionalClientScopesRead(ctx context.Context, data *sc
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
 	realmId := data.Get("realm_id").(string)
+	protocol := data.Get("protocol").(string)
 
 	optionalClientScopes, err := keycloakClient.GetRealmOptionalClientScopes(ctx, realmId)
 	if err != nil {
 		return handleNotFoundError(ctx, err, data)
 	}
 
+	optionalClientScopes, err = filterRealmClientScopesByProtocol(ctx, keycloakClient, realmId, protocol, optionalClientScopes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var scopeNames []string
 	for _, clientScope := range optionalClientScopes {
 		scopeNames = append(scopeNames, clientScope.Name)
 	}
 
 	data.Set("optional_scopes", scopeNames)
-	data.SetId(realmId)
+	data.SetId(realmClientScopesId(realmId, protocol))
 
 	return nil
 }
@@ -54,13 +68,24 @@ func resourceKeycloakRealmOptionalClientScopesReconcile(ctx context.Context, dat
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
 	realmId := data.Get("realm_id").(string)
+	protocol := data.Get("protocol").(string)
 	tfOptionalClientScopes := data.Get("optional_scopes").(*schema.Set)
 
+	err := validateRealmClientScopesProtocol(ctx, keycloakClient, realmId, protocol, interfaceSliceToStringSlice(tfOptionalClientScopes.List()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	keycloakOptionalClientScopes, err := keycloakClient.GetRealmOptionalClientScopes(ctx, realmId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	keycloakOptionalClientScopes, err = filterRealmClientScopesByProtocol(ctx, keycloakClient, realmId, protocol, keycloakOptionalClientScopes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var scopesToUnmark []string
 	for _, keycloakOptionalClientScope := range keycloakOptionalClientScopes {
 		// if this scope is an optional client scope in keycloak and tf state, no update is required
@@ -84,7 +109,7 @@ func resourceKeycloakRealmOptionalClientScopesReconcile(ctx context.Context, dat
 		return diag.FromErr(err)
 	}
 
-	data.SetId(realmId)
+	data.SetId(realmClientScopesId(realmId, protocol))
 
 	return resourceKeycloakRealmOptionalClientScopesRead(ctx, data, meta)
 }
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRoleComposites() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRoleCompositesReconcile,
+		ReadContext:   resourceKeycloakRoleCompositesRead,
+		UpdateContext: resourceKeycloakRoleCompositesReconcile,
+		DeleteContext: resourceKeycloakRoleCompositesDelete,
+		// This resource can be imported using {{realm}}/{{roleId}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRoleCompositesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the realm or client role the composite roles are added to.",
+			},
+			"composite_role_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+				Description: "The ids of the realm and client roles the role is composed of, the roles of any client can be used.",
+			},
+			"exhaustive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When true, the composite roles which aren't in composite_role_ids are removed from the role.",
+			},
+		},
+	}
+}
+
+func roleCompositesId(realmId, roleId string) string {
+	return fmt.Sprintf("%s/%s", realmId, roleId)
+}
+
+func resourceKeycloakRoleCompositesReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	roleId := data.Get("role_id").(string)
+	exhaustive := data.Get("exhaustive").(bool)
+
+	role, err := keycloakClient.GetRole(ctx, realmId, roleId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	composites, err := keycloakClient.GetRoleComposites(ctx, role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfComposites, err := mapCompositeRoleIdsToRoleObjects(ctx, keycloakClient, data.Get("composite_role_ids").(*schema.Set).List(), realmId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the composites removed from the configuration are removed from the role even when it isn't exhaustive
+	compositesToRemove := minusRoles(composites, tfComposites)
+	if !exhaustive {
+		o, n := data.GetChange("composite_role_ids")
+		removed := o.(*schema.Set).Difference(n.(*schema.Set))
+
+		compositesToRemove = nil
+		for _, composite := range composites {
+			if removed.Contains(composite.Id) {
+				compositesToRemove = append(compositesToRemove, composite)
+			}
+		}
+	}
+
+	if compositesToAdd := minusRoles(tfComposites, composites); len(compositesToAdd) != 0 {
+		if err = keycloakClient.AddCompositesToRole(ctx, role, compositesToAdd); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if len(compositesToRemove) != 0 {
+		if err = keycloakClient.RemoveCompositesFromRole(ctx, role, compositesToRemove); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(roleCompositesId(realmId, roleId))
+
+	return resourceKeycloakRoleCompositesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRoleCompositesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	roleId := data.Get("role_id").(string)
+	tfCompositeIds := data.Get("composite_role_ids").(*schema.Set)
+	exhaustive := data.Get("exhaustive").(bool)
+
+	role, err := keycloakClient.GetRole(ctx, realmId, roleId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	composites, err := keycloakClient.GetRoleComposites(ctx, role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var compositeRoleIds []string
+	for _, composite := range composites {
+		if exhaustive || tfCompositeIds.Contains(composite.Id) {
+			compositeRoleIds = append(compositeRoleIds, composite.Id)
+		}
+	}
+
+	data.Set("composite_role_ids", compositeRoleIds)
+	data.SetId(roleCompositesId(realmId, roleId))
+
+	return nil
+}
+
+func resourceKeycloakRoleCompositesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	roleId := data.Get("role_id").(string)
+	tfCompositeIds := data.Get("composite_role_ids").(*schema.Set)
+
+	role, err := keycloakClient.GetRole(ctx, realmId, roleId)
+	if err != nil {
+		if keycloak.ErrorIs404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	composites, err := keycloakClient.GetRoleComposites(ctx, role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the composites managed by this resource are removed, the ones deleted since are already gone
+	var compositesToRemove []*keycloak.Role
+	for _, composite := range composites {
+		if tfCompositeIds.Contains(composite.Id) {
+			compositesToRemove = append(compositesToRemove, composite)
+		}
+	}
+
+	if len(compositesToRemove) != 0 {
+		return diag.FromErr(keycloakClient.RemoveCompositesFromRole(ctx, role, compositesToRemove))
+	}
+
+	return nil
+}
+
+func resourceKeycloakRoleCompositesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{roleId}}.")
+	}
+
+	if _, err := keycloakClient.GetRole(ctx, parts[0], parts[1]); err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("role_id", parts[1])
+	d.Set("exhaustive", true)
+
+	diagnostics := resourceKeycloakRoleCompositesRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRoleComposites_basic(t *testing.T) {
+	t.Parallel()
+
+	prefix := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_role_composites.composites"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRoleComposites(prefix, "[keycloak_role.realm_role.id, keycloak_role.client_a_role.id, keycloak_role.client_b_role.id]", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "composite_role_ids.#", "3"),
+					testAccCheckKeycloakRoleComposites(resourceName, 3),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testKeycloakRoleComposites(prefix, "[keycloak_role.client_b_role.id]", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "composite_role_ids.#", "1"),
+					testAccCheckKeycloakRoleComposites(resourceName, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRoleComposites_additive(t *testing.T) {
+	t.Parallel()
+
+	prefix := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_role_composites.composites"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRoleComposites(prefix, "[keycloak_role.client_a_role.id]", false),
+				Check:  testAccCheckKeycloakRoleComposites(resourceName, 1),
+			},
+			{
+				// composites added out of band are left alone
+				PreConfig: func() {
+					parent, err := keycloakClient.GetRoleByName(testCtx, testAccRealm.Realm, "", prefix+"-parent")
+					if err != nil {
+						t.Fatal(err)
+					}
+					composite, err := keycloakClient.GetRoleByName(testCtx, testAccRealm.Realm, "", prefix+"-realm")
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := keycloakClient.AddCompositesToRole(testCtx, parent, []*keycloak.Role{composite}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRoleComposites(prefix, "[keycloak_role.client_a_role.id, keycloak_role.client_b_role.id]", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "composite_role_ids.#", "2"),
+					testAccCheckKeycloakRoleComposites(resourceName, 3),
+				),
+			},
+			{
+				Config: testKeycloakRoleComposites(prefix, "[keycloak_role.client_b_role.id]", false),
+				Check:  testAccCheckKeycloakRoleComposites(resourceName, 2),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakRoleComposites_drift(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	prefix := acctest.RandomWithPrefix("tf-unit")
+	client := &keycloak.OpenidClient{
+		RealmId:  testAccRealm.Realm,
+		ClientId: prefix,
+		Enabled:  true,
+	}
+	if err := keycloakClient.NewOpenidClient(testCtx, client); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteOpenidClient(testCtx, testAccRealm.Realm, client.Id)
+	})
+
+	var roles []*keycloak.Role
+	for i, clientId := range []string{client.Id, "", client.Id, ""} {
+		role := &keycloak.Role{
+			RealmId:  testAccRealm.Realm,
+			ClientId: clientId,
+			Name:     fmt.Sprintf("%s-%d", prefix, i),
+		}
+		if err := keycloakClient.CreateRole(testCtx, role); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = keycloakClient.DeleteRole(testCtx, testAccRealm.Realm, role.Id)
+		})
+		roles = append(roles, role)
+	}
+
+	// a client role composed of a realm role and of a role of the same client
+	compositesResource := testAccProvider.ResourcesMap["keycloak_role_composites"]
+	attributes := map[string]interface{}{
+		"realm_id":           testAccRealm.Realm,
+		"role_id":            roles[0].Id,
+		"composite_role_ids": []interface{}{roles[1].Id, roles[2].Id},
+	}
+	data := schema.TestResourceDataRaw(t, compositesResource.Schema, attributes)
+	if diags := compositesResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != testAccRealm.Realm+"/"+roles[0].Id {
+		t.Errorf("unexpected id %s", data.Id())
+	}
+
+	parent, err := keycloakClient.GetRole(testCtx, testAccRealm.Realm, roles[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parent.Composite {
+		t.Error("expected the role to be a composite")
+	}
+
+	// a composite added out of band shows up as drift
+	if err := keycloakClient.AddCompositesToRole(testCtx, parent, roles[3:]); err != nil {
+		t.Fatal(err)
+	}
+	if diags := compositesResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	diff, err := compositesResource.Diff(testCtx, data.State(), terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["composite_role_ids.#"] == nil || diff.Attributes["composite_role_ids.#"].Old != "3" {
+		t.Fatalf("expected a diff of composite_role_ids, got %v", diff)
+	}
+
+	data = schema.TestResourceDataRaw(t, compositesResource.Schema, attributes)
+	if diags := compositesResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	composites, err := keycloakClient.GetRoleComposites(testCtx, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(composites) != 2 {
+		t.Errorf("expected the composite added out of band to be removed, got %v", composites)
+	}
+
+	if diags := compositesResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	composites, err = keycloakClient.GetRoleComposites(testCtx, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(composites) != 0 {
+		t.Errorf("expected no composite once deleted, got %v", composites)
+	}
+}
+
+func testAccCheckKeycloakRoleComposites(resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		role, err := keycloakClient.GetRole(testCtx, rs.Primary.Attributes["realm_id"], rs.Primary.Attributes["role_id"])
+		if err != nil {
+			return err
+		}
+
+		composites, err := keycloakClient.GetRoleComposites(testCtx, role)
+		if err != nil {
+			return err
+		}
+
+		if len(composites) != expected {
+			return fmt.Errorf("expected role %s to have %d composites, got %d", role.Name, expected, len(composites))
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRoleComposites(prefix, compositeRoleIds string, exhaustive bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client_a" {
+	realm_id    = data.keycloak_realm.realm.id
+	client_id   = "%s-a"
+	access_type = "PUBLIC"
+}
+
+resource "keycloak_openid_client" "client_b" {
+	realm_id    = data.keycloak_realm.realm.id
+	client_id   = "%s-b"
+	access_type = "PUBLIC"
+}
+
+resource "keycloak_role" "parent" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s-parent"
+}
+
+resource "keycloak_role" "realm_role" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s-realm"
+}
+
+resource "keycloak_role" "client_a_role" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client_a.id
+	name      = "role-a"
+}
+
+resource "keycloak_role" "client_b_role" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client_b.id
+	name      = "role-b"
+}
+
+resource "keycloak_role_composites" "composites" {
+	realm_id           = data.keycloak_realm.realm.id
+	role_id            = keycloak_role.parent.id
+	composite_role_ids = %s
+	exhaustive         = %t
+}
+	`, testAccRealm.Realm, prefix, prefix, prefix, prefix, compositeRoleIds, exhaustive)
+}
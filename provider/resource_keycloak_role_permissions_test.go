@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRolePermission_basic(t *testing.T) {
+	roleName := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRolePermissionDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRolePermission_basic(roleName, groupName),
+				Check:  testAccCheckKeycloakRolePermissionExists("keycloak_role_permissions.test"),
+			},
+			{
+				ResourceName:      "keycloak_role_permissions.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRolePermission_createAfterManualDestroy(t *testing.T) {
+	var rolePermissions = &keycloak.RolePermissions{}
+
+	roleName := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRolePermissionDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRolePermission_basic(roleName, groupName),
+				Check: func(s *terraform.State) error {
+					permissions, err := getRolePermissionsFromState(s, "keycloak_role_permissions.test")
+					if err != nil {
+						return err
+					}
+
+					*rolePermissions = *permissions
+
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DisableRolePermissions(testCtx, rolePermissions.RealmId, rolePermissions.RoleId)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRolePermission_basic(roleName, groupName),
+				Check:  testAccCheckKeycloakRolePermissionExists("keycloak_role_permissions.test"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRolePermissionExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		permissions, err := getRolePermissionsFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		if !permissions.Enabled {
+			return fmt.Errorf("expected permissions of role %s to be enabled", permissions.RoleId)
+		}
+
+		rs := s.RootModule().Resources[resourceName]
+		authorizationResourceServerId := rs.Primary.Attributes["authorization_resource_server_id"]
+
+		mapRoleScopePolicyId := rs.Primary.Attributes["map_role_scope.0.policies.0"]
+		mapRoleScopeDescription := rs.Primary.Attributes["map_role_scope.0.description"]
+		mapRoleScopeDecisionStrategy := rs.Primary.Attributes["map_role_scope.0.decision_strategy"]
+
+		authzClientMapRoleScope, err := keycloakClient.GetOpenidClientAuthorizationPermission(testCtx, permissions.RealmId, authorizationResourceServerId, permissions.ScopePermissions["map-role"])
+		if err != nil {
+			return err
+		}
+
+		if len(authzClientMapRoleScope.Policies) != 1 || authzClientMapRoleScope.Policies[0] != mapRoleScopePolicyId {
+			return fmt.Errorf("expected map-role permission to have policy %s, got %v", mapRoleScopePolicyId, authzClientMapRoleScope.Policies)
+		}
+
+		if authzClientMapRoleScope.Description != mapRoleScopeDescription {
+			return fmt.Errorf("description %s was not equal to %s", authzClientMapRoleScope.Description, mapRoleScopeDescription)
+		}
+
+		if authzClientMapRoleScope.DecisionStrategy != mapRoleScopeDecisionStrategy {
+			return fmt.Errorf("DecisionStrategy %s was not equal to %s", authzClientMapRoleScope.DecisionStrategy, mapRoleScopeDecisionStrategy)
+		}
+
+		if mapRoleCompositeScope := rs.Primary.Attributes["map_role_composite_scope.#"]; mapRoleCompositeScope != "" && mapRoleCompositeScope != "0" {
+			return fmt.Errorf("map_role_composite_scope found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakRolePermissionDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_role_permissions" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			roleId := rs.Primary.Attributes["role_id"]
+
+			permissions, err := keycloakClient.GetRolePermissions(testCtx, realmId, roleId)
+			if err == nil && permissions.Enabled {
+				return fmt.Errorf("permissions of role %s are still enabled", roleId)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getRolePermissionsFromState(s *terraform.State, resourceName string) (*keycloak.RolePermissions, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	realmId := rs.Primary.Attributes["realm_id"]
+	roleId := rs.Primary.Attributes["role_id"]
+
+	permissions, err := keycloakClient.GetRolePermissions(testCtx, realmId, roleId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting role permissions with realm id %s and role id %s : %s", realmId, roleId, err)
+	}
+
+	return permissions, nil
+}
+
+func testKeycloakRolePermission_basic(roleName, groupName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+data "keycloak_openid_client" "realm_management" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "realm-management"
+}
+
+resource "keycloak_openid_client_permissions" "realm-management_permission" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = data.keycloak_openid_client.realm_management.id
+}
+
+resource "keycloak_role" "role" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+}
+
+resource "keycloak_group" "group" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+}
+
+resource "keycloak_openid_client_group_policy" "test" {
+	realm_id           = data.keycloak_realm.realm.id
+	resource_server_id = data.keycloak_openid_client.realm_management.id
+	name               = "role_managers_%s"
+	groups {
+		id              = keycloak_group.group.id
+		path            = keycloak_group.group.path
+		extend_children = false
+	}
+	logic             = "POSITIVE"
+	decision_strategy = "UNANIMOUS"
+	depends_on = [
+		keycloak_openid_client_permissions.realm-management_permission,
+	]
+}
+
+resource "keycloak_role_permissions" "test" {
+	realm_id = data.keycloak_realm.realm.id
+	role_id  = keycloak_role.role.id
+
+	map_role_scope {
+		policies          = [
+			keycloak_openid_client_group_policy.test.id
+		]
+		description       = "map_role_scope"
+		decision_strategy = "UNANIMOUS"
+	}
+}
+	`, testAccRealm.Realm, roleName, groupName, roleName)
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakSamlClientOptionalScopes() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakSamlClientOptionalScopesReconcile,
+		ReadContext:   resourceKeycloakSamlClientOptionalScopesRead,
+		DeleteContext: resourceKeycloakSamlClientOptionalScopesDelete,
+		UpdateContext: resourceKeycloakSamlClientOptionalScopesReconcile,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"optional_scopes": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func samlClientOptionalScopesId(realmId string, clientId string) string {
+	return fmt.Sprintf("%s/%s", realmId, clientId)
+}
+
+func resourceKeycloakSamlClientOptionalScopesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	clientId := data.Get("client_id").(string)
+
+	clientScopes, err := keycloakClient.GetSamlClientOptionalScopes(ctx, realmId, clientId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var optionalScopes []string
+	for _, clientScope := range clientScopes {
+		optionalScopes = append(optionalScopes, clientScope.Name)
+	}
+
+	data.Set("optional_scopes", optionalScopes)
+	data.SetId(samlClientOptionalScopesId(realmId, clientId))
+
+	return nil
+}
+
+func resourceKeycloakSamlClientOptionalScopesReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	clientId := data.Get("client_id").(string)
+	tfSamlClientOptionalScopes := data.Get("optional_scopes").(*schema.Set)
+
+	keycloakSamlClientOptionalScopes, err := keycloakClient.GetSamlClientOptionalScopes(ctx, realmId, clientId)
+	if err != nil {
+		if keycloak.ErrorIs404(err) {
+			return diag.FromErr(fmt.Errorf("validation error: client with id %s does not exist", clientId))
+		}
+		return diag.FromErr(err)
+	}
+
+	var samlClientOptionalScopesToDetach []string
+	for _, keycloakSamlClientOptionalScope := range keycloakSamlClientOptionalScopes {
+		// if this scope is attached in keycloak and tf state, no update is required
+		// remove it from the set so we can look at scopes that need to be attached later
+		if tfSamlClientOptionalScopes.Contains(keycloakSamlClientOptionalScope.Name) {
+			tfSamlClientOptionalScopes.Remove(keycloakSamlClientOptionalScope.Name)
+		} else {
+			// if this scope is attached in keycloak but not in tf state, add them to a slice containing all scopes to detach
+			samlClientOptionalScopesToDetach = append(samlClientOptionalScopesToDetach, keycloakSamlClientOptionalScope.Name)
+		}
+	}
+
+	// detach scopes that aren't in tf state
+	err = keycloakClient.DetachSamlClientOptionalScopes(ctx, realmId, clientId, samlClientOptionalScopesToDetach)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// attach scopes that exist in tf state but not in keycloak
+	err = keycloakClient.AttachSamlClientOptionalScopes(ctx, realmId, clientId, interfaceSliceToStringSlice(tfSamlClientOptionalScopes.List()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(samlClientOptionalScopesId(realmId, clientId))
+
+	return resourceKeycloakSamlClientOptionalScopesRead(ctx, data, meta)
+}
+
+func resourceKeycloakSamlClientOptionalScopesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	clientId := data.Get("client_id").(string)
+	optionalScopes := data.Get("optional_scopes").(*schema.Set)
+
+	return diag.FromErr(keycloakClient.DetachSamlClientOptionalScopes(ctx, realmId, clientId, interfaceSliceToStringSlice(optionalScopes.List())))
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSamlClientOptionalScopes_basic(t *testing.T) {
+	t.Parallel()
+	client := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlClientOptionalScopes_basic(client, clientScope),
+				Check:  testAccCheckKeycloakSamlClientHasOptionalScopes("keycloak_saml_client_optional_scopes.optional_scopes", []string{clientScope}),
+			},
+			// we need a separate test step for destroy instead of using CheckDestroy because this resource is implicitly
+			// destroyed at the end of each test via destroying clients
+			{
+				Config: testKeycloakSamlClientDefaultScopes_noDefaultScopes(client, clientScope),
+				Check:  testAccCheckKeycloakSamlClientOptionalScopeIsNotAttached("keycloak_saml_client.client", clientScope),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlClientOptionalScopes_validateClientDoesNotExist(t *testing.T) {
+	t.Parallel()
+	client := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakSamlClientOptionalScopes_validationNoClient(client, clientScope),
+				ExpectError: regexp.MustCompile("validation error: client with id .+ does not exist"),
+			},
+		},
+	})
+}
+
+// if an optional client scope is manually detached from a client with optional scopes controlled by this resource, terraform should add it again
+func TestAccKeycloakSamlClientOptionalScopes_authoritativeAdd(t *testing.T) {
+	t.Parallel()
+	client := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlClientOptionalScopes_basic(client, clientScope),
+				Check:  testAccCheckKeycloakSamlClientHasOptionalScopes("keycloak_saml_client_optional_scopes.optional_scopes", []string{clientScope}),
+			},
+			{
+				PreConfig: func() {
+					samlClient, err := keycloakClient.GetSamlClientByClientId(testCtx, testAccRealm.Realm, client)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					err = keycloakClient.DetachSamlClientOptionalScopes(testCtx, testAccRealm.Realm, samlClient.Id, []string{clientScope})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakSamlClientOptionalScopes_basic(client, clientScope),
+				Check:  testAccCheckKeycloakSamlClientHasOptionalScopes("keycloak_saml_client_optional_scopes.optional_scopes", []string{clientScope}),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlClientOptionalScopes(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	samlClient := &keycloak.SamlClient{
+		RealmId:  testAccRealm.Realm,
+		ClientId: acctest.RandomWithPrefix("tf-unit"),
+	}
+	if err := keycloakClient.NewSamlClient(testCtx, samlClient); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClient(testCtx, testAccRealm.Realm, samlClient.Id)
+	})
+
+	var scopeNames []string
+	for i := 0; i < 2; i++ {
+		clientScope := &keycloak.SamlClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-unit")}
+		if err := keycloakClient.NewSamlClientScope(testCtx, clientScope); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = keycloakClient.DeleteSamlClientScope(testCtx, testAccRealm.Realm, clientScope.Id)
+		})
+		scopeNames = append(scopeNames, clientScope.Name)
+	}
+
+	optionalScopesResource := testAccProvider.ResourcesMap["keycloak_saml_client_optional_scopes"]
+	data := schema.TestResourceDataRaw(t, optionalScopesResource.Schema, map[string]interface{}{
+		"realm_id":        testAccRealm.Realm,
+		"client_id":       samlClient.Id,
+		"optional_scopes": []interface{}{scopeNames[0]},
+	})
+	if diags := optionalScopesResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != testAccRealm.Realm+"/"+samlClient.Id {
+		t.Errorf("unexpected id %s", data.Id())
+	}
+
+	// a scope attached outside of terraform is detached again
+	if err := keycloakClient.AttachSamlClientOptionalScopes(testCtx, testAccRealm.Realm, samlClient.Id, []string{scopeNames[1]}); err != nil {
+		t.Fatal(err)
+	}
+	if diags := optionalScopesResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Get("optional_scopes").(*schema.Set).Len() != 2 {
+		t.Errorf("expected the drift to be detected, got %v", data.Get("optional_scopes"))
+	}
+
+	data.Set("optional_scopes", []interface{}{scopeNames[0]})
+	if diags := optionalScopesResource.UpdateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	optionalScopes, err := keycloakClient.GetSamlClientOptionalScopes(testCtx, testAccRealm.Realm, samlClient.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(optionalScopes) != 1 || optionalScopes[0].Name != scopeNames[0] {
+		t.Errorf("unexpected optional scopes %v", optionalScopes)
+	}
+
+	// the default scopes of the client are left alone
+	defaultScopes, err := keycloakClient.GetSamlClientDefaultScopes(testCtx, testAccRealm.Realm, samlClient.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defaultScopes) == 0 {
+		t.Error("expected the client to keep the default scopes of the realm")
+	}
+
+	if diags := optionalScopesResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	optionalScopes, err = keycloakClient.GetSamlClientOptionalScopes(testCtx, testAccRealm.Realm, samlClient.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(optionalScopes) != 0 {
+		t.Errorf("expected no optional scopes, got %v", optionalScopes)
+	}
+}
+
+func getOptionalSamlClientScopesFromState(resourceName string, s *terraform.State) ([]*keycloak.SamlClientScope, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	var client string
+	if strings.HasPrefix(resourceName, "keycloak_saml_client_optional_scopes") {
+		client = rs.Primary.Attributes["client_id"]
+	} else {
+		client = rs.Primary.ID
+	}
+
+	keycloakOptionalSamlClientScopes, err := keycloakClient.GetSamlClientOptionalScopes(testCtx, testAccRealm.Realm, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return keycloakOptionalSamlClientScopes, nil
+}
+
+func testAccCheckKeycloakSamlClientHasOptionalScopes(resourceName string, tfOptionalClientScopes []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		keycloakOptionalClientScopes, err := getOptionalSamlClientScopesFromState(resourceName, s)
+		if err != nil {
+			return err
+		}
+
+		for _, tfOptionalClientScope := range tfOptionalClientScopes {
+			found := false
+
+			for _, keycloakOptionalScope := range keycloakOptionalClientScopes {
+				if keycloakOptionalScope.Name == tfOptionalClientScope {
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				return fmt.Errorf("optional scope %s is not assigned to client", tfOptionalClientScope)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakSamlClientOptionalScopeIsNotAttached(resourceName, clientScope string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		keycloakOptionalClientScopes, err := getOptionalSamlClientScopesFromState(resourceName, s)
+		if err != nil {
+			return err
+		}
+
+		for _, keycloakOptionalClientScope := range keycloakOptionalClientScopes {
+			if keycloakOptionalClientScope.Name == clientScope {
+				return fmt.Errorf("expected client scope with name %s to not be attached to client", clientScope)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlClientOptionalScopes_basic(client, clientScope string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client" "client" {
+	client_id   = "%s"
+	realm_id    = data.keycloak_realm.realm.id
+
+	sign_documents          = false
+	sign_assertions         = true
+	include_authn_statement = true
+
+	signing_certificate     = file("testdata/saml-cert.pem")
+	signing_private_key     = file("testdata/saml-key.pem")
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name        = "%s"
+	realm_id    = data.keycloak_realm.realm.id
+
+	description = "test description"
+}
+
+resource "keycloak_saml_client_optional_scopes" "optional_scopes" {
+	realm_id        = data.keycloak_realm.realm.id
+	client_id       = keycloak_saml_client.client.id
+	optional_scopes = [
+		keycloak_saml_client_scope.client_scope.name
+	]
+}
+	`, testAccRealm.Realm, client, clientScope)
+}
+
+func testKeycloakSamlClientOptionalScopes_validationNoClient(client, clientScope string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name        = "%s"
+	realm_id    = data.keycloak_realm.realm.id
+
+	description = "test description"
+}
+
+resource "keycloak_saml_client_optional_scopes" "optional_scopes" {
+	realm_id        = data.keycloak_realm.realm.id
+	client_id       = "%s"
+	optional_scopes = [
+		keycloak_saml_client_scope.client_scope.name
+	]
+}
+	`, testAccRealm.Realm, clientScope, client)
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSamlGroupMembershipProtocolMapper_basicClient(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_group_membership_protocol_mapper.saml_group_membership_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlGroupMembershipProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "single_group_attribute", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlGroupMembershipProtocolMapper_basicClientScope(t *testing.T) {
+	t.Parallel()
+	clientScopeId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_group_membership_protocol_mapper.saml_group_membership_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlGroupMembershipProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_clientScope(clientScopeId, mapperName),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "full_path", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClientScope(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlGroupMembershipProtocolMapper_import(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_group_membership_protocol_mapper.saml_group_membership_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlGroupMembershipProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClient(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlGroupMembershipProtocolMapper_update(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_group_membership_protocol_mapper.saml_group_membership_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlGroupMembershipProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName),
+			},
+			{
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "single_group_attribute", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlGroupMembershipProtocolMapper_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+	var mapper = &keycloak.SamlGroupMembershipProtocolMapper{}
+
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_group_membership_protocol_mapper.saml_group_membership_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlGroupMembershipProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlGroupMembershipProtocolMapperFetch(resourceName, mapper),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteSamlGroupMembershipProtocolMapper(testCtx, mapper.RealmId, mapper.ClientId, mapper.ClientScopeId, mapper.Id)
+					if err != nil {
+						t.Error(err)
+					}
+				},
+				Config: testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlGroupMembershipProtocolMapper(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	clientScope := &keycloak.SamlClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClientScope(testCtx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClientScope(testCtx, testAccRealm.Realm, clientScope.Id)
+	})
+
+	mapperResource := testAccProvider.ResourcesMap["keycloak_saml_group_membership_protocol_mapper"]
+	data := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":                       "group membership",
+		"realm_id":                   testAccRealm.Realm,
+		"client_scope_id":            clientScope.Id,
+		"saml_attribute_name":        "Group",
+		"saml_attribute_name_format": "Basic",
+	})
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	mapper, err := keycloakClient.GetSamlGroupMembershipProtocolMapper(testCtx, testAccRealm.Realm, "", clientScope.Id, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapper.SamlAttributeName != "Group" || mapper.SamlAttributeNameFormat != "Basic" || !mapper.SingleGroupAttribute || !mapper.FullPath {
+		t.Errorf("unexpected mapper %+v", mapper)
+	}
+
+	// a second mapper with the same name is rejected before reaching keycloak
+	duplicate := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":                       "group membership",
+		"realm_id":                   testAccRealm.Realm,
+		"client_scope_id":            clientScope.Id,
+		"saml_attribute_name":        "Group",
+		"saml_attribute_name_format": "Basic",
+	})
+	if diags := mapperResource.CreateContext(testCtx, duplicate, keycloakClient); !diags.HasError() {
+		t.Error("expected a duplicate mapper name to be rejected")
+	}
+
+	if diags := mapperResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := mapperResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != "" {
+		t.Error("expected a deleted mapper to be removed from state")
+	}
+}
+
+func testAccKeycloakSamlGroupMembershipProtocolMapperDestroy() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for resourceName, rs := range state.RootModule().Resources {
+			if rs.Type != "keycloak_saml_group_membership_protocol_mapper" {
+				continue
+			}
+
+			mapper, _ := getSamlGroupMembershipMapperUsingState(state, resourceName)
+
+			if mapper != nil {
+				return fmt.Errorf("saml group membership protocol mapper with id %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlGroupMembershipProtocolMapperExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		_, err := getSamlGroupMembershipMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlGroupMembershipProtocolMapperFetch(resourceName string, mapper *keycloak.SamlGroupMembershipProtocolMapper) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		fetchedMapper, err := getSamlGroupMembershipMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		mapper.Id = fetchedMapper.Id
+		mapper.ClientId = fetchedMapper.ClientId
+		mapper.ClientScopeId = fetchedMapper.ClientScopeId
+		mapper.RealmId = fetchedMapper.RealmId
+
+		return nil
+	}
+}
+
+func getSamlGroupMembershipMapperUsingState(state *terraform.State, resourceName string) (*keycloak.SamlGroupMembershipProtocolMapper, error) {
+	rs, ok := state.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found in TF state: %s ", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+	clientId := rs.Primary.Attributes["client_id"]
+	clientScopeId := rs.Primary.Attributes["client_scope_id"]
+
+	return keycloakClient.GetSamlGroupMembershipProtocolMapper(testCtx, realm, clientId, clientScopeId, id)
+}
+
+func testKeycloakSamlGroupMembershipProtocolMapper_basic_client(clientId, mapperName string, singleGroupAttribute bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client" "saml_client" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_saml_group_membership_protocol_mapper" "saml_group_membership_mapper" {
+	name                       = "%s"
+	realm_id                   = data.keycloak_realm.realm.id
+	client_id                  = keycloak_saml_client.saml_client.id
+
+	saml_attribute_name        = "Group"
+	saml_attribute_name_format = "Basic"
+	single_group_attribute     = %t
+}`, testAccRealm.Realm, clientId, mapperName, singleGroupAttribute)
+}
+
+func testKeycloakSamlGroupMembershipProtocolMapper_basic_clientScope(clientScopeId, mapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_group_membership_protocol_mapper" "saml_group_membership_mapper" {
+	name                       = "%s"
+	realm_id                   = data.keycloak_realm.realm.id
+	client_scope_id            = keycloak_saml_client_scope.client_scope.id
+
+	saml_attribute_name        = "Group"
+	saml_attribute_name_format = "Basic"
+	friendly_name              = "groups"
+	full_path                  = false
+}`, testAccRealm.Realm, clientScopeId, mapperName)
+}
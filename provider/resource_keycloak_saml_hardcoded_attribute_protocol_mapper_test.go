@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSamlHardcodedAttributeProtocolMapper_basicClient(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_attribute_protocol_mapper.saml_hardcoded_attribute_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedAttributeProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName, "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "attribute_value", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlHardcodedAttributeProtocolMapper_basicClientScope(t *testing.T) {
+	t.Parallel()
+	clientScopeId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_attribute_protocol_mapper.saml_hardcoded_attribute_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedAttributeProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_clientScope(clientScopeId, mapperName),
+				Check:  testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClientScope(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlHardcodedAttributeProtocolMapper_import(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_attribute_protocol_mapper.saml_hardcoded_attribute_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedAttributeProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName, "foo"),
+				Check:  testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClient(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlHardcodedAttributeProtocolMapper_update(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_attribute_protocol_mapper.saml_hardcoded_attribute_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedAttributeProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName, "foo"),
+				Check:  testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName),
+			},
+			{
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName, "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "attribute_value", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlHardcodedAttributeProtocolMapper_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+	var mapper = &keycloak.SamlHardcodedAttributeProtocolMapper{}
+
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_attribute_protocol_mapper.saml_hardcoded_attribute_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedAttributeProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName, "foo"),
+				Check:  testKeycloakSamlHardcodedAttributeProtocolMapperFetch(resourceName, mapper),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteSamlHardcodedAttributeProtocolMapper(testCtx, mapper.RealmId, mapper.ClientId, mapper.ClientScopeId, mapper.Id)
+					if err != nil {
+						t.Error(err)
+					}
+				},
+				Config: testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName, "foo"),
+				Check:  testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlHardcodedAttributeProtocolMapper(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	clientScope := &keycloak.SamlClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClientScope(testCtx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClientScope(testCtx, testAccRealm.Realm, clientScope.Id)
+	})
+
+	mapperResource := testAccProvider.ResourcesMap["keycloak_saml_hardcoded_attribute_protocol_mapper"]
+	data := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":                       "hardcoded attribute",
+		"realm_id":                   testAccRealm.Realm,
+		"client_scope_id":            clientScope.Id,
+		"saml_attribute_name":        "tenant",
+		"saml_attribute_name_format": "Basic",
+		"attribute_value":            "acme",
+	})
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	mapper, err := keycloakClient.GetSamlHardcodedAttributeProtocolMapper(testCtx, testAccRealm.Realm, "", clientScope.Id, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapper.SamlAttributeName != "tenant" || mapper.SamlAttributeNameFormat != "Basic" || mapper.AttributeValue != "acme" {
+		t.Errorf("unexpected mapper %+v", mapper)
+	}
+
+	// a second mapper with the same name is rejected before reaching keycloak
+	duplicate := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":                       "hardcoded attribute",
+		"realm_id":                   testAccRealm.Realm,
+		"client_scope_id":            clientScope.Id,
+		"saml_attribute_name":        "tenant",
+		"saml_attribute_name_format": "Basic",
+	})
+	if diags := mapperResource.CreateContext(testCtx, duplicate, keycloakClient); !diags.HasError() {
+		t.Error("expected a duplicate mapper name to be rejected")
+	}
+
+	if diags := mapperResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := mapperResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != "" {
+		t.Error("expected a deleted mapper to be removed from state")
+	}
+}
+
+func testAccKeycloakSamlHardcodedAttributeProtocolMapperDestroy() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for resourceName, rs := range state.RootModule().Resources {
+			if rs.Type != "keycloak_saml_hardcoded_attribute_protocol_mapper" {
+				continue
+			}
+
+			mapper, _ := getSamlHardcodedAttributeMapperUsingState(state, resourceName)
+
+			if mapper != nil {
+				return fmt.Errorf("saml hardcoded attribute protocol mapper with id %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlHardcodedAttributeProtocolMapperExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		_, err := getSamlHardcodedAttributeMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlHardcodedAttributeProtocolMapperFetch(resourceName string, mapper *keycloak.SamlHardcodedAttributeProtocolMapper) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		fetchedMapper, err := getSamlHardcodedAttributeMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		mapper.Id = fetchedMapper.Id
+		mapper.ClientId = fetchedMapper.ClientId
+		mapper.ClientScopeId = fetchedMapper.ClientScopeId
+		mapper.RealmId = fetchedMapper.RealmId
+
+		return nil
+	}
+}
+
+func getSamlHardcodedAttributeMapperUsingState(state *terraform.State, resourceName string) (*keycloak.SamlHardcodedAttributeProtocolMapper, error) {
+	rs, ok := state.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found in TF state: %s ", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+	clientId := rs.Primary.Attributes["client_id"]
+	clientScopeId := rs.Primary.Attributes["client_scope_id"]
+
+	return keycloakClient.GetSamlHardcodedAttributeProtocolMapper(testCtx, realm, clientId, clientScopeId, id)
+}
+
+func testKeycloakSamlHardcodedAttributeProtocolMapper_basic_client(clientId, mapperName string, attributeValue string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client" "saml_client" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_saml_hardcoded_attribute_protocol_mapper" "saml_hardcoded_attribute_mapper" {
+	name                       = "%s"
+	realm_id                   = data.keycloak_realm.realm.id
+	client_id                  = keycloak_saml_client.saml_client.id
+
+	saml_attribute_name        = "tenant"
+	saml_attribute_name_format = "Basic"
+	attribute_value            = "%s"
+}`, testAccRealm.Realm, clientId, mapperName, attributeValue)
+}
+
+func testKeycloakSamlHardcodedAttributeProtocolMapper_basic_clientScope(clientScopeId, mapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_hardcoded_attribute_protocol_mapper" "saml_hardcoded_attribute_mapper" {
+	name                       = "%s"
+	realm_id                   = data.keycloak_realm.realm.id
+	client_scope_id            = keycloak_saml_client_scope.client_scope.id
+
+	saml_attribute_name        = "tenant"
+	saml_attribute_name_format = "Basic"
+	friendly_name              = "tenant"
+	attribute_value            = "acme"
+}`, testAccRealm.Realm, clientScopeId, mapperName)
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSamlHardcodedRoleProtocolMapper_basicRealmRole_client(t *testing.T) {
+	t.Parallel()
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_role_protocol_mapper.saml_hardcoded_role_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedRoleProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedRoleProtocolMapper_basicRealmRole_client(role, clientId, mapperName),
+				Check:  testKeycloakSamlHardcodedRoleProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClient(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlHardcodedRoleProtocolMapper_basicClientRole_clientScope(t *testing.T) {
+	t.Parallel()
+	clientIdForRole := acctest.RandomWithPrefix("tf-acc")
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientScopeId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_role_protocol_mapper.saml_hardcoded_role_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedRoleProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedRoleProtocolMapper_basicClientRole_clientScope(clientIdForRole, role, clientScopeId, mapperName),
+				Check:  testKeycloakSamlHardcodedRoleProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClientScope(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlHardcodedRoleProtocolMapper_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+	var mapper = &keycloak.SamlHardcodedRoleProtocolMapper{}
+
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_hardcoded_role_protocol_mapper.saml_hardcoded_role_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlHardcodedRoleProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlHardcodedRoleProtocolMapper_basicRealmRole_client(role, clientId, mapperName),
+				Check:  testKeycloakSamlHardcodedRoleProtocolMapperFetch(resourceName, mapper),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteSamlHardcodedRoleProtocolMapper(testCtx, mapper.RealmId, mapper.ClientId, mapper.ClientScopeId, mapper.Id)
+					if err != nil {
+						t.Error(err)
+					}
+				},
+				Config: testKeycloakSamlHardcodedRoleProtocolMapper_basicRealmRole_client(role, clientId, mapperName),
+				Check:  testKeycloakSamlHardcodedRoleProtocolMapperExists(resourceName),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlHardcodedRoleProtocolMapper(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	samlClient := &keycloak.SamlClient{RealmId: testAccRealm.Realm, ClientId: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClient(testCtx, samlClient); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClient(testCtx, testAccRealm.Realm, samlClient.Id)
+	})
+
+	// client roles are referenced by the client id of their client, which keycloak resolves again on read
+	role := &keycloak.Role{RealmId: testAccRealm.Realm, ClientId: samlClient.Id, Name: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.CreateRole(testCtx, role); err != nil {
+		t.Fatal(err)
+	}
+
+	mapperResource := testAccProvider.ResourcesMap["keycloak_saml_hardcoded_role_protocol_mapper"]
+	data := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":      "hardcoded role",
+		"realm_id":  testAccRealm.Realm,
+		"client_id": samlClient.Id,
+		"role_id":   role.Id,
+	})
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Get("role_id").(string) != role.Id {
+		t.Errorf("expected role_id %s, got %s", role.Id, data.Get("role_id"))
+	}
+
+	protocolMappers, err := keycloakClient.ListGenericProtocolMappers(testCtx, testAccRealm.Realm, samlClient.Id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protocolMappers) != 1 || protocolMappers[0].Config["role"] != samlClient.ClientId+"."+role.Name {
+		t.Errorf("unexpected protocol mappers %+v", protocolMappers)
+	}
+
+	if diags := mapperResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+func testAccKeycloakSamlHardcodedRoleProtocolMapperDestroy() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for resourceName, rs := range state.RootModule().Resources {
+			if rs.Type != "keycloak_saml_hardcoded_role_protocol_mapper" {
+				continue
+			}
+
+			mapper, _ := getSamlHardcodedRoleMapperUsingState(state, resourceName)
+
+			if mapper != nil {
+				return fmt.Errorf("saml hardcoded role protocol mapper with id %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlHardcodedRoleProtocolMapperExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		_, err := getSamlHardcodedRoleMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlHardcodedRoleProtocolMapperFetch(resourceName string, mapper *keycloak.SamlHardcodedRoleProtocolMapper) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		fetchedMapper, err := getSamlHardcodedRoleMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		mapper.Id = fetchedMapper.Id
+		mapper.ClientId = fetchedMapper.ClientId
+		mapper.ClientScopeId = fetchedMapper.ClientScopeId
+		mapper.RealmId = fetchedMapper.RealmId
+
+		return nil
+	}
+}
+
+func getSamlHardcodedRoleMapperUsingState(state *terraform.State, resourceName string) (*keycloak.SamlHardcodedRoleProtocolMapper, error) {
+	rs, ok := state.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found in TF state: %s ", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+	clientId := rs.Primary.Attributes["client_id"]
+	clientScopeId := rs.Primary.Attributes["client_scope_id"]
+
+	return keycloakClient.GetSamlHardcodedRoleProtocolMapper(testCtx, realm, clientId, clientScopeId, id)
+}
+
+func testKeycloakSamlHardcodedRoleProtocolMapper_basicRealmRole_client(role, clientId, mapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_role" "role" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_client" "saml_client" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_saml_hardcoded_role_protocol_mapper" "saml_hardcoded_role_mapper" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_saml_client.saml_client.id
+	role_id   = keycloak_role.role.id
+}`, testAccRealm.Realm, role, clientId, mapperName)
+}
+
+func testKeycloakSamlHardcodedRoleProtocolMapper_basicClientRole_clientScope(clientIdForRole, role, clientScopeId, mapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client" "saml_client_for_role" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_role" "role" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_saml_client.saml_client_for_role.id
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_hardcoded_role_protocol_mapper" "saml_hardcoded_role_mapper" {
+	name            = "%s"
+	realm_id        = data.keycloak_realm.realm.id
+	client_scope_id = keycloak_saml_client_scope.client_scope.id
+	role_id         = keycloak_role.role.id
+}`, testAccRealm.Realm, clientIdForRole, role, clientScopeId, mapperName)
+}
@@ -1,8 +1,14 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"dario.cat/mergo"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
@@ -95,7 +101,26 @@ func resourceKeycloakSamlIdentityProvider() *schema.Resource {
 		"signing_certificate": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Signing Certificate.",
+			Description: "Signing Certificate. Several certificates can be separated by commas, so the signing key of the identity provider can be rolled over.",
+		},
+		"signing_certificates": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+			Description: "The certificates of signing_certificate.",
+		},
+		"metadata_url": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ValidateFunc:  validation.IsURLWithHTTPorHTTPS,
+			ConflictsWith: []string{"metadata_xml"},
+			Description:   "The URL of the SAML metadata of the identity provider. The endpoints, signing certificates and NameID format which aren't configured explicitly are imported from it, and kept in sync with it.",
+		},
+		"metadata_xml": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"metadata_url"},
+			Description:   "The SAML metadata of the identity provider, as an XML document. The endpoints, signing certificates and NameID format which aren't configured explicitly are imported from it.",
 		},
 		"signature_algorithm": {
 			Type:         schema.TypeString,
@@ -183,9 +208,79 @@ func resourceKeycloakSamlIdentityProvider() *schema.Resource {
 	samlResource.CreateContext = resourceKeycloakIdentityProviderCreate(getSamlIdentityProviderFromData, setSamlIdentityProviderData)
 	samlResource.ReadContext = resourceKeycloakIdentityProviderRead(setSamlIdentityProviderData)
 	samlResource.UpdateContext = resourceKeycloakIdentityProviderUpdate(getSamlIdentityProviderFromData, setSamlIdentityProviderData)
+
+	metadata := &identityProviderImport{
+		sources:    []string{"realm", "provider_id", "metadata_url", "metadata_xml"},
+		attributes: []string{"single_sign_on_service_url", "single_logout_service_url", "signing_certificate", "name_id_policy_format"},
+		values:     getSamlIdentityProviderImportedValues,
+	}
+	metadata.apply(samlResource)
+
+	samlResource.CustomizeDiff = customdiff.All(samlResource.CustomizeDiff, resourceKeycloakSamlIdentityProviderSigningCertificatesDiff)
+	samlResource.ValidateRawResourceConfigFuncs = []schema.ValidateRawResourceConfigFunc{
+		requiredWithoutAll(cty.GetAttrPath("single_sign_on_service_url"), []cty.Path{cty.GetAttrPath("metadata_url"), cty.GetAttrPath("metadata_xml")}),
+	}
+
 	return samlResource
 }
 
+// getSamlIdentityProviderImportedValues has keycloak read the metadata of the identity provider, all the values are
+// empty without metadata
+func getSamlIdentityProviderImportedValues(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data identityProviderImportData) (map[string]string, error) {
+	values := map[string]string{}
+
+	realm := data.Get("realm").(string)
+	providerId := data.Get("provider_id").(string)
+
+	var config map[string]string
+	var err error
+	if metadataUrl := data.Get("metadata_url").(string); metadataUrl != "" {
+		config, err = keycloakClient.ImportIdentityProviderConfig(ctx, realm, providerId, metadataUrl)
+	} else if metadataXml := data.Get("metadata_xml").(string); metadataXml != "" {
+		config, err = keycloakClient.ImportIdentityProviderConfigFromDocument(ctx, realm, providerId, metadataXml)
+	} else {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error importing the saml metadata: %w", err)
+	}
+
+	values["single_sign_on_service_url"] = config["singleSignOnServiceUrl"]
+	values["single_logout_service_url"] = config["singleLogoutServiceUrl"]
+	values["signing_certificate"] = config["signingCertificate"]
+	values["name_id_policy_format"] = ""
+	for name, format := range nameIdPolicyFormats {
+		if format == config["nameIDPolicyFormat"] {
+			values["name_id_policy_format"] = name
+		}
+	}
+
+	return values, nil
+}
+
+func resourceKeycloakSamlIdentityProviderSigningCertificatesDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("signing_certificate") {
+		return d.SetNewComputed("signing_certificates")
+	}
+
+	if !d.HasChange("signing_certificate") {
+		return nil
+	}
+
+	return d.SetNew("signing_certificates", splitSigningCertificates(d.Get("signing_certificate").(string)))
+}
+
+func splitSigningCertificates(signingCertificate string) []interface{} {
+	signingCertificates := make([]interface{}, 0)
+	for _, certificate := range strings.Split(signingCertificate, ",") {
+		if certificate = strings.TrimSpace(certificate); certificate != "" {
+			signingCertificates = append(signingCertificates, certificate)
+		}
+	}
+
+	return signingCertificates
+}
+
 func getSamlIdentityProviderFromData(data *schema.ResourceData, keycloakVersion *version.Version) (*keycloak.IdentityProvider, error) {
 	rec, defaultConfig := getIdentityProviderFromData(data, keycloakVersion)
 	rec.ProviderId = data.Get("provider_id").(string)
@@ -258,6 +353,7 @@ func setSamlIdentityProviderData(data *schema.ResourceData, identityProvider *ke
 	data.Set("single_logout_service_url", identityProvider.Config.SingleLogoutServiceUrl)
 	data.Set("single_sign_on_service_url", identityProvider.Config.SingleSignOnServiceUrl)
 	data.Set("signing_certificate", identityProvider.Config.SigningCertificate)
+	data.Set("signing_certificates", splitSigningCertificates(identityProvider.Config.SigningCertificate))
 	data.Set("signature_algorithm", identityProvider.Config.SignatureAlgorithm)
 	data.Set("xml_sign_key_info_key_name_transformer", identityProvider.Config.XmlSigKeyInfoKeyNameTransformer)
 	data.Set("post_binding_authn_request", identityProvider.Config.PostBindingAuthnRequest)
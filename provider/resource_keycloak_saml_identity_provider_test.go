@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
@@ -260,6 +261,99 @@ func TestAccKeycloakSamlIdentityProvider_basicUpdateAll(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakSamlIdentityProvider_metadataXml(t *testing.T) {
+	t.Parallel()
+
+	samlName := acctest.RandomWithPrefix("tf-acc")
+	_, currentCertificate := generateKeyAndCert(2048)
+	_, nextCertificate := generateKeyAndCert(2048)
+	resourceName := "keycloak_saml_identity_provider.saml"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSamlIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlIdentityProvider_metadataXml(samlName, testKeycloakSamlIdentityProviderMetadata(currentCertificate)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "single_sign_on_service_url", "https://idp.example.com/sso"),
+					resource.TestCheckResourceAttr(resourceName, "single_logout_service_url", "https://idp.example.com/slo"),
+					resource.TestCheckResourceAttr(resourceName, "name_id_policy_format", "Email"),
+					resource.TestCheckResourceAttr(resourceName, "signing_certificates.#", "1"),
+				),
+			},
+			{
+				// the identity provider publishes its next signing certificate ahead of the rollover
+				Config: testKeycloakSamlIdentityProvider_metadataXml(samlName, testKeycloakSamlIdentityProviderMetadata(currentCertificate, nextCertificate)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "signing_certificate", currentCertificate+","+nextCertificate),
+					resource.TestCheckResourceAttr(resourceName, "signing_certificates.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "signing_certificates.1", nextCertificate),
+				),
+			},
+			{
+				ResourceName:            "keycloak_saml_identity_provider.saml",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/" + samlName,
+				ImportStateVerifyIgnore: []string{"metadata_xml"},
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlIdentityProvider_metadataXml(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	alias := acctest.RandomWithPrefix("tf-unit")
+	attributes := map[string]interface{}{
+		"realm":        testAccRealm.Realm,
+		"alias":        alias,
+		"entity_id":    "https://sp.example.com",
+		"metadata_xml": testKeycloakSamlIdentityProviderMetadata("current"),
+	}
+
+	samlResource := testAccProvider.ResourcesMap["keycloak_saml_identity_provider"]
+	data := schema.TestResourceDataRaw(t, samlResource.Schema, attributes)
+	if diags := samlResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteIdentityProvider(testCtx, testAccRealm.Realm, alias)
+	})
+
+	identityProvider, err := keycloakClient.GetIdentityProvider(testCtx, testAccRealm.Realm, alias)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkIdentityProviderConfigValues(identityProvider, map[string]string{
+		"singleSignOnServiceUrl": "https://idp.example.com/sso",
+		"singleLogoutServiceUrl": "https://idp.example.com/slo",
+		"nameIDPolicyFormat":     "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+		"signingCertificate":     "current",
+	}); err != nil {
+		t.Error(err)
+	}
+
+	// a new signing certificate in the metadata shows up in the plan
+	attributes["metadata_xml"] = testKeycloakSamlIdentityProviderMetadata("current", "next")
+
+	diff, err := samlResource.Diff(testCtx, data.State(), terraform.NewResourceConfigRaw(attributes), keycloakClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["signing_certificate"] == nil || diff.Attributes["signing_certificate"].New != "current,next" {
+		t.Fatalf("expected a diff of signing_certificate, got %v", diff)
+	}
+	if diff.Attributes["signing_certificates.1"] == nil || diff.Attributes["signing_certificates.1"].New != "next" {
+		t.Errorf("expected the next certificate to be added to signing_certificates, got %v", diff)
+	}
+	if diff.Attributes["single_sign_on_service_url"] != nil {
+		t.Errorf("expected single_sign_on_service_url not to change, got %v", diff.Attributes["single_sign_on_service_url"])
+	}
+}
+
 func testAccCheckKeycloakSamlIdentityProviderExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		_, err := getKeycloakSamlIdentityProviderFromState(s, resourceName)
@@ -509,3 +603,39 @@ resource "keycloak_saml_identity_provider" "saml" {
 }
 	`, testAccRealm.Realm, organizationName, saml)
 }
+
+func testKeycloakSamlIdentityProvider_metadataXml(saml, metadata string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_identity_provider" "saml" {
+	realm     = data.keycloak_realm.realm.id
+	alias     = "%s"
+	entity_id = "https://example.com/entity_id"
+
+	metadata_xml = <<EOT
+%s
+EOT
+}
+	`, testAccRealm.Realm, saml, metadata)
+}
+
+func testKeycloakSamlIdentityProviderMetadata(signingCertificates ...string) string {
+	keyDescriptors := ""
+	for _, signingCertificate := range signingCertificates {
+		keyDescriptors += fmt.Sprintf(`
+		<md:KeyDescriptor use="signing">
+			<ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+		</md:KeyDescriptor>`, signingCertificate)
+	}
+
+	return fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com">
+	<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">%s
+		<md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/slo"/>
+		<md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress</md:NameIDFormat>
+		<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso"/>
+	</md:IDPSSODescriptor>
+</md:EntityDescriptor>`, keyDescriptors)
+}
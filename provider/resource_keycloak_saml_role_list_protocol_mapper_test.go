@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSamlRoleListProtocolMapper_basicClient(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_list_protocol_mapper.saml_role_list_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleListProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlRoleListProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "single_role_attribute", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleListProtocolMapper_basicClientScope(t *testing.T) {
+	t.Parallel()
+	clientScopeId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_list_protocol_mapper.saml_role_list_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleListProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_clientScope(clientScopeId, mapperName),
+				Check:  testKeycloakSamlRoleListProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClientScope(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleListProtocolMapper_import(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_list_protocol_mapper.saml_role_list_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleListProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlRoleListProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClient(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleListProtocolMapper_update(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_list_protocol_mapper.saml_role_list_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleListProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlRoleListProtocolMapperExists(resourceName),
+			},
+			{
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlRoleListProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "single_role_attribute", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleListProtocolMapper_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+	var mapper = &keycloak.SamlRoleListProtocolMapper{}
+
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_list_protocol_mapper.saml_role_list_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleListProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlRoleListProtocolMapperFetch(resourceName, mapper),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteSamlRoleListProtocolMapper(testCtx, mapper.RealmId, mapper.ClientId, mapper.ClientScopeId, mapper.Id)
+					if err != nil {
+						t.Error(err)
+					}
+				},
+				Config: testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName, true),
+				Check:  testKeycloakSamlRoleListProtocolMapperExists(resourceName),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlRoleListProtocolMapper(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	clientScope := &keycloak.SamlClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClientScope(testCtx, clientScope); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClientScope(testCtx, testAccRealm.Realm, clientScope.Id)
+	})
+
+	mapperResource := testAccProvider.ResourcesMap["keycloak_saml_role_list_protocol_mapper"]
+	data := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":                       "role list",
+		"realm_id":                   testAccRealm.Realm,
+		"client_scope_id":            clientScope.Id,
+		"saml_attribute_name":        "Role",
+		"saml_attribute_name_format": "Basic",
+	})
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	mapper, err := keycloakClient.GetSamlRoleListProtocolMapper(testCtx, testAccRealm.Realm, "", clientScope.Id, data.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapper.SamlAttributeName != "Role" || mapper.SamlAttributeNameFormat != "Basic" || !mapper.SingleRoleAttribute {
+		t.Errorf("unexpected mapper %+v", mapper)
+	}
+
+	// a second mapper with the same name is rejected before reaching keycloak
+	duplicate := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":                       "role list",
+		"realm_id":                   testAccRealm.Realm,
+		"client_scope_id":            clientScope.Id,
+		"saml_attribute_name":        "Role",
+		"saml_attribute_name_format": "Basic",
+	})
+	if diags := mapperResource.CreateContext(testCtx, duplicate, keycloakClient); !diags.HasError() {
+		t.Error("expected a duplicate mapper name to be rejected")
+	}
+
+	if diags := mapperResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := mapperResource.ReadContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != "" {
+		t.Error("expected a deleted mapper to be removed from state")
+	}
+}
+
+func testAccKeycloakSamlRoleListProtocolMapperDestroy() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for resourceName, rs := range state.RootModule().Resources {
+			if rs.Type != "keycloak_saml_role_list_protocol_mapper" {
+				continue
+			}
+
+			mapper, _ := getSamlRoleListMapperUsingState(state, resourceName)
+
+			if mapper != nil {
+				return fmt.Errorf("saml role list protocol mapper with id %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlRoleListProtocolMapperExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		_, err := getSamlRoleListMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlRoleListProtocolMapperFetch(resourceName string, mapper *keycloak.SamlRoleListProtocolMapper) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		fetchedMapper, err := getSamlRoleListMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		mapper.Id = fetchedMapper.Id
+		mapper.ClientId = fetchedMapper.ClientId
+		mapper.ClientScopeId = fetchedMapper.ClientScopeId
+		mapper.RealmId = fetchedMapper.RealmId
+
+		return nil
+	}
+}
+
+func getSamlRoleListMapperUsingState(state *terraform.State, resourceName string) (*keycloak.SamlRoleListProtocolMapper, error) {
+	rs, ok := state.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found in TF state: %s ", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+	clientId := rs.Primary.Attributes["client_id"]
+	clientScopeId := rs.Primary.Attributes["client_scope_id"]
+
+	return keycloakClient.GetSamlRoleListProtocolMapper(testCtx, realm, clientId, clientScopeId, id)
+}
+
+func testKeycloakSamlRoleListProtocolMapper_basic_client(clientId, mapperName string, singleRoleAttribute bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client" "saml_client" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_saml_role_list_protocol_mapper" "saml_role_list_mapper" {
+	name                       = "%s"
+	realm_id                   = data.keycloak_realm.realm.id
+	client_id                  = keycloak_saml_client.saml_client.id
+
+	saml_attribute_name        = "Role"
+	saml_attribute_name_format = "Basic"
+	single_role_attribute      = %t
+}`, testAccRealm.Realm, clientId, mapperName, singleRoleAttribute)
+}
+
+func testKeycloakSamlRoleListProtocolMapper_basic_clientScope(clientScopeId, mapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_role_list_protocol_mapper" "saml_role_list_mapper" {
+	name                       = "%s"
+	realm_id                   = data.keycloak_realm.realm.id
+	client_scope_id            = keycloak_saml_client_scope.client_scope.id
+
+	saml_attribute_name        = "Role"
+	saml_attribute_name_format = "Basic"
+	friendly_name              = "role"
+}`, testAccRealm.Realm, clientScopeId, mapperName)
+}
@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(t *testing.T) {
+	t.Parallel()
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_name_protocol_mapper.saml_role_name_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleNameProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(role, clientId, mapperName, "renamed"),
+				Check:  testKeycloakSamlRoleNameProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClient(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleNameProtocolMapper_basicClientRole_clientScope(t *testing.T) {
+	t.Parallel()
+	clientIdForRole := acctest.RandomWithPrefix("tf-acc")
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientScopeId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_name_protocol_mapper.saml_role_name_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleNameProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleNameProtocolMapper_basicClientRole_clientScope(clientIdForRole, role, clientScopeId, mapperName),
+				Check:  testKeycloakSamlRoleNameProtocolMapperExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getGenericProtocolMapperIdForClientScope(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleNameProtocolMapper_update(t *testing.T) {
+	t.Parallel()
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_name_protocol_mapper.saml_role_name_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleNameProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(role, clientId, mapperName, "renamed"),
+				Check:  testKeycloakSamlRoleNameProtocolMapperExists(resourceName),
+			},
+			{
+				Config: testKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(role, clientId, mapperName, "renamed-again"),
+				Check: resource.ComposeTestCheckFunc(
+					testKeycloakSamlRoleNameProtocolMapperExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "new_role_name", "renamed-again"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSamlRoleNameProtocolMapper_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+	var mapper = &keycloak.SamlRoleNameProtocolMapper{}
+
+	role := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	mapperName := acctest.RandomWithPrefix("tf-acc")
+
+	resourceName := "keycloak_saml_role_name_protocol_mapper.saml_role_name_mapper"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccKeycloakSamlRoleNameProtocolMapperDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(role, clientId, mapperName, "renamed"),
+				Check:  testKeycloakSamlRoleNameProtocolMapperFetch(resourceName, mapper),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteSamlRoleNameProtocolMapper(testCtx, mapper.RealmId, mapper.ClientId, mapper.ClientScopeId, mapper.Id)
+					if err != nil {
+						t.Error(err)
+					}
+				},
+				Config: testKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(role, clientId, mapperName, "renamed"),
+				Check:  testKeycloakSamlRoleNameProtocolMapperExists(resourceName),
+			},
+		},
+	})
+}
+
+func TestUnitKeycloakSamlRoleNameProtocolMapper(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	samlClient := &keycloak.SamlClient{RealmId: testAccRealm.Realm, ClientId: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.NewSamlClient(testCtx, samlClient); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteSamlClient(testCtx, testAccRealm.Realm, samlClient.Id)
+	})
+
+	// client roles are referenced by the client id of their client, which keycloak resolves again on read
+	role := &keycloak.Role{RealmId: testAccRealm.Realm, ClientId: samlClient.Id, Name: acctest.RandomWithPrefix("tf-unit")}
+	if err := keycloakClient.CreateRole(testCtx, role); err != nil {
+		t.Fatal(err)
+	}
+
+	mapperResource := testAccProvider.ResourcesMap["keycloak_saml_role_name_protocol_mapper"]
+	data := schema.TestResourceDataRaw(t, mapperResource.Schema, map[string]interface{}{
+		"name":          "role name",
+		"realm_id":      testAccRealm.Realm,
+		"client_id":     samlClient.Id,
+		"role_id":       role.Id,
+		"new_role_name": "renamed",
+	})
+	if diags := mapperResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Get("role_id").(string) != role.Id {
+		t.Errorf("expected role_id %s, got %s", role.Id, data.Get("role_id"))
+	}
+
+	protocolMappers, err := keycloakClient.ListGenericProtocolMappers(testCtx, testAccRealm.Realm, samlClient.Id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protocolMappers) != 1 || protocolMappers[0].Config["role"] != samlClient.ClientId+"."+role.Name || protocolMappers[0].Config["new.role.name"] != "renamed" {
+		t.Errorf("unexpected protocol mappers %+v", protocolMappers)
+	}
+
+	if diags := mapperResource.DeleteContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+func testAccKeycloakSamlRoleNameProtocolMapperDestroy() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for resourceName, rs := range state.RootModule().Resources {
+			if rs.Type != "keycloak_saml_role_name_protocol_mapper" {
+				continue
+			}
+
+			mapper, _ := getSamlRoleNameMapperUsingState(state, resourceName)
+
+			if mapper != nil {
+				return fmt.Errorf("saml role name protocol mapper with id %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlRoleNameProtocolMapperExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		_, err := getSamlRoleNameMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakSamlRoleNameProtocolMapperFetch(resourceName string, mapper *keycloak.SamlRoleNameProtocolMapper) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		fetchedMapper, err := getSamlRoleNameMapperUsingState(state, resourceName)
+		if err != nil {
+			return err
+		}
+
+		mapper.Id = fetchedMapper.Id
+		mapper.ClientId = fetchedMapper.ClientId
+		mapper.ClientScopeId = fetchedMapper.ClientScopeId
+		mapper.RealmId = fetchedMapper.RealmId
+
+		return nil
+	}
+}
+
+func getSamlRoleNameMapperUsingState(state *terraform.State, resourceName string) (*keycloak.SamlRoleNameProtocolMapper, error) {
+	rs, ok := state.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found in TF state: %s ", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+	clientId := rs.Primary.Attributes["client_id"]
+	clientScopeId := rs.Primary.Attributes["client_scope_id"]
+
+	return keycloakClient.GetSamlRoleNameProtocolMapper(testCtx, realm, clientId, clientScopeId, id)
+}
+
+func testKeycloakSamlRoleNameProtocolMapper_basicRealmRole_client(role, clientId, mapperName, newRoleName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_role" "role" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_client" "saml_client" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_saml_role_name_protocol_mapper" "saml_role_name_mapper" {
+	name          = "%s"
+	realm_id      = data.keycloak_realm.realm.id
+	client_id     = keycloak_saml_client.saml_client.id
+	role_id       = keycloak_role.role.id
+	new_role_name = "%s"
+}`, testAccRealm.Realm, role, clientId, mapperName, newRoleName)
+}
+
+func testKeycloakSamlRoleNameProtocolMapper_basicClientRole_clientScope(clientIdForRole, role, clientScopeId, mapperName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_saml_client" "saml_client_for_role" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "%s"
+}
+
+resource "keycloak_role" "role" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_saml_client.saml_client_for_role.id
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_saml_role_name_protocol_mapper" "saml_role_name_mapper" {
+	name            = "%s"
+	realm_id        = data.keycloak_realm.realm.id
+	client_scope_id = keycloak_saml_client_scope.client_scope.id
+	role_id         = keycloak_role.role.id
+	new_role_name   = "renamed"
+}`, testAccRealm.Realm, clientIdForRole, role, clientScopeId, mapperName)
+}
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var (
+	keycloakUserCredentialOtpAlgorithms = []string{"HmacSHA1", "HmacSHA256", "HmacSHA512"}
+)
+
+func resourceKeycloakUserCredential() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakUserCredentialCreate,
+		ReadContext:   resourceKeycloakUserCredentialRead,
+		DeleteContext: resourceKeycloakUserCredentialDelete,
+		UpdateContext: resourceKeycloakUserCredentialUpdate,
+		// This resource can be imported using {{realmId}}/{{userId}}/{{credentialId}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakUserCredentialImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the credential. Only password and otp credentials can be created, other types such as webauthn can be imported.",
+			},
+			"user_label": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"move_to_first": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the credential is kept first in the priority order of the user's credentials.",
+			},
+			"password": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"otp"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": {
+							Type:      schema.TypeString,
+							Required:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"temporary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"otp": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"password"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"secret": {
+							Type:      schema.TypeString,
+							Required:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"algorithm": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "HmacSHA1",
+							ValidateFunc: validation.StringInSlice(keycloakUserCredentialOtpAlgorithms, false),
+						},
+						"digits": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      6,
+							ValidateFunc: validation.IntInSlice([]int{6, 8}),
+						},
+						"period": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"created_date": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func userCredentialId(realmId, userId, credentialId string) string {
+	return fmt.Sprintf("%s/%s/%s", realmId, userId, credentialId)
+}
+
+func parseUserCredentialId(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{userId}}/{{credentialId}}")
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func resourceKeycloakUserCredentialCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	userId := data.Get("user_id").(string)
+	credentialType := data.Get("type").(string)
+	userLabel := data.Get("user_label").(string)
+
+	var credential *keycloak.UserCredential
+	var err error
+
+	switch credentialType {
+	case "password":
+		v, ok := data.GetOk("password")
+		if !ok {
+			return diag.Errorf("a password block is required to create a credential of type password")
+		}
+		password := v.([]interface{})[0].(map[string]interface{})
+
+		credential, err = keycloakClient.SetUserPasswordCredential(ctx, realmId, userId, password["value"].(string), password["temporary"].(bool))
+	case "otp":
+		v, ok := data.GetOk("otp")
+		if !ok {
+			return diag.Errorf("an otp block is required to create a credential of type otp")
+		}
+		otp := v.([]interface{})[0].(map[string]interface{})
+
+		credential, err = keycloakClient.NewUserOtpCredential(ctx, realmId, userId, userLabel, otp["secret"].(string), keycloak.OtpCredentialData{
+			SubType:   "totp",
+			Digits:    otp["digits"].(int),
+			Period:    otp["period"].(int),
+			Algorithm: otp["algorithm"].(string),
+		})
+	default:
+		return diag.Errorf("credentials of type %s can't be created through the admin API, import an existing one instead", credentialType)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(userCredentialId(realmId, userId, credential.Id))
+
+	if userLabel != "" && userLabel != credential.UserLabel {
+		err = keycloakClient.UpdateUserCredentialLabel(ctx, realmId, userId, credential.Id, userLabel)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if data.Get("move_to_first").(bool) {
+		err = keycloakClient.MoveUserCredentialToFirst(ctx, realmId, userId, credential.Id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeycloakUserCredentialRead(ctx, data, meta)
+}
+
+func resourceKeycloakUserCredentialRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId, userId, credentialId, err := parseUserCredentialId(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	credentials, err := keycloakClient.GetUserCredentials(ctx, realmId, userId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var credential *keycloak.UserCredential
+	position := 0
+	for i, c := range credentials {
+		if c.Id == credentialId {
+			credential = c
+			position = i
+		}
+	}
+	if credential == nil {
+		tflog.Warn(ctx, "Removing resource with id from state as it no longer exists", map[string]interface{}{
+			"id": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	}
+
+	data.Set("realm_id", realmId)
+	data.Set("user_id", userId)
+	data.Set("type", credential.Type)
+	data.Set("user_label", credential.UserLabel)
+	data.Set("created_date", credential.CreatedDate)
+
+	// a credential moved down by the user or another credential moved to first shows up as drift
+	if data.Get("move_to_first").(bool) && position != 0 {
+		data.Set("move_to_first", false)
+	}
+
+	return nil
+}
+
+func resourceKeycloakUserCredentialUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId, userId, credentialId, err := parseUserCredentialId(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if data.HasChange("user_label") {
+		err = keycloakClient.UpdateUserCredentialLabel(ctx, realmId, userId, credentialId, data.Get("user_label").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if data.Get("move_to_first").(bool) {
+		err = keycloakClient.MoveUserCredentialToFirst(ctx, realmId, userId, credentialId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeycloakUserCredentialRead(ctx, data, meta)
+}
+
+func resourceKeycloakUserCredentialDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId, userId, credentialId, err := parseUserCredentialId(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.DeleteUserCredential(ctx, realmId, userId, credentialId)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakUserCredentialImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId, userId, credentialId, err := parseUserCredentialId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = keycloakClient.GetUserCredential(ctx, realmId, userId, credentialId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("move_to_first", false)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,367 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakUserCredential_basic(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakUserCredentialDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakUserCredential_basic(username, "authenticator", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_user_credential.otp", "type", "otp"),
+					resource.TestCheckResourceAttr("keycloak_user_credential.otp", "user_label", "authenticator"),
+					resource.TestCheckResourceAttrSet("keycloak_user_credential.otp", "created_date"),
+					testAccCheckKeycloakUserCredentialPosition("keycloak_user_credential.password", 0),
+				),
+			},
+			{
+				Config: testKeycloakUserCredential_basic(username, "phone", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_user_credential.otp", "user_label", "phone"),
+					testAccCheckKeycloakUserCredentialPosition("keycloak_user_credential.otp", 0),
+				),
+			},
+			{
+				ResourceName:            "keycloak_user_credential.otp",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"otp", "move_to_first"},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakUserCredential_movedOutOfBand(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakUserCredentialDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakUserCredential_basic(username, "authenticator", true),
+				Check:  testAccCheckKeycloakUserCredentialPosition("keycloak_user_credential.otp", 0),
+			},
+			{
+				PreConfig: func() {
+					user, err := keycloakClient.GetUserByUsername(testCtx, testAccRealm.Realm, username)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					credentials, err := keycloakClient.GetUserCredentials(testCtx, testAccRealm.Realm, user.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					err = keycloakClient.MoveUserCredentialToFirst(testCtx, testAccRealm.Realm, user.Id, credentials[len(credentials)-1].Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakUserCredential_basic(username, "authenticator", true),
+				Check:  testAccCheckKeycloakUserCredentialPosition("keycloak_user_credential.otp", 0),
+			},
+		},
+	})
+}
+
+// TestAccKeycloakUserCredential_otpSeeded checks against a real Keycloak that the OTP credential added through the user
+// update is stored with the requested settings
+func TestAccKeycloakUserCredential_otpSeeded(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakUserCredentialDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakUserCredential_otpSettings(username),
+				Check: testAccCheckKeycloakUserOtpCredentialExists("keycloak_user_credential.otp", keycloak.OtpCredentialData{
+					SubType:   "totp",
+					Digits:    8,
+					Period:    60,
+					Algorithm: "HmacSHA256",
+				}),
+			},
+		},
+	})
+}
+
+// TestUnitKeycloakUserCredential_crud drives the resource functions against the fake Keycloak
+func TestUnitKeycloakUserCredential_crud(t *testing.T) {
+	testUnitFakeOnly(t)
+
+	user := createTestUser(t, acctest.RandomWithPrefix("tf-unit"))
+
+	credentialResource := testAccProvider.ResourcesMap["keycloak_user_credential"]
+	password := schema.TestResourceDataRaw(t, credentialResource.Schema, map[string]interface{}{
+		"realm_id": testAccRealm.Realm,
+		"user_id":  user,
+		"type":     "password",
+		"password": []interface{}{
+			map[string]interface{}{"value": "secret"},
+		},
+	})
+	otp := schema.TestResourceDataRaw(t, credentialResource.Schema, map[string]interface{}{
+		"realm_id":      testAccRealm.Realm,
+		"user_id":       user,
+		"type":          "otp",
+		"user_label":    "authenticator",
+		"move_to_first": true,
+		"otp": []interface{}{
+			map[string]interface{}{"secret": "12345678901234567890"},
+		},
+	})
+
+	for _, data := range []*schema.ResourceData{password, otp} {
+		if diags := credentialResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+			t.Fatalf("unable to create credential: %v", diags)
+		}
+	}
+
+	credentials, err := keycloakClient.GetUserCredentials(testCtx, testAccRealm.Realm, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(credentials) != 2 || credentials[0].Type != "otp" || credentials[0].UserLabel != "authenticator" || credentials[1].Type != "password" {
+		t.Fatalf("unexpected credentials %+v %+v", credentials[0], credentials[1])
+	}
+	if credentials[0].SecretData != "" {
+		t.Error("expected the secret not to be returned")
+	}
+
+	// moving the password first out of band is detected as drift
+	if err := keycloakClient.MoveUserCredentialToFirst(testCtx, testAccRealm.Realm, user, credentials[1].Id); err != nil {
+		t.Fatal(err)
+	}
+	if diags := credentialResource.ReadContext(testCtx, otp, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if otp.Get("move_to_first").(bool) {
+		t.Error("expected move_to_first to be false once the credential was moved down")
+	}
+
+	// removing the otp credentials through the reset resource removes it from state
+	resetResource := testAccProvider.ResourcesMap["keycloak_user_credentials_reset"]
+	reset := schema.TestResourceDataRaw(t, resetResource.Schema, map[string]interface{}{
+		"realm_id":         testAccRealm.Realm,
+		"user_id":          user,
+		"credential_types": []interface{}{"otp"},
+	})
+	if diags := resetResource.CreateContext(testCtx, reset, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if reset.Get("removed_credential_ids").(*schema.Set).Len() != 1 {
+		t.Errorf("expected a single removed credential, got %v", reset.Get("removed_credential_ids"))
+	}
+
+	if diags := credentialResource.ReadContext(testCtx, otp, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if otp.Id() != "" {
+		t.Error("expected otp credential to be removed from state")
+	}
+
+	if diags := credentialResource.DeleteContext(testCtx, password, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	credentials, err = keycloakClient.GetUserCredentials(testCtx, testAccRealm.Realm, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(credentials) != 0 {
+		t.Errorf("expected no credentials left, got %d", len(credentials))
+	}
+}
+
+func createTestUser(t *testing.T, username string) string {
+	t.Helper()
+
+	userResource := testAccProvider.ResourcesMap["keycloak_user"]
+	data := schema.TestResourceDataRaw(t, userResource.Schema, map[string]interface{}{
+		"realm_id": testAccRealm.Realm,
+		"username": username,
+	})
+
+	if diags := userResource.CreateContext(testCtx, data, keycloakClient); diags.HasError() {
+		t.Fatalf("unable to create user: %v", diags)
+	}
+
+	t.Cleanup(func() {
+		_ = keycloakClient.DeleteUser(testCtx, testAccRealm.Realm, data.Id())
+	})
+
+	return data.Id()
+}
+
+func testAccCheckKeycloakUserCredentialPosition(resourceName string, position int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId, userId, credentialId, err := parseUserCredentialId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		credentials, err := keycloakClient.GetUserCredentials(testCtx, realmId, userId)
+		if err != nil {
+			return err
+		}
+
+		for i, credential := range credentials {
+			if credential.Id == credentialId {
+				if i != position {
+					return fmt.Errorf("expected credential %s to be at position %d, got %d", credentialId, position, i)
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("credential %s not found", credentialId)
+	}
+}
+
+func testAccCheckKeycloakUserOtpCredentialExists(resourceName string, expected keycloak.OtpCredentialData) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId, userId, credentialId, err := parseUserCredentialId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		credential, err := keycloakClient.GetUserCredential(testCtx, realmId, userId, credentialId)
+		if err != nil {
+			return err
+		}
+
+		if credential.Type != "otp" {
+			return fmt.Errorf("expected credential %s to be of type otp, got %s", credentialId, credential.Type)
+		}
+
+		var credentialData keycloak.OtpCredentialData
+		err = json.Unmarshal([]byte(credential.CredentialData), &credentialData)
+		if err != nil {
+			return fmt.Errorf("unable to parse the credential data of credential %s: %s", credentialId, err)
+		}
+
+		// the counter is only meaningful for hotp credentials
+		credentialData.Counter = expected.Counter
+		if credentialData != expected {
+			return fmt.Errorf("expected credential %s to have credential data %+v, got %+v", credentialId, expected, credentialData)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakUserCredentialDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_user_credential" {
+				continue
+			}
+
+			realmId, userId, credentialId, err := parseUserCredentialId(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			credential, _ := keycloakClient.GetUserCredential(testCtx, realmId, userId, credentialId)
+			if credential != nil {
+				return fmt.Errorf("credential %s still exists", credentialId)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakUserCredential_basic(username, label string, moveToFirst bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_user" "user" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+}
+
+resource "keycloak_user_credential" "password" {
+	realm_id = data.keycloak_realm.realm.id
+	user_id  = keycloak_user.user.id
+	type     = "password"
+
+	password {
+		value = "My password"
+	}
+}
+
+resource "keycloak_user_credential" "otp" {
+	realm_id      = data.keycloak_realm.realm.id
+	user_id       = keycloak_user.user.id
+	type          = "otp"
+	user_label    = "%s"
+	move_to_first = %t
+
+	otp {
+		secret = "12345678901234567890"
+	}
+
+	depends_on = [keycloak_user_credential.password]
+}
+	`, testAccRealm.Realm, username, label, moveToFirst)
+}
+
+func testKeycloakUserCredential_otpSettings(username string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_user" "user" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+}
+
+resource "keycloak_user_credential" "otp" {
+	realm_id   = data.keycloak_realm.realm.id
+	user_id    = keycloak_user.user.id
+	type       = "otp"
+	user_label = "authenticator"
+
+	otp {
+		secret    = "12345678901234567890"
+		algorithm = "HmacSHA256"
+		digits    = 8
+		period    = 60
+	}
+}
+	`, testAccRealm.Realm, username)
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// resourceKeycloakUserCredentialsReset removes every credential of the given types when it is created, for example to
+// force a user to enroll OTP again. Changing triggers runs the removal again, destroying the resource does nothing.
+func resourceKeycloakUserCredentialsReset() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakUserCredentialsResetCreate,
+		ReadContext:   resourceKeycloakUserCredentialsResetRead,
+		DeleteContext: resourceKeycloakUserCredentialsResetDelete,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"credential_types": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that remove the credentials again whenever they change.",
+			},
+			"removed_credential_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKeycloakUserCredentialsResetCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	userId := data.Get("user_id").(string)
+
+	removedCredentialIds := make([]string, 0)
+	for _, credentialType := range data.Get("credential_types").(*schema.Set).List() {
+		removed, err := keycloakClient.DeleteUserCredentialsByType(ctx, realmId, userId, credentialType.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		removedCredentialIds = append(removedCredentialIds, removed...)
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s/%s", realmId, userId, id.UniqueId()))
+	data.Set("removed_credential_ids", removedCredentialIds)
+
+	return nil
+}
+
+func resourceKeycloakUserCredentialsResetRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	_, err := keycloakClient.GetUser(ctx, data.Get("realm_id").(string), data.Get("user_id").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakUserCredentialsResetDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
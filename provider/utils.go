@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -13,6 +16,13 @@ import (
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
 
+// searchId identifies a data source by its search parameters, the same search results in the same id
+func searchId(realmId string, parameters ...string) string {
+	hash := sha1.Sum([]byte(strings.Join(parameters, "\n")))
+
+	return fmt.Sprintf("%s/%s", realmId, hex.EncodeToString(hash[:]))
+}
+
 func keys(data map[string]string) []string {
 	var result []string
 	for k := range data {